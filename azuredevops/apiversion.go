@@ -0,0 +1,301 @@
+package azuredevops
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// defaultAPIVersions lists the api-version used for each resource, keyed by
+// "area/resource". Keys are matched case-insensitively.
+var defaultAPIVersions = map[string]string{
+	"build/builds":                    "5.1-preview.1",
+	"build/builds.queue":              "5.1-preview.5", // Builds.Queue uses a newer preview than List
+	"build/definitions":               "5.1-preview.1",
+	"core/teams":                      "5.1-preview.1",
+	"git/annotatedTags":               "5.1-preview.1",
+	"git/blobs":                       "5.1",
+	"git/changes":                     "5.1-preview.1",
+	"git/cherryPicks":                 "5.1-preview.1",
+	"git/commits":                     "5.1",
+	"git/diffs":                       "5.1",
+	"git/forks":                       "5.1-preview.1",
+	"git/importRequests":              "5.1-preview.1",
+	"git/items":                       "5.1",
+	"git/pullRequestCommentLikes":     "5.1-preview.1",
+	"git/pullRequestCommits":          "5.1-preview.1",
+	"git/pullRequestIterationChanges": "5.1",
+	"git/pullRequestIterations":       "5.1",
+	"git/pullRequestReviewers":        "5.1",
+	"git/pullRequests":                "5.1-preview.1",
+	"git/pullRequestStatuses":         "5.1-preview.1",
+	"git/pullRequestThreadComments":   "5.1-preview.1",
+	"git/pullRequestThreads":          "5.1-preview.1",
+	"git/pushes":                      "5.1",
+	"git/refs":                        "5.1-preview.1",
+	"git/repositories":                "5.1-preview.1",
+	"git/reverts":                     "5.1-preview.1",
+	"git/stats":                       "5.1",
+	"git/statuses":                    "5.1-preview.1",
+	"git/trees":                       "5.1",
+	"graph/descriptors":               "5.1-preview.1",
+	"graph/users":                     "5.1-preview.1",
+	"policy/evaluations":              "5.1-preview",
+	"test/results":                    "4.1",
+	"test/runs":                       "4.1",
+	"wit/comments":                    "5.1-preview.3",
+	"wit/workItems":                   "5.1-preview.1",
+	"work/boards":                     "5.1-preview.1",
+	"work/deliveryTimeline":           "5.1-preview.1",
+	"work/iterations":                 "5.1-preview.1",
+	"work/iterationWorkItems":         "5.1-preview.1",
+	"work/plans":                      "5.1-preview.1",
+}
+
+// APIVersions is a registry of the api-version requested for each REST
+// resource, keyed by area and resource name as reported by the ResourceAreas
+// and OPTIONS discovery endpoints. It is safe for concurrent use.
+type APIVersions struct {
+	mu       sync.RWMutex
+	versions map[string]string
+}
+
+// NewAPIVersions returns a registry populated with the default api-version
+// of every resource used by the client.
+func NewAPIVersions() *APIVersions {
+	v := &APIVersions{versions: make(map[string]string, len(defaultAPIVersions))}
+	for key, version := range defaultAPIVersions {
+		v.versions[strings.ToLower(key)] = version
+	}
+	return v
+}
+
+func apiVersionKey(area, resource string) string {
+	return strings.ToLower(area + "/" + resource)
+}
+
+// Get returns the api-version registered for a resource, or an empty string
+// if the resource is unknown.
+func (v *APIVersions) Get(area, resource string) string {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	return v.versions[apiVersionKey(area, resource)]
+}
+
+// Set overrides the api-version requested for a resource, such as
+// Set("git", "refs", "6.0").
+func (v *APIVersions) Set(area, resource, version string) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.versions[apiVersionKey(area, resource)] = version
+}
+
+// apiVersion returns the api-version to request for a resource. Resources
+// missing from a caller supplied registry use their default version.
+func (c *Client) apiVersion(area, resource string) string {
+	if c.APIVersions != nil {
+		if version := c.APIVersions.Get(area, resource); version != "" {
+			return version
+		}
+	}
+	for key, version := range defaultAPIVersions {
+		if strings.EqualFold(key, area+"/"+resource) {
+			return version
+		}
+	}
+	return ""
+}
+
+// APIResourceLocation describes a REST resource and the versions of it
+// supported by a server.
+type APIResourceLocation struct {
+	ID              *string `json:"id,omitempty"`
+	Area            *string `json:"area,omitempty"`
+	ResourceName    *string `json:"resourceName,omitempty"`
+	RouteTemplate   *string `json:"routeTemplate,omitempty"`
+	ResourceVersion *int    `json:"resourceVersion,omitempty"`
+	MinVersion      *string `json:"minVersion,omitempty"`
+	MaxVersion      *string `json:"maxVersion,omitempty"`
+	ReleasedVersion *string `json:"releasedVersion,omitempty"`
+}
+
+// APIResourceLocationsResponse describes the OPTIONS discovery response
+type APIResourceLocationsResponse struct {
+	Count     int                    `json:"count"`
+	Locations []*APIResourceLocation `json:"value"`
+}
+
+// ListResourceLocations returns the REST resources supported by the server,
+// using the OPTIONS discovery endpoint.
+func (c *Client) ListResourceLocations(ctx context.Context, owner string) ([]*APIResourceLocation, *Response, error) {
+	URL := fmt.Sprintf("%s/_apis/", owner)
+
+	req, err := c.NewRequest("OPTIONS", URL, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	r := new(APIResourceLocationsResponse)
+	resp, err := c.Execute(ctx, req, r)
+
+	return r.Locations, resp, err
+}
+
+// DiscoverAPIVersions updates the APIVersions registry with the versions
+// supported by the server. Resources which the server supports only at an
+// older version than registered are downgraded to the newest version the
+// server offers. Newer server versions are not adopted, since the client's
+// types are written against the registered versions.
+func (c *Client) DiscoverAPIVersions(ctx context.Context, owner string) error {
+	locations, _, err := c.ListResourceLocations(ctx, owner)
+	if err != nil {
+		return err
+	}
+	if c.APIVersions == nil {
+		c.APIVersions = NewAPIVersions()
+	}
+
+	for _, l := range locations {
+		current := c.APIVersions.Get(l.GetArea(), l.GetResourceName())
+		if current == "" || l.GetMaxVersion() == "" {
+			continue
+		}
+		if compareAPIVersions(current, l.GetMaxVersion()) <= 0 {
+			continue
+		}
+		version := l.GetMaxVersion()
+		if compareAPIVersions(version, l.GetReleasedVersion()) > 0 {
+			version = fmt.Sprintf("%s-preview.%d", version, l.GetResourceVersion())
+		}
+		c.APIVersions.Set(l.GetArea(), l.GetResourceName(), version)
+	}
+	return nil
+}
+
+// versionOutOfRangeTypeKey is the exception reported by servers which do not
+// support the api-version of a request.
+const versionOutOfRangeTypeKey = "VssVersionOutOfRangeException"
+
+// supportedVersionPattern extracts the latest api-version supported by a
+// server from the message of a VssVersionOutOfRangeException, e.g. "The
+// requested REST API version of 5.1 is out of range for this server. The
+// latest REST API version this server supports is 5.0."
+var supportedVersionPattern = regexp.MustCompile(`supports is ([0-9]+(\.[0-9]+)?)`)
+
+// compareAPIVersions compares the numeric parts of two api-version values,
+// ignoring any -preview suffix. It returns -1, 0 or 1.
+func compareAPIVersions(a, b string) int {
+	pa := strings.Split(strings.SplitN(a, "-", 2)[0], ".")
+	pb := strings.Split(strings.SplitN(b, "-", 2)[0], ".")
+	for i := 0; i < len(pa) || i < len(pb); i++ {
+		var x, y int
+		if i < len(pa) {
+			x, _ = strconv.Atoi(pa[i])
+		}
+		if i < len(pb) {
+			y, _ = strconv.Atoi(pb[i])
+		}
+		switch {
+		case x < y:
+			return -1
+		case x > y:
+			return 1
+		}
+	}
+	return 0
+}
+
+// clampAPIVersion downgrades version to max when it is newer, keeping any
+// -preview suffix so that preview resources remain addressable.
+func clampAPIVersion(version, max string) string {
+	if max == "" || compareAPIVersions(version, max) <= 0 {
+		return version
+	}
+	if i := strings.Index(version, "-"); i >= 0 {
+		return max + version[i:]
+	}
+	return max
+}
+
+// maxAPIVersion returns the highest api-version the client will send, which
+// is the lower of MaxAPIVersion and any limit reported by the server.
+func (c *Client) maxAPIVersion() string {
+	c.versionMu.Lock()
+	server := c.serverAPIVersion
+	c.versionMu.Unlock()
+
+	switch {
+	case c.MaxAPIVersion == "":
+		return server
+	case server == "" || compareAPIVersions(c.MaxAPIVersion, server) <= 0:
+		return c.MaxAPIVersion
+	}
+	return server
+}
+
+// limitAPIVersion downgrades the api-version query parameter of u to the
+// highest version the client will send. It reports whether u was changed.
+func (c *Client) limitAPIVersion(u *url.URL) bool {
+	max := c.maxAPIVersion()
+	if max == "" {
+		return false
+	}
+	q := u.Query()
+	version := q.Get("api-version")
+	if version == "" {
+		return false
+	}
+	if clamped := clampAPIVersion(version, max); clamped != version {
+		q.Set("api-version", clamped)
+		u.RawQuery = q.Encode()
+		return true
+	}
+	return false
+}
+
+// fallbackAPIVersion handles a 400 response to req. If the server rejected
+// the api-version of req as too new, the latest version it supports is
+// remembered and req is sent again with that version. Otherwise resp is
+// returned unchanged.
+func (c *Client) fallbackAPIVersion(ctx context.Context, req *http.Request, resp *http.Response) (*http.Response, error) {
+	data, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = ioutil.NopCloser(bytes.NewReader(data))
+	if err != nil {
+		return resp, nil
+	}
+
+	errResp := &ErrorResponse{}
+	if json.Unmarshal(data, errResp) != nil || errResp.TypeKey != versionOutOfRangeTypeKey {
+		return resp, nil
+	}
+	m := supportedVersionPattern.FindStringSubmatch(errResp.Message)
+	if m == nil || (req.Body != nil && req.GetBody == nil) {
+		return resp, nil
+	}
+
+	c.versionMu.Lock()
+	if c.serverAPIVersion == "" || compareAPIVersions(m[1], c.serverAPIVersion) < 0 {
+		c.serverAPIVersion = m[1]
+	}
+	c.versionMu.Unlock()
+
+	if !c.limitAPIVersion(req.URL) {
+		return resp, nil
+	}
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		req.Body = body
+	}
+	return c.send(ctx, req)
+}
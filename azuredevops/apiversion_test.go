@@ -0,0 +1,78 @@
+package azuredevops_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/mcdafydd/go-azuredevops/azuredevops"
+)
+
+func TestAPIVersions_override(t *testing.T) {
+	c, mux, _, teardown := setup()
+	defer teardown()
+
+	c.APIVersions.Set("git", "refs", "6.0")
+
+	mux.HandleFunc(gitRefsListURL, func(w http.ResponseWriter, r *http.Request) {
+		if got, want := r.URL.Query().Get("api-version"), "6.0"; got != want {
+			t.Errorf("api-version is %s, want %s", got, want)
+		}
+		fmt.Fprint(w, `{}`)
+	})
+
+	_, _, err := c.Git.ListRefs(context.Background(), "o", "p", "r", "heads", nil)
+	if err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+}
+
+func TestAPIVersions_defaults(t *testing.T) {
+	v := azuredevops.NewAPIVersions()
+
+	if got, want := v.Get("git", "pullRequestIterations"), "5.1"; got != want {
+		t.Errorf("Get(git, pullRequestIterations) is %s, want %s", got, want)
+	}
+	if got, want := v.Get("Git", "PullRequestIterations"), "5.1"; got != want {
+		t.Errorf("keys are not case insensitive: got %s, want %s", got, want)
+	}
+	if got := v.Get("git", "unknown"); got != "" {
+		t.Errorf("Get(git, unknown) is %s, want empty string", got)
+	}
+}
+
+func TestClient_DiscoverAPIVersions(t *testing.T) {
+	c, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/o/_apis/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "OPTIONS")
+		fmt.Fprint(w, `{
+			"count": 3,
+			"value": [
+				{"area": "git", "resourceName": "refs", "resourceVersion": 1, "minVersion": "1.0", "maxVersion": "5.0", "releasedVersion": "5.0"},
+				{"area": "git", "resourceName": "pullRequestThreads", "resourceVersion": 1, "minVersion": "3.0", "maxVersion": "5.0", "releasedVersion": "4.1"},
+				{"area": "build", "resourceName": "builds", "resourceVersion": 5, "minVersion": "2.0", "maxVersion": "6.0", "releasedVersion": "6.0"}
+			]
+		}`)
+	})
+
+	if err := c.DiscoverAPIVersions(context.Background(), "o"); err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+
+	tt := []struct {
+		area, resource, want string
+	}{
+		{area: "git", resource: "refs", want: "5.0"},
+		{area: "git", resource: "pullRequestThreads", want: "5.0-preview.1"},
+		{area: "build", resource: "builds", want: "5.1-preview.1"},
+		{area: "git", resource: "repositories", want: "5.1-preview.1"},
+	}
+	for _, tc := range tt {
+		if got := c.APIVersions.Get(tc.area, tc.resource); got != tc.want {
+			t.Errorf("APIVersions.Get(%s, %s) is %s, want %s", tc.area, tc.resource, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,402 @@
+package azuredevops
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultOAuthTokenURL is the token endpoint for Azure DevOps OAuth apps
+	DefaultOAuthTokenURL = "https://app.vssps.visualstudio.com/oauth2/token"
+	// DefaultAzureADAuthorityURL is the base URL of the Azure AD token endpoint
+	DefaultAzureADAuthorityURL = "https://login.microsoftonline.com/"
+	// AzureDevOpsResourceScope is the Azure AD scope granting access to Azure
+	// DevOps, which is identified by its well-known application ID.
+	AzureDevOpsResourceScope = "499b84ac-1321-427f-aa17-267ca6975798/.default"
+
+	// expiryDelta is how long before its expiry a token is considered stale,
+	// so that requests aren't sent with a token about to expire.
+	expiryDelta = time.Minute
+)
+
+// Token is a credential used to authenticate API requests.
+type Token struct {
+	// Type is the authorization scheme, either "Basic" for personal access
+	// tokens or "Bearer" for OAuth and Azure AD access tokens.
+	Type string
+	// AccessToken is the personal access token or bearer token.
+	AccessToken string
+	// Expiry is when the token expires. A zero value never expires.
+	Expiry time.Time
+}
+
+// Valid reports whether the token is set and not about to expire.
+func (t *Token) Valid() bool {
+	if t == nil || t.AccessToken == "" {
+		return false
+	}
+	return t.Expiry.IsZero() || time.Now().Add(expiryDelta).Before(t.Expiry)
+}
+
+// SetAuthHeader sets the Authorization header of r using the token. Basic
+// tokens are sent as the password of an empty username, as required for
+// personal access tokens.
+func (t *Token) SetAuthHeader(r *http.Request) {
+	if strings.EqualFold(t.Type, "Basic") {
+		r.Header.Set("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(":"+t.AccessToken)))
+		return
+	}
+	r.Header.Set("Authorization", "Bearer "+t.AccessToken)
+}
+
+// TokenSource supplies the tokens used to authenticate requests.
+type TokenSource interface {
+	Token(ctx context.Context) (*Token, error)
+}
+
+// RefreshableTokenSource is a TokenSource which caches tokens, and can
+// discard a cached token after the service rejects it.
+type RefreshableTokenSource interface {
+	TokenSource
+	// Invalidate discards tok if it is the cached token, so that the next
+	// call to Token fetches a new one.
+	Invalidate(tok *Token)
+}
+
+type staticTokenSource struct {
+	token *Token
+}
+
+func (s *staticTokenSource) Token(ctx context.Context) (*Token, error) {
+	return s.token, nil
+}
+
+// PersonalAccessToken returns a TokenSource which always supplies the given
+// personal access token.
+func PersonalAccessToken(pat string) TokenSource {
+	return &staticTokenSource{&Token{Type: "Basic", AccessToken: pat}}
+}
+
+// StaticBearerToken returns a TokenSource which always supplies the given
+// bearer token, such as an OAuth access token obtained elsewhere.
+func StaticBearerToken(token string) TokenSource {
+	return &staticTokenSource{&Token{Type: "Bearer", AccessToken: token}}
+}
+
+// TokenError reports a failure to obtain a token from a token endpoint.
+type TokenError struct {
+	Response    *http.Response // HTTP response of the token endpoint
+	StatusCode  int            // HTTP status code of the response
+	ErrorCode   string         // OAuth error code, such as invalid_grant
+	Description string         // error description reported by the endpoint
+}
+
+func (e *TokenError) Error() string {
+	msg := fmt.Sprintf("azuredevops: token request failed with status %d", e.StatusCode)
+	if e.ErrorCode != "" {
+		msg = fmt.Sprintf("%s: %s", msg, e.ErrorCode)
+	}
+	if e.Description != "" {
+		msg = fmt.Sprintf("%s: %s", msg, e.Description)
+	}
+	return msg
+}
+
+// Is reports whether the token was refused because the credentials were
+// rejected, which matches ErrUnauthorized.
+func (e *TokenError) Is(target error) bool {
+	if target != ErrUnauthorized {
+		return false
+	}
+	switch e.ErrorCode {
+	case "invalid_client", "invalid_grant", "unauthorized_client":
+		return true
+	}
+	return e.StatusCode == http.StatusUnauthorized || e.StatusCode == http.StatusForbidden
+}
+
+// tokenResponse describes the body returned by OAuth token endpoints.
+type tokenResponse struct {
+	AccessToken      string      `json:"access_token"`
+	TokenType        string      `json:"token_type"`
+	RefreshToken     string      `json:"refresh_token"`
+	ExpiresIn        interface{} `json:"expires_in"` // number, or string for Azure DevOps OAuth
+	Error            string      `json:"error"`
+	ErrorDescription string      `json:"error_description"`
+}
+
+// requestToken posts form to a token endpoint and decodes the token response.
+func requestToken(ctx context.Context, client *http.Client, tokenURL string, form url.Values) (*tokenResponse, error) {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	req, err := http.NewRequest("POST", tokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := client.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	tr := &tokenResponse{}
+	decErr := json.Unmarshal(body, tr)
+	if resp.StatusCode < 200 || resp.StatusCode > 299 || tr.Error != "" {
+		return nil, &TokenError{
+			Response:    resp,
+			StatusCode:  resp.StatusCode,
+			ErrorCode:   tr.Error,
+			Description: tr.ErrorDescription,
+		}
+	}
+	if decErr != nil {
+		return nil, decErr
+	}
+	if tr.AccessToken == "" {
+		return nil, &TokenError{Response: resp, StatusCode: resp.StatusCode, Description: "response contains no access_token"}
+	}
+	return tr, nil
+}
+
+// token converts the response into a bearer Token.
+func (tr *tokenResponse) token() *Token {
+	tok := &Token{Type: "Bearer", AccessToken: tr.AccessToken}
+	var seconds int64
+	switch v := tr.ExpiresIn.(type) {
+	case float64:
+		seconds = int64(v)
+	case string:
+		seconds, _ = strconv.ParseInt(v, 10, 64)
+	}
+	if seconds > 0 {
+		tok.Expiry = time.Now().Add(time.Duration(seconds) * time.Second)
+	}
+	return tok
+}
+
+// tokenCache holds the current token of a refreshing TokenSource.
+type tokenCache struct {
+	mu    sync.Mutex
+	token *Token
+}
+
+// get returns the cached token if it is valid, or a new token from fetch.
+func (c *tokenCache) get(fetch func() (*Token, error)) (*Token, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.token.Valid() {
+		return c.token, nil
+	}
+	tok, err := fetch()
+	if err != nil {
+		return nil, err
+	}
+	c.token = tok
+	return tok, nil
+}
+
+func (c *tokenCache) invalidate(tok *Token) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.token == tok {
+		c.token = nil
+	}
+}
+
+// OAuthTokenSource supplies access tokens for an Azure DevOps OAuth app,
+// redeeming a refresh token when the current access token expires. Azure
+// DevOps issues a new refresh token with each access token, which replaces
+// RefreshToken.
+// https://docs.microsoft.com/en-us/azure/devops/integrate/get-started/authentication/oauth?view=azure-devops#refresh-an-expired-access-token
+type OAuthTokenSource struct {
+	// ClientSecret is the client secret of the registered app.
+	ClientSecret string
+	// RefreshToken is the refresh token obtained when the user authorized
+	// the app.
+	RefreshToken string
+	// RedirectURI is the callback URL registered for the app.
+	RedirectURI string
+	// TokenURL is the token endpoint. Defaults to DefaultOAuthTokenURL.
+	TokenURL string
+	// HTTPClient is used for token requests. Defaults to http.DefaultClient.
+	HTTPClient *http.Client
+
+	cache tokenCache
+}
+
+// Token returns the current access token, refreshing it if it has expired.
+func (s *OAuthTokenSource) Token(ctx context.Context) (*Token, error) {
+	return s.cache.get(func() (*Token, error) {
+		tokenURL := s.TokenURL
+		if tokenURL == "" {
+			tokenURL = DefaultOAuthTokenURL
+		}
+		form := url.Values{
+			"client_assertion_type": {"urn:ietf:params:oauth:client-assertion-type:jwt-bearer"},
+			"client_assertion":      {s.ClientSecret},
+			"grant_type":            {"refresh_token"},
+			"assertion":             {s.RefreshToken},
+			"redirect_uri":          {s.RedirectURI},
+		}
+		tr, err := requestToken(ctx, s.HTTPClient, tokenURL, form)
+		if err != nil {
+			return nil, err
+		}
+		if tr.RefreshToken != "" {
+			s.RefreshToken = tr.RefreshToken
+		}
+		return tr.token(), nil
+	})
+}
+
+// Invalidate discards tok so that the next call to Token refreshes it.
+func (s *OAuthTokenSource) Invalidate(tok *Token) {
+	s.cache.invalidate(tok)
+}
+
+// ClientCredentialsTokenSource supplies Azure AD access tokens for a service
+// principal, using the OAuth 2.0 client credentials grant.
+// https://docs.microsoft.com/en-us/azure/active-directory/develop/v2-oauth2-client-creds-grant-flow
+type ClientCredentialsTokenSource struct {
+	// TenantID is the Azure AD tenant of the service principal.
+	TenantID string
+	// ClientID is the application ID of the service principal.
+	ClientID string
+	// ClientSecret is a client secret of the service principal.
+	ClientSecret string
+	// Scope is the requested scope. Defaults to AzureDevOpsResourceScope.
+	Scope string
+	// TokenURL is the token endpoint. Defaults to the v2.0 endpoint of
+	// TenantID under DefaultAzureADAuthorityURL.
+	TokenURL string
+	// HTTPClient is used for token requests. Defaults to http.DefaultClient.
+	HTTPClient *http.Client
+
+	cache tokenCache
+}
+
+// Token returns the current access token, requesting a new one if it has
+// expired.
+func (s *ClientCredentialsTokenSource) Token(ctx context.Context) (*Token, error) {
+	return s.cache.get(func() (*Token, error) {
+		tokenURL := s.TokenURL
+		if tokenURL == "" {
+			tokenURL = fmt.Sprintf("%s%s/oauth2/v2.0/token", DefaultAzureADAuthorityURL, url.PathEscape(s.TenantID))
+		}
+		scope := s.Scope
+		if scope == "" {
+			scope = AzureDevOpsResourceScope
+		}
+		form := url.Values{
+			"grant_type":    {"client_credentials"},
+			"client_id":     {s.ClientID},
+			"client_secret": {s.ClientSecret},
+			"scope":         {scope},
+		}
+		tr, err := requestToken(ctx, s.HTTPClient, tokenURL, form)
+		if err != nil {
+			return nil, err
+		}
+		return tr.token(), nil
+	})
+}
+
+// Invalidate discards tok so that the next call to Token requests a new one.
+func (s *ClientCredentialsTokenSource) Invalidate(tok *Token) {
+	s.cache.invalidate(tok)
+}
+
+// TokenTransport is an http.RoundTripper that authenticates all requests
+// with tokens from Source. If the service rejects a request with 401 and
+// Source is a RefreshableTokenSource, the token is invalidated and the request
+// is sent once more with a fresh token.
+//
+// Requests are sent with the X-TFS-FedAuthRedirect header, so that rejected
+// credentials result in a 401 response rather than a redirect to a sign-in
+// page.
+type TokenTransport struct {
+	Source TokenSource
+
+	// Transport is the underlying HTTP transport to use when making requests.
+	// It will default to http.DefaultTransport if nil.
+	Transport http.RoundTripper
+}
+
+// RoundTrip implements the RoundTripper interface.
+func (t *TokenTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.Source == nil {
+		return nil, fmt.Errorf("azuredevops: TokenTransport has no Source")
+	}
+	tok, err := t.Source.Token(req.Context())
+	if err != nil {
+		return nil, err
+	}
+	resp, err := t.transport().RoundTrip(authorizedRequest(req, tok))
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+
+	source, ok := t.Source.(RefreshableTokenSource)
+	if !ok || (req.Body != nil && req.GetBody == nil) {
+		return resp, nil
+	}
+	source.Invalidate(tok)
+	tok, err = source.Token(req.Context())
+	if err != nil {
+		resp.Body.Close()
+		return nil, err
+	}
+	retry := authorizedRequest(req, tok)
+	if req.GetBody != nil {
+		if retry.Body, err = req.GetBody(); err != nil {
+			resp.Body.Close()
+			return nil, err
+		}
+	}
+	resp.Body.Close()
+	return t.transport().RoundTrip(retry)
+}
+
+// authorizedRequest returns a copy of req authenticated with tok. The
+// RoundTripper specification forbids modifying the original request.
+func authorizedRequest(req *http.Request, tok *Token) *http.Request {
+	req2 := new(http.Request)
+	*req2 = *req
+	req2.Header = make(http.Header, len(req.Header)+2)
+	for k, s := range req.Header {
+		req2.Header[k] = append([]string(nil), s...)
+	}
+	req2.Header.Set("X-TFS-FedAuthRedirect", "Suppress")
+	tok.SetAuthHeader(req2)
+	return req2
+}
+
+// Client returns an *http.Client that makes requests authenticated with
+// tokens from Source.
+func (t *TokenTransport) Client() *http.Client {
+	return &http.Client{Transport: t}
+}
+
+func (t *TokenTransport) transport() http.RoundTripper {
+	if t.Transport != nil {
+		return t.Transport
+	}
+	return http.DefaultTransport
+}
@@ -0,0 +1,161 @@
+package azuredevops_test
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mcdafydd/go-azuredevops/azuredevops"
+)
+
+func TestBasicAuthTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, pass, ok := r.BasicAuth()
+		if !ok || user != "user" || pass != "secret" {
+			t.Errorf("BasicAuth = %q, %q, %v; want user, secret, true", user, pass, ok)
+		}
+	}))
+	defer server.Close()
+
+	tp := azuredevops.BasicAuthTransport{Username: "user", Password: "secret"}
+	resp, err := tp.Client().Get(server.URL)
+	if err != nil {
+		t.Fatalf("Get returned error: %v", err)
+	}
+	resp.Body.Close()
+
+	// OTP is deprecated and ignored, as it was before it was deprecated.
+	tp.OTP = "123456"
+	resp, err = tp.Client().Get(server.URL)
+	if err != nil {
+		t.Fatalf("Get with OTP set returned error: %v", err)
+	}
+	resp.Body.Close()
+}
+
+func TestTokenTransport_personalAccessToken(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		want := "Basic " + base64.StdEncoding.EncodeToString([]byte(":pat"))
+		if got := r.Header.Get("Authorization"); got != want {
+			t.Errorf("Authorization = %q, want %q", got, want)
+		}
+		if got := r.Header.Get("X-TFS-FedAuthRedirect"); got != "Suppress" {
+			t.Errorf("X-TFS-FedAuthRedirect = %q, want Suppress", got)
+		}
+	}))
+	defer server.Close()
+
+	tp := azuredevops.TokenTransport{Source: azuredevops.PersonalAccessToken("pat")}
+	resp, err := tp.Client().Get(server.URL)
+	if err != nil {
+		t.Fatalf("Get returned error: %v", err)
+	}
+	resp.Body.Close()
+}
+
+func TestTokenTransport_clientCredentialsRefreshOn401(t *testing.T) {
+	issued := 0
+	tokenServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		if got := r.PostForm.Get("grant_type"); got != "client_credentials" {
+			t.Errorf("grant_type = %q, want client_credentials", got)
+		}
+		if got := r.PostForm.Get("scope"); got != azuredevops.AzureDevOpsResourceScope {
+			t.Errorf("scope = %q, want %q", got, azuredevops.AzureDevOpsResourceScope)
+		}
+		issued++
+		fmt.Fprintf(w, `{"token_type":"Bearer","expires_in":3599,"access_token":"token-%d"}`, issued)
+	}))
+	defer tokenServer.Close()
+
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	calls := 0
+	mux.HandleFunc("/o/p/_apis/build/builds", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if r.Header.Get("Authorization") == "Bearer token-1" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if got, want := r.Header.Get("Authorization"), "Bearer token-2"; got != want {
+			t.Errorf("Authorization = %q, want %q", got, want)
+		}
+		fmt.Fprint(w, `{"count":0,"value":[]}`)
+	})
+
+	source := &azuredevops.ClientCredentialsTokenSource{
+		TenantID:     "tenant",
+		ClientID:     "id",
+		ClientSecret: "secret",
+		TokenURL:     tokenServer.URL,
+	}
+	tp := &azuredevops.TokenTransport{Source: source}
+	authClient, _ := azuredevops.NewClient(tp.Client())
+	authClient.BaseURL = client.BaseURL
+
+	for i := 0; i < 2; i++ {
+		if _, _, err := authClient.Builds.List(context.Background(), "o", "p", nil); err != nil {
+			t.Fatalf("Builds.List returned error: %v", err)
+		}
+	}
+	if issued != 2 {
+		t.Errorf("Issued %d tokens, want 2", issued)
+	}
+	if calls != 3 {
+		t.Errorf("Server received %d calls, want 3", calls)
+	}
+}
+
+func TestOAuthTokenSource(t *testing.T) {
+	tokenServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		if got := r.PostForm.Get("assertion"); got != "refresh-1" {
+			t.Errorf("assertion = %q, want refresh-1", got)
+		}
+		fmt.Fprint(w, `{"access_token":"access","token_type":"jwt-bearer","expires_in":"3599","refresh_token":"refresh-2"}`)
+	}))
+	defer tokenServer.Close()
+
+	source := &azuredevops.OAuthTokenSource{
+		ClientSecret: "secret",
+		RefreshToken: "refresh-1",
+		TokenURL:     tokenServer.URL,
+	}
+	tok, err := source.Token(context.Background())
+	if err != nil {
+		t.Fatalf("Token returned error: %v", err)
+	}
+	if tok.AccessToken != "access" || !tok.Valid() {
+		t.Errorf("Token = %+v, want valid access token", tok)
+	}
+	if source.RefreshToken != "refresh-2" {
+		t.Errorf("RefreshToken = %q, want refresh-2", source.RefreshToken)
+	}
+}
+
+func TestClientCredentialsTokenSource_rejected(t *testing.T) {
+	tokenServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		fmt.Fprint(w, `{"error":"invalid_client","error_description":"AADSTS7000215: Invalid client secret provided."}`)
+	}))
+	defer tokenServer.Close()
+
+	source := &azuredevops.ClientCredentialsTokenSource{ClientID: "id", TokenURL: tokenServer.URL}
+	_, err := source.Token(context.Background())
+
+	var tokenErr *azuredevops.TokenError
+	if !errors.As(err, &tokenErr) {
+		t.Fatalf("Expected *TokenError, got %v", err)
+	}
+	if tokenErr.ErrorCode != "invalid_client" {
+		t.Errorf("ErrorCode = %q, want invalid_client", tokenErr.ErrorCode)
+	}
+	if !errors.Is(err, azuredevops.ErrUnauthorized) {
+		t.Error("Expected error to match ErrUnauthorized")
+	}
+}
@@ -47,6 +47,70 @@ func (a *AgentPoolQueue) GetURL() string {
 	return *a.URL
 }
 
+// GetArea returns the Area field if it's non-nil, zero value otherwise.
+func (a *APIResourceLocation) GetArea() string {
+	if a == nil || a.Area == nil {
+		return ""
+	}
+	return *a.Area
+}
+
+// GetID returns the ID field if it's non-nil, zero value otherwise.
+func (a *APIResourceLocation) GetID() string {
+	if a == nil || a.ID == nil {
+		return ""
+	}
+	return *a.ID
+}
+
+// GetMaxVersion returns the MaxVersion field if it's non-nil, zero value otherwise.
+func (a *APIResourceLocation) GetMaxVersion() string {
+	if a == nil || a.MaxVersion == nil {
+		return ""
+	}
+	return *a.MaxVersion
+}
+
+// GetMinVersion returns the MinVersion field if it's non-nil, zero value otherwise.
+func (a *APIResourceLocation) GetMinVersion() string {
+	if a == nil || a.MinVersion == nil {
+		return ""
+	}
+	return *a.MinVersion
+}
+
+// GetReleasedVersion returns the ReleasedVersion field if it's non-nil, zero value otherwise.
+func (a *APIResourceLocation) GetReleasedVersion() string {
+	if a == nil || a.ReleasedVersion == nil {
+		return ""
+	}
+	return *a.ReleasedVersion
+}
+
+// GetResourceName returns the ResourceName field if it's non-nil, zero value otherwise.
+func (a *APIResourceLocation) GetResourceName() string {
+	if a == nil || a.ResourceName == nil {
+		return ""
+	}
+	return *a.ResourceName
+}
+
+// GetResourceVersion returns the ResourceVersion field if it's non-nil, zero value otherwise.
+func (a *APIResourceLocation) GetResourceVersion() int {
+	if a == nil || a.ResourceVersion == nil {
+		return 0
+	}
+	return *a.ResourceVersion
+}
+
+// GetRouteTemplate returns the RouteTemplate field if it's non-nil, zero value otherwise.
+func (a *APIResourceLocation) GetRouteTemplate() string {
+	if a == nil || a.RouteTemplate == nil {
+		return ""
+	}
+	return *a.RouteTemplate
+}
+
 // GetAllowedMappings returns the AllowedMappings field if it's non-nil, zero value otherwise.
 func (b *Board) GetAllowedMappings() string {
 	if b == nil || b.AllowedMappings == nil {
@@ -567,6 +631,14 @@ func (b *BuildDefinition) GetRepository() *BuildRepository {
 	return b.Repository
 }
 
+// GetContinuationToken returns the ContinuationToken field if it's non-nil, zero value otherwise.
+func (b *BuildDefinitionsListOptions) GetContinuationToken() string {
+	if b == nil || b.ContinuationToken == nil {
+		return ""
+	}
+	return *b.ContinuationToken
+}
+
 // GetIncludeAllProperties returns the IncludeAllProperties field if it's non-nil, zero value otherwise.
 func (b *BuildDefinitionsListOptions) GetIncludeAllProperties() bool {
 	if b == nil || b.IncludeAllProperties == nil {
@@ -583,6 +655,14 @@ func (b *BuildDefinitionsListOptions) GetPath() string {
 	return *b.Path
 }
 
+// GetTop returns the Top field if it's non-nil, zero value otherwise.
+func (b *BuildDefinitionsListOptions) GetTop() int {
+	if b == nil || b.Top == nil {
+		return 0
+	}
+	return *b.Top
+}
+
 // GetName returns the Name field if it's non-nil, zero value otherwise.
 func (b *BuildDemand) GetName() string {
 	if b == nil || b.Name == nil {
@@ -927,6 +1007,22 @@ func (c *Comment) GetPublishedDate() *Time {
 	return c.PublishedDate
 }
 
+// GetFirstComparingIteration returns the FirstComparingIteration field if it's non-nil, zero value otherwise.
+func (c *CommentIterationContext) GetFirstComparingIteration() int {
+	if c == nil || c.FirstComparingIteration == nil {
+		return 0
+	}
+	return *c.FirstComparingIteration
+}
+
+// GetSecondComparingIteration returns the SecondComparingIteration field if it's non-nil, zero value otherwise.
+func (c *CommentIterationContext) GetSecondComparingIteration() int {
+	if c == nil || c.SecondComparingIteration == nil {
+		return 0
+	}
+	return *c.SecondComparingIteration
+}
+
 // GetLine returns the Line field if it's non-nil, zero value otherwise.
 func (c *CommentPosition) GetLine() int {
 	if c == nil || c.Line == nil {
@@ -943,6 +1039,62 @@ func (c *CommentPosition) GetOffset() int {
 	return *c.Offset
 }
 
+// GetFirstComparingIteration returns the FirstComparingIteration field if it's non-nil, zero value otherwise.
+func (c *CommentTrackingCriteria) GetFirstComparingIteration() int {
+	if c == nil || c.FirstComparingIteration == nil {
+		return 0
+	}
+	return *c.FirstComparingIteration
+}
+
+// GetOrigFilePath returns the OrigFilePath field if it's non-nil, zero value otherwise.
+func (c *CommentTrackingCriteria) GetOrigFilePath() string {
+	if c == nil || c.OrigFilePath == nil {
+		return ""
+	}
+	return *c.OrigFilePath
+}
+
+// GetOrigLeftFileEnd returns the OrigLeftFileEnd field.
+func (c *CommentTrackingCriteria) GetOrigLeftFileEnd() *CommentPosition {
+	if c == nil {
+		return nil
+	}
+	return c.OrigLeftFileEnd
+}
+
+// GetOrigLeftFileStart returns the OrigLeftFileStart field.
+func (c *CommentTrackingCriteria) GetOrigLeftFileStart() *CommentPosition {
+	if c == nil {
+		return nil
+	}
+	return c.OrigLeftFileStart
+}
+
+// GetOrigRightFileEnd returns the OrigRightFileEnd field.
+func (c *CommentTrackingCriteria) GetOrigRightFileEnd() *CommentPosition {
+	if c == nil {
+		return nil
+	}
+	return c.OrigRightFileEnd
+}
+
+// GetOrigRightFileStart returns the OrigRightFileStart field.
+func (c *CommentTrackingCriteria) GetOrigRightFileStart() *CommentPosition {
+	if c == nil {
+		return nil
+	}
+	return c.OrigRightFileStart
+}
+
+// GetSecondComparingIteration returns the SecondComparingIteration field if it's non-nil, zero value otherwise.
+func (c *CommentTrackingCriteria) GetSecondComparingIteration() int {
+	if c == nil || c.SecondComparingIteration == nil {
+		return 0
+	}
+	return *c.SecondComparingIteration
+}
+
 // GetCommentID returns the CommentID field if it's non-nil, zero value otherwise.
 func (c *CommentVersionRef) GetCommentID() int {
 	if c == nil || c.CommentID == nil {
@@ -1055,6 +1207,14 @@ func (d *DeliveryTeam) GetName() string {
 	return *d.Name
 }
 
+// GetInnerException returns the InnerException field.
+func (e *ErrorResponse) GetInnerException() *ErrorResponse {
+	if e == nil {
+		return nil
+	}
+	return e.InnerException
+}
+
 // GetArtifactID returns the ArtifactID field if it's non-nil, zero value otherwise.
 func (f *Favourite) GetArtifactID() string {
 	if f == nil || f.ArtifactID == nil {
@@ -1159,233 +1319,769 @@ func (f *FileContentMetadata) GetVSLink() string {
 	return *f.VSLink
 }
 
-// GetChangeID returns the ChangeID field if it's non-nil, zero value otherwise.
-func (g *GitChange) GetChangeID() int {
-	if g == nil || g.ChangeID == nil {
-		return 0
+// GetMessage returns the Message field if it's non-nil, zero value otherwise.
+func (g *GitAnnotatedTag) GetMessage() string {
+	if g == nil || g.Message == nil {
+		return ""
 	}
-	return *g.ChangeID
+	return *g.Message
 }
 
-// GetChangeType returns the ChangeType field if it's non-nil, zero value otherwise.
-func (g *GitChange) GetChangeType() string {
-	if g == nil || g.ChangeType == nil {
+// GetName returns the Name field if it's non-nil, zero value otherwise.
+func (g *GitAnnotatedTag) GetName() string {
+	if g == nil || g.Name == nil {
 		return ""
 	}
-	return *g.ChangeType
+	return *g.Name
 }
 
-// GetItem returns the Item field.
-func (g *GitChange) GetItem() *GitItem {
-	if g == nil {
-		return nil
+// GetObjectID returns the ObjectID field if it's non-nil, zero value otherwise.
+func (g *GitAnnotatedTag) GetObjectID() string {
+	if g == nil || g.ObjectID == nil {
+		return ""
 	}
-	return g.Item
+	return *g.ObjectID
 }
 
-// GetNewContent returns the NewContent field.
-func (g *GitChange) GetNewContent() *ItemContent {
+// GetTaggedBy returns the TaggedBy field.
+func (g *GitAnnotatedTag) GetTaggedBy() *GitUserDate {
 	if g == nil {
 		return nil
 	}
-	return g.NewContent
+	return g.TaggedBy
 }
 
-// GetNewContentTemplate returns the NewContentTemplate field.
-func (g *GitChange) GetNewContentTemplate() *GitTemplate {
+// GetTaggedObject returns the TaggedObject field.
+func (g *GitAnnotatedTag) GetTaggedObject() *GitObject {
 	if g == nil {
 		return nil
 	}
-	return g.NewContentTemplate
+	return g.TaggedObject
 }
 
-// GetOriginalPath returns the OriginalPath field if it's non-nil, zero value otherwise.
-func (g *GitChange) GetOriginalPath() string {
-	if g == nil || g.OriginalPath == nil {
+// GetURL returns the URL field if it's non-nil, zero value otherwise.
+func (g *GitAnnotatedTag) GetURL() string {
+	if g == nil || g.URL == nil {
 		return ""
 	}
-	return *g.OriginalPath
+	return *g.URL
 }
 
-// GetSourceServerItem returns the SourceServerItem field if it's non-nil, zero value otherwise.
-func (g *GitChange) GetSourceServerItem() string {
-	if g == nil || g.SourceServerItem == nil {
-		return ""
+// GetConflict returns the Conflict field if it's non-nil, zero value otherwise.
+func (g *GitAsyncRefOperationDetail) GetConflict() bool {
+	if g == nil || g.Conflict == nil {
+		return false
 	}
-	return *g.SourceServerItem
+	return *g.Conflict
 }
 
-// GetURL returns the URL field if it's non-nil, zero value otherwise.
-func (g *GitChange) GetURL() string {
-	if g == nil || g.URL == nil {
+// GetCurrentCommitID returns the CurrentCommitID field if it's non-nil, zero value otherwise.
+func (g *GitAsyncRefOperationDetail) GetCurrentCommitID() string {
+	if g == nil || g.CurrentCommitID == nil {
 		return ""
 	}
-	return *g.URL
+	return *g.CurrentCommitID
 }
 
-// GetChangeCounts returns the ChangeCounts field if it's non-nil, zero value otherwise.
-func (g *GitCommitChanges) GetChangeCounts() map[string]int {
-	if g == nil || g.ChangeCounts == nil {
-		return map[string]int{}
+// GetFailureMessage returns the FailureMessage field if it's non-nil, zero value otherwise.
+func (g *GitAsyncRefOperationDetail) GetFailureMessage() string {
+	if g == nil || g.FailureMessage == nil {
+		return ""
 	}
-	return *g.ChangeCounts
+	return *g.FailureMessage
 }
 
-// GetAuthor returns the Author field.
-func (g *GitCommitRef) GetAuthor() *GitUserDate {
+// GetProgress returns the Progress field.
+func (g *GitAsyncRefOperationDetail) GetProgress() *float64 {
 	if g == nil {
 		return nil
 	}
-	return g.Author
+	return g.Progress
 }
 
-// GetChangeCounts returns the ChangeCounts field if it's non-nil, zero value otherwise.
-func (g *GitCommitRef) GetChangeCounts() map[string]int {
-	if g == nil || g.ChangeCounts == nil {
-		return map[string]int{}
+// GetStatus returns the Status field if it's non-nil, zero value otherwise.
+func (g *GitAsyncRefOperationDetail) GetStatus() string {
+	if g == nil || g.Status == nil {
+		return ""
 	}
-	return *g.ChangeCounts
+	return *g.Status
 }
 
-// GetChanges returns the Changes field.
-func (g *GitCommitRef) GetChanges() *GitChange {
-	if g == nil {
-		return nil
+// GetTimedout returns the Timedout field if it's non-nil, zero value otherwise.
+func (g *GitAsyncRefOperationDetail) GetTimedout() bool {
+	if g == nil || g.Timedout == nil {
+		return false
 	}
-	return g.Changes
+	return *g.Timedout
 }
 
-// GetComment returns the Comment field if it's non-nil, zero value otherwise.
-func (g *GitCommitRef) GetComment() string {
-	if g == nil || g.Comment == nil {
+// GetGeneratedRefName returns the GeneratedRefName field if it's non-nil, zero value otherwise.
+func (g *GitAsyncRefOperationParameters) GetGeneratedRefName() string {
+	if g == nil || g.GeneratedRefName == nil {
 		return ""
 	}
-	return *g.Comment
+	return *g.GeneratedRefName
 }
 
-// GetCommentTruncated returns the CommentTruncated field if it's non-nil, zero value otherwise.
-func (g *GitCommitRef) GetCommentTruncated() bool {
-	if g == nil || g.CommentTruncated == nil {
-		return false
+// GetOntoRefName returns the OntoRefName field if it's non-nil, zero value otherwise.
+func (g *GitAsyncRefOperationParameters) GetOntoRefName() string {
+	if g == nil || g.OntoRefName == nil {
+		return ""
 	}
-	return *g.CommentTruncated
+	return *g.OntoRefName
 }
 
-// GetCommitID returns the CommitID field if it's non-nil, zero value otherwise.
-func (g *GitCommitRef) GetCommitID() string {
-	if g == nil || g.CommitID == nil {
-		return ""
+// GetRepository returns the Repository field.
+func (g *GitAsyncRefOperationParameters) GetRepository() *GitRepository {
+	if g == nil {
+		return nil
 	}
-	return *g.CommitID
+	return g.Repository
 }
 
-// GetCommitter returns the Committer field.
-func (g *GitCommitRef) GetCommitter() *GitUserDate {
+// GetSource returns the Source field.
+func (g *GitAsyncRefOperationParameters) GetSource() *GitAsyncRefOperationSource {
 	if g == nil {
 		return nil
 	}
-	return g.Committer
+	return g.Source
+}
+
+// GetPullRequestID returns the PullRequestID field if it's non-nil, zero value otherwise.
+func (g *GitAsyncRefOperationSource) GetPullRequestID() int {
+	if g == nil || g.PullRequestID == nil {
+		return 0
+	}
+	return *g.PullRequestID
 }
 
 // GetLinks returns the Links field if it's non-nil, zero value otherwise.
-func (g *GitCommitRef) GetLinks() map[string]Link {
+func (g *GitBlobRef) GetLinks() map[string]Link {
 	if g == nil || g.Links == nil {
 		return map[string]Link{}
 	}
 	return *g.Links
 }
 
-// GetPush returns the Push field.
-func (g *GitCommitRef) GetPush() *GitPushRef {
-	if g == nil {
-		return nil
+// GetObjectID returns the ObjectID field if it's non-nil, zero value otherwise.
+func (g *GitBlobRef) GetObjectID() string {
+	if g == nil || g.ObjectID == nil {
+		return ""
 	}
-	return g.Push
+	return *g.ObjectID
 }
 
-// GetRemoteURL returns the RemoteURL field if it's non-nil, zero value otherwise.
-func (g *GitCommitRef) GetRemoteURL() string {
-	if g == nil || g.RemoteURL == nil {
-		return ""
+// GetSize returns the Size field if it's non-nil, zero value otherwise.
+func (g *GitBlobRef) GetSize() int64 {
+	if g == nil || g.Size == nil {
+		return 0
 	}
-	return *g.RemoteURL
+	return *g.Size
 }
 
 // GetURL returns the URL field if it's non-nil, zero value otherwise.
-func (g *GitCommitRef) GetURL() string {
+func (g *GitBlobRef) GetURL() string {
 	if g == nil || g.URL == nil {
 		return ""
 	}
 	return *g.URL
 }
 
-// GetWorkItems returns the WorkItems field.
-func (g *GitCommitRef) GetWorkItems() *ResourceRef {
-	if g == nil {
-		return nil
-	}
-	return g.WorkItems
-}
-
-// GetCommitID returns the CommitID field if it's non-nil, zero value otherwise.
-func (g *GitItem) GetCommitID() string {
-	if g == nil || g.CommitID == nil {
-		return ""
+// GetAheadCount returns the AheadCount field if it's non-nil, zero value otherwise.
+func (g *GitBranchStats) GetAheadCount() int {
+	if g == nil || g.AheadCount == nil {
+		return 0
 	}
-	return *g.CommitID
+	return *g.AheadCount
 }
 
-// GetContent returns the Content field if it's non-nil, zero value otherwise.
-func (g *GitItem) GetContent() string {
-	if g == nil || g.Content == nil {
-		return ""
+// GetBehindCount returns the BehindCount field if it's non-nil, zero value otherwise.
+func (g *GitBranchStats) GetBehindCount() int {
+	if g == nil || g.BehindCount == nil {
+		return 0
 	}
-	return *g.Content
+	return *g.BehindCount
 }
 
-// GetContentMetadata returns the ContentMetadata field.
-func (g *GitItem) GetContentMetadata() *FileContentMetadata {
+// GetCommit returns the Commit field.
+func (g *GitBranchStats) GetCommit() *GitCommitRef {
 	if g == nil {
 		return nil
 	}
-	return g.ContentMetadata
-}
-
-// GetGitObjectType returns the GitObjectType field if it's non-nil, zero value otherwise.
-func (g *GitItem) GetGitObjectType() string {
-	if g == nil || g.GitObjectType == nil {
-		return ""
-	}
-	return *g.GitObjectType
+	return g.Commit
 }
 
-// GetIsFolder returns the IsFolder field if it's non-nil, zero value otherwise.
-func (g *GitItem) GetIsFolder() bool {
-	if g == nil || g.IsFolder == nil {
+// GetIsBaseVersion returns the IsBaseVersion field if it's non-nil, zero value otherwise.
+func (g *GitBranchStats) GetIsBaseVersion() bool {
+	if g == nil || g.IsBaseVersion == nil {
 		return false
 	}
-	return *g.IsFolder
+	return *g.IsBaseVersion
 }
 
-// GetIsSymLink returns the IsSymLink field if it's non-nil, zero value otherwise.
-func (g *GitItem) GetIsSymLink() bool {
-	if g == nil || g.IsSymLink == nil {
-		return false
+// GetName returns the Name field if it's non-nil, zero value otherwise.
+func (g *GitBranchStats) GetName() string {
+	if g == nil || g.Name == nil {
+		return ""
 	}
-	return *g.IsSymLink
+	return *g.Name
 }
 
-// GetLatestProcessedChange returns the LatestProcessedChange field.
-func (g *GitItem) GetLatestProcessedChange() *GitCommitRef {
+// GetBaseVersion returns the BaseVersion field.
+func (g *GitBranchStatsOptions) GetBaseVersion() *GitVersionDescriptor {
 	if g == nil {
 		return nil
 	}
-	return g.LatestProcessedChange
+	return g.BaseVersion
 }
 
-// GetLinks returns the Links field if it's non-nil, zero value otherwise.
-func (g *GitItem) GetLinks() map[string]Link {
-	if g == nil || g.Links == nil {
+// GetChangeID returns the ChangeID field if it's non-nil, zero value otherwise.
+func (g *GitChange) GetChangeID() int {
+	if g == nil || g.ChangeID == nil {
+		return 0
+	}
+	return *g.ChangeID
+}
+
+// GetChangeType returns the ChangeType field if it's non-nil, zero value otherwise.
+func (g *GitChange) GetChangeType() string {
+	if g == nil || g.ChangeType == nil {
+		return ""
+	}
+	return *g.ChangeType
+}
+
+// GetItem returns the Item field.
+func (g *GitChange) GetItem() *GitItem {
+	if g == nil {
+		return nil
+	}
+	return g.Item
+}
+
+// GetNewContent returns the NewContent field.
+func (g *GitChange) GetNewContent() *ItemContent {
+	if g == nil {
+		return nil
+	}
+	return g.NewContent
+}
+
+// GetNewContentTemplate returns the NewContentTemplate field.
+func (g *GitChange) GetNewContentTemplate() *GitTemplate {
+	if g == nil {
+		return nil
+	}
+	return g.NewContentTemplate
+}
+
+// GetOriginalPath returns the OriginalPath field if it's non-nil, zero value otherwise.
+func (g *GitChange) GetOriginalPath() string {
+	if g == nil || g.OriginalPath == nil {
+		return ""
+	}
+	return *g.OriginalPath
+}
+
+// GetSourceServerItem returns the SourceServerItem field if it's non-nil, zero value otherwise.
+func (g *GitChange) GetSourceServerItem() string {
+	if g == nil || g.SourceServerItem == nil {
+		return ""
+	}
+	return *g.SourceServerItem
+}
+
+// GetURL returns the URL field if it's non-nil, zero value otherwise.
+func (g *GitChange) GetURL() string {
+	if g == nil || g.URL == nil {
+		return ""
+	}
+	return *g.URL
+}
+
+// GetCherryPickID returns the CherryPickID field if it's non-nil, zero value otherwise.
+func (g *GitCherryPick) GetCherryPickID() int {
+	if g == nil || g.CherryPickID == nil {
+		return 0
+	}
+	return *g.CherryPickID
+}
+
+// GetDetailedStatus returns the DetailedStatus field.
+func (g *GitCherryPick) GetDetailedStatus() *GitAsyncRefOperationDetail {
+	if g == nil {
+		return nil
+	}
+	return g.DetailedStatus
+}
+
+// GetLinks returns the Links field if it's non-nil, zero value otherwise.
+func (g *GitCherryPick) GetLinks() map[string]Link {
+	if g == nil || g.Links == nil {
+		return map[string]Link{}
+	}
+	return *g.Links
+}
+
+// GetParameters returns the Parameters field.
+func (g *GitCherryPick) GetParameters() *GitAsyncRefOperationParameters {
+	if g == nil {
+		return nil
+	}
+	return g.Parameters
+}
+
+// GetStatus returns the Status field if it's non-nil, zero value otherwise.
+func (g *GitCherryPick) GetStatus() string {
+	if g == nil || g.Status == nil {
+		return ""
+	}
+	return *g.Status
+}
+
+// GetURL returns the URL field if it's non-nil, zero value otherwise.
+func (g *GitCherryPick) GetURL() string {
+	if g == nil || g.URL == nil {
+		return ""
+	}
+	return *g.URL
+}
+
+// GetChangeCounts returns the ChangeCounts field if it's non-nil, zero value otherwise.
+func (g *GitCommitChanges) GetChangeCounts() map[string]int {
+	if g == nil || g.ChangeCounts == nil {
+		return map[string]int{}
+	}
+	return *g.ChangeCounts
+}
+
+// GetAheadCount returns the AheadCount field if it's non-nil, zero value otherwise.
+func (g *GitCommitDiffs) GetAheadCount() int {
+	if g == nil || g.AheadCount == nil {
+		return 0
+	}
+	return *g.AheadCount
+}
+
+// GetAllChangesIncluded returns the AllChangesIncluded field if it's non-nil, zero value otherwise.
+func (g *GitCommitDiffs) GetAllChangesIncluded() bool {
+	if g == nil || g.AllChangesIncluded == nil {
+		return false
+	}
+	return *g.AllChangesIncluded
+}
+
+// GetBaseCommit returns the BaseCommit field if it's non-nil, zero value otherwise.
+func (g *GitCommitDiffs) GetBaseCommit() string {
+	if g == nil || g.BaseCommit == nil {
+		return ""
+	}
+	return *g.BaseCommit
+}
+
+// GetBehindCount returns the BehindCount field if it's non-nil, zero value otherwise.
+func (g *GitCommitDiffs) GetBehindCount() int {
+	if g == nil || g.BehindCount == nil {
+		return 0
+	}
+	return *g.BehindCount
+}
+
+// GetChangeCounts returns the ChangeCounts field if it's non-nil, zero value otherwise.
+func (g *GitCommitDiffs) GetChangeCounts() map[string]int {
+	if g == nil || g.ChangeCounts == nil {
+		return map[string]int{}
+	}
+	return *g.ChangeCounts
+}
+
+// GetCommonCommit returns the CommonCommit field if it's non-nil, zero value otherwise.
+func (g *GitCommitDiffs) GetCommonCommit() string {
+	if g == nil || g.CommonCommit == nil {
+		return ""
+	}
+	return *g.CommonCommit
+}
+
+// GetTargetCommit returns the TargetCommit field if it's non-nil, zero value otherwise.
+func (g *GitCommitDiffs) GetTargetCommit() string {
+	if g == nil || g.TargetCommit == nil {
+		return ""
+	}
+	return *g.TargetCommit
+}
+
+// GetDiffCommonCommit returns the DiffCommonCommit field if it's non-nil, zero value otherwise.
+func (g *GitCommitDiffsOptions) GetDiffCommonCommit() bool {
+	if g == nil || g.DiffCommonCommit == nil {
+		return false
+	}
+	return *g.DiffCommonCommit
+}
+
+// GetSkip returns the Skip field if it's non-nil, zero value otherwise.
+func (g *GitCommitDiffsOptions) GetSkip() int {
+	if g == nil || g.Skip == nil {
+		return 0
+	}
+	return *g.Skip
+}
+
+// GetTop returns the Top field if it's non-nil, zero value otherwise.
+func (g *GitCommitDiffsOptions) GetTop() int {
+	if g == nil || g.Top == nil {
+		return 0
+	}
+	return *g.Top
+}
+
+// GetChangeCount returns the ChangeCount field if it's non-nil, zero value otherwise.
+func (g *GitCommitGetOptions) GetChangeCount() int {
+	if g == nil || g.ChangeCount == nil {
+		return 0
+	}
+	return *g.ChangeCount
+}
+
+// GetAuthor returns the Author field.
+func (g *GitCommitRef) GetAuthor() *GitUserDate {
+	if g == nil {
+		return nil
+	}
+	return g.Author
+}
+
+// GetChangeCounts returns the ChangeCounts field if it's non-nil, zero value otherwise.
+func (g *GitCommitRef) GetChangeCounts() map[string]int {
+	if g == nil || g.ChangeCounts == nil {
+		return map[string]int{}
+	}
+	return *g.ChangeCounts
+}
+
+// GetComment returns the Comment field if it's non-nil, zero value otherwise.
+func (g *GitCommitRef) GetComment() string {
+	if g == nil || g.Comment == nil {
+		return ""
+	}
+	return *g.Comment
+}
+
+// GetCommentTruncated returns the CommentTruncated field if it's non-nil, zero value otherwise.
+func (g *GitCommitRef) GetCommentTruncated() bool {
+	if g == nil || g.CommentTruncated == nil {
+		return false
+	}
+	return *g.CommentTruncated
+}
+
+// GetCommitID returns the CommitID field if it's non-nil, zero value otherwise.
+func (g *GitCommitRef) GetCommitID() string {
+	if g == nil || g.CommitID == nil {
+		return ""
+	}
+	return *g.CommitID
+}
+
+// GetCommitter returns the Committer field.
+func (g *GitCommitRef) GetCommitter() *GitUserDate {
+	if g == nil {
+		return nil
+	}
+	return g.Committer
+}
+
+// GetLinks returns the Links field if it's non-nil, zero value otherwise.
+func (g *GitCommitRef) GetLinks() map[string]Link {
+	if g == nil || g.Links == nil {
+		return map[string]Link{}
+	}
+	return *g.Links
+}
+
+// GetPush returns the Push field.
+func (g *GitCommitRef) GetPush() *GitPushRef {
+	if g == nil {
+		return nil
+	}
+	return g.Push
+}
+
+// GetRemoteURL returns the RemoteURL field if it's non-nil, zero value otherwise.
+func (g *GitCommitRef) GetRemoteURL() string {
+	if g == nil || g.RemoteURL == nil {
+		return ""
+	}
+	return *g.RemoteURL
+}
+
+// GetURL returns the URL field if it's non-nil, zero value otherwise.
+func (g *GitCommitRef) GetURL() string {
+	if g == nil || g.URL == nil {
+		return ""
+	}
+	return *g.URL
+}
+
+// GetWorkItems returns the WorkItems field.
+func (g *GitCommitRef) GetWorkItems() *ResourceRef {
+	if g == nil {
+		return nil
+	}
+	return g.WorkItems
+}
+
+// GetCreatedDate returns the CreatedDate field.
+func (g *GitDeletedRepository) GetCreatedDate() *Time {
+	if g == nil {
+		return nil
+	}
+	return g.CreatedDate
+}
+
+// GetDeletedBy returns the DeletedBy field.
+func (g *GitDeletedRepository) GetDeletedBy() *IdentityRef {
+	if g == nil {
+		return nil
+	}
+	return g.DeletedBy
+}
+
+// GetDeletedDate returns the DeletedDate field.
+func (g *GitDeletedRepository) GetDeletedDate() *Time {
+	if g == nil {
+		return nil
+	}
+	return g.DeletedDate
+}
+
+// GetID returns the ID field if it's non-nil, zero value otherwise.
+func (g *GitDeletedRepository) GetID() string {
+	if g == nil || g.ID == nil {
+		return ""
+	}
+	return *g.ID
+}
+
+// GetName returns the Name field if it's non-nil, zero value otherwise.
+func (g *GitDeletedRepository) GetName() string {
+	if g == nil || g.Name == nil {
+		return ""
+	}
+	return *g.Name
+}
+
+// GetProject returns the Project field.
+func (g *GitDeletedRepository) GetProject() *TeamProjectReference {
+	if g == nil {
+		return nil
+	}
+	return g.Project
+}
+
+// GetOverwrite returns the Overwrite field if it's non-nil, zero value otherwise.
+func (g *GitImportGitSource) GetOverwrite() bool {
+	if g == nil || g.Overwrite == nil {
+		return false
+	}
+	return *g.Overwrite
+}
+
+// GetURL returns the URL field if it's non-nil, zero value otherwise.
+func (g *GitImportGitSource) GetURL() string {
+	if g == nil || g.URL == nil {
+		return ""
+	}
+	return *g.URL
+}
+
+// GetDetailedStatus returns the DetailedStatus field.
+func (g *GitImportRequest) GetDetailedStatus() *GitImportStatusDetail {
+	if g == nil {
+		return nil
+	}
+	return g.DetailedStatus
+}
+
+// GetImportRequestID returns the ImportRequestID field if it's non-nil, zero value otherwise.
+func (g *GitImportRequest) GetImportRequestID() int {
+	if g == nil || g.ImportRequestID == nil {
+		return 0
+	}
+	return *g.ImportRequestID
+}
+
+// GetLinks returns the Links field if it's non-nil, zero value otherwise.
+func (g *GitImportRequest) GetLinks() map[string]Link {
+	if g == nil || g.Links == nil {
+		return map[string]Link{}
+	}
+	return *g.Links
+}
+
+// GetParameters returns the Parameters field.
+func (g *GitImportRequest) GetParameters() *GitImportRequestParameters {
+	if g == nil {
+		return nil
+	}
+	return g.Parameters
+}
+
+// GetRepository returns the Repository field.
+func (g *GitImportRequest) GetRepository() *GitRepository {
+	if g == nil {
+		return nil
+	}
+	return g.Repository
+}
+
+// GetStatus returns the Status field if it's non-nil, zero value otherwise.
+func (g *GitImportRequest) GetStatus() string {
+	if g == nil || g.Status == nil {
+		return ""
+	}
+	return *g.Status
+}
+
+// GetURL returns the URL field if it's non-nil, zero value otherwise.
+func (g *GitImportRequest) GetURL() string {
+	if g == nil || g.URL == nil {
+		return ""
+	}
+	return *g.URL
+}
+
+// GetDeleteServiceEndpointAfterImportIsDone returns the DeleteServiceEndpointAfterImportIsDone field if it's non-nil, zero value otherwise.
+func (g *GitImportRequestParameters) GetDeleteServiceEndpointAfterImportIsDone() bool {
+	if g == nil || g.DeleteServiceEndpointAfterImportIsDone == nil {
+		return false
+	}
+	return *g.DeleteServiceEndpointAfterImportIsDone
+}
+
+// GetGitSource returns the GitSource field.
+func (g *GitImportRequestParameters) GetGitSource() *GitImportGitSource {
+	if g == nil {
+		return nil
+	}
+	return g.GitSource
+}
+
+// GetServiceEndpointID returns the ServiceEndpointID field if it's non-nil, zero value otherwise.
+func (g *GitImportRequestParameters) GetServiceEndpointID() string {
+	if g == nil || g.ServiceEndpointID == nil {
+		return ""
+	}
+	return *g.ServiceEndpointID
+}
+
+// GetTfvcSource returns the TfvcSource field.
+func (g *GitImportRequestParameters) GetTfvcSource() *GitImportTfvcSource {
+	if g == nil {
+		return nil
+	}
+	return g.TfvcSource
+}
+
+// GetCurrentStep returns the CurrentStep field if it's non-nil, zero value otherwise.
+func (g *GitImportStatusDetail) GetCurrentStep() int {
+	if g == nil || g.CurrentStep == nil {
+		return 0
+	}
+	return *g.CurrentStep
+}
+
+// GetErrorMessage returns the ErrorMessage field if it's non-nil, zero value otherwise.
+func (g *GitImportStatusDetail) GetErrorMessage() string {
+	if g == nil || g.ErrorMessage == nil {
+		return ""
+	}
+	return *g.ErrorMessage
+}
+
+// GetImportHistory returns the ImportHistory field if it's non-nil, zero value otherwise.
+func (g *GitImportTfvcSource) GetImportHistory() bool {
+	if g == nil || g.ImportHistory == nil {
+		return false
+	}
+	return *g.ImportHistory
+}
+
+// GetImportHistoryMaxDays returns the ImportHistoryMaxDays field if it's non-nil, zero value otherwise.
+func (g *GitImportTfvcSource) GetImportHistoryMaxDays() int {
+	if g == nil || g.ImportHistoryMaxDays == nil {
+		return 0
+	}
+	return *g.ImportHistoryMaxDays
+}
+
+// GetPath returns the Path field if it's non-nil, zero value otherwise.
+func (g *GitImportTfvcSource) GetPath() string {
+	if g == nil || g.Path == nil {
+		return ""
+	}
+	return *g.Path
+}
+
+// GetCommitID returns the CommitID field if it's non-nil, zero value otherwise.
+func (g *GitItem) GetCommitID() string {
+	if g == nil || g.CommitID == nil {
+		return ""
+	}
+	return *g.CommitID
+}
+
+// GetContent returns the Content field if it's non-nil, zero value otherwise.
+func (g *GitItem) GetContent() string {
+	if g == nil || g.Content == nil {
+		return ""
+	}
+	return *g.Content
+}
+
+// GetContentMetadata returns the ContentMetadata field.
+func (g *GitItem) GetContentMetadata() *FileContentMetadata {
+	if g == nil {
+		return nil
+	}
+	return g.ContentMetadata
+}
+
+// GetGitObjectType returns the GitObjectType field if it's non-nil, zero value otherwise.
+func (g *GitItem) GetGitObjectType() string {
+	if g == nil || g.GitObjectType == nil {
+		return ""
+	}
+	return *g.GitObjectType
+}
+
+// GetIsFolder returns the IsFolder field if it's non-nil, zero value otherwise.
+func (g *GitItem) GetIsFolder() bool {
+	if g == nil || g.IsFolder == nil {
+		return false
+	}
+	return *g.IsFolder
+}
+
+// GetIsSymLink returns the IsSymLink field if it's non-nil, zero value otherwise.
+func (g *GitItem) GetIsSymLink() bool {
+	if g == nil || g.IsSymLink == nil {
+		return false
+	}
+	return *g.IsSymLink
+}
+
+// GetLatestProcessedChange returns the LatestProcessedChange field.
+func (g *GitItem) GetLatestProcessedChange() *GitCommitRef {
+	if g == nil {
+		return nil
+	}
+	return g.LatestProcessedChange
+}
+
+// GetLinks returns the Links field if it's non-nil, zero value otherwise.
+func (g *GitItem) GetLinks() map[string]Link {
+	if g == nil || g.Links == nil {
 		return map[string]Link{}
 	}
 	return *g.Links
@@ -1423,6 +2119,38 @@ func (g *GitItem) GetURL() string {
 	return *g.URL
 }
 
+// GetVersion returns the Version field.
+func (g *GitItemListOptions) GetVersion() *GitVersionDescriptor {
+	if g == nil {
+		return nil
+	}
+	return g.Version
+}
+
+// GetVersion returns the Version field.
+func (g *GitItemOptions) GetVersion() *GitVersionDescriptor {
+	if g == nil {
+		return nil
+	}
+	return g.Version
+}
+
+// GetObjectID returns the ObjectID field if it's non-nil, zero value otherwise.
+func (g *GitObject) GetObjectID() string {
+	if g == nil || g.ObjectID == nil {
+		return ""
+	}
+	return *g.ObjectID
+}
+
+// GetObjectType returns the ObjectType field if it's non-nil, zero value otherwise.
+func (g *GitObject) GetObjectType() string {
+	if g == nil || g.ObjectType == nil {
+		return ""
+	}
+	return *g.ObjectType
+}
+
 // GetArtifactID returns the ArtifactID field if it's non-nil, zero value otherwise.
 func (g *GitPullRequest) GetArtifactID() string {
 	if g == nil || g.ArtifactID == nil {
@@ -1652,23 +2380,55 @@ func (g *GitPullRequest) GetTitle() string {
 	if g == nil || g.Title == nil {
 		return ""
 	}
-	return *g.Title
+	return *g.Title
+}
+
+// GetURL returns the URL field if it's non-nil, zero value otherwise.
+func (g *GitPullRequest) GetURL() string {
+	if g == nil || g.URL == nil {
+		return ""
+	}
+	return *g.URL
+}
+
+// GetChangeID returns the ChangeID field if it's non-nil, zero value otherwise.
+func (g *GitPullRequestChange) GetChangeID() int {
+	if g == nil || g.ChangeID == nil {
+		return 0
+	}
+	return *g.ChangeID
+}
+
+// GetChangeTrackingID returns the ChangeTrackingID field if it's non-nil, zero value otherwise.
+func (g *GitPullRequestChange) GetChangeTrackingID() int {
+	if g == nil || g.ChangeTrackingID == nil {
+		return 0
+	}
+	return *g.ChangeTrackingID
+}
+
+// GetChangeType returns the ChangeType field if it's non-nil, zero value otherwise.
+func (g *GitPullRequestChange) GetChangeType() string {
+	if g == nil || g.ChangeType == nil {
+		return ""
+	}
+	return *g.ChangeType
+}
+
+// GetItem returns the Item field.
+func (g *GitPullRequestChange) GetItem() *GitItem {
+	if g == nil {
+		return nil
+	}
+	return g.Item
 }
 
-// GetURL returns the URL field if it's non-nil, zero value otherwise.
-func (g *GitPullRequest) GetURL() string {
-	if g == nil || g.URL == nil {
+// GetOriginalPath returns the OriginalPath field if it's non-nil, zero value otherwise.
+func (g *GitPullRequestChange) GetOriginalPath() string {
+	if g == nil || g.OriginalPath == nil {
 		return ""
 	}
-	return *g.URL
-}
-
-// GetChangeTrackingID returns the ChangeTrackingID field if it's non-nil, zero value otherwise.
-func (g *GitPullRequestChange) GetChangeTrackingID() int {
-	if g == nil || g.ChangeTrackingID == nil {
-		return 0
-	}
-	return *g.ChangeTrackingID
+	return *g.OriginalPath
 }
 
 // GetID returns the ID field if it's non-nil, zero value otherwise.
@@ -1711,6 +2471,14 @@ func (g *GitPullRequestCommentThread) GetPublishedDate() *Time {
 	return g.PublishedDate
 }
 
+// GetPullRequestContext returns the PullRequestContext field.
+func (g *GitPullRequestCommentThread) GetPullRequestContext() *GitPullRequestThreadContext {
+	if g == nil {
+		return nil
+	}
+	return g.PullRequestContext
+}
+
 // GetPullRequestThreadContext returns the PullRequestThreadContext field.
 func (g *GitPullRequestCommentThread) GetPullRequestThreadContext() *GitPullRequestCommentThreadContext {
 	if g == nil {
@@ -1727,6 +2495,14 @@ func (g *GitPullRequestCommentThread) GetStatus() string {
 	return *g.Status
 }
 
+// GetThreadContext returns the ThreadContext field.
+func (g *GitPullRequestCommentThread) GetThreadContext() *GitPullRequestCommentThreadContext {
+	if g == nil {
+		return nil
+	}
+	return g.ThreadContext
+}
+
 // GetFilePath returns the FilePath field if it's non-nil, zero value otherwise.
 func (g *GitPullRequestCommentThreadContext) GetFilePath() string {
 	if g == nil || g.FilePath == nil {
@@ -1975,6 +2751,30 @@ func (g *GitPullRequestStatus) GetIterationID() int {
 	return *g.IterationID
 }
 
+// GetChangeTrackingID returns the ChangeTrackingID field if it's non-nil, zero value otherwise.
+func (g *GitPullRequestThreadContext) GetChangeTrackingID() int {
+	if g == nil || g.ChangeTrackingID == nil {
+		return 0
+	}
+	return *g.ChangeTrackingID
+}
+
+// GetIterationContext returns the IterationContext field.
+func (g *GitPullRequestThreadContext) GetIterationContext() *CommentIterationContext {
+	if g == nil {
+		return nil
+	}
+	return g.IterationContext
+}
+
+// GetTrackingCriteria returns the TrackingCriteria field.
+func (g *GitPullRequestThreadContext) GetTrackingCriteria() *CommentTrackingCriteria {
+	if g == nil {
+		return nil
+	}
+	return g.TrackingCriteria
+}
+
 // GetComment returns the Comment field.
 func (g *GitPullRequestWithComment) GetComment() *Comment {
 	if g == nil {
@@ -2039,6 +2839,30 @@ func (g *GitPush) GetURL() string {
 	return *g.URL
 }
 
+// GetIncludeCommits returns the IncludeCommits field if it's non-nil, zero value otherwise.
+func (g *GitPushGetOptions) GetIncludeCommits() int {
+	if g == nil || g.IncludeCommits == nil {
+		return 0
+	}
+	return *g.IncludeCommits
+}
+
+// GetSkip returns the Skip field if it's non-nil, zero value otherwise.
+func (g *GitPushListOptions) GetSkip() int {
+	if g == nil || g.Skip == nil {
+		return 0
+	}
+	return *g.Skip
+}
+
+// GetTop returns the Top field if it's non-nil, zero value otherwise.
+func (g *GitPushListOptions) GetTop() int {
+	if g == nil || g.Top == nil {
+		return 0
+	}
+	return *g.Top
+}
+
 // GetRepository returns the Repository field.
 func (g *GitPushRef) GetRepository() *GitRepository {
 	if g == nil {
@@ -2047,6 +2871,38 @@ func (g *GitPushRef) GetRepository() *GitRepository {
 	return g.Repository
 }
 
+// GetCompareVersion returns the CompareVersion field.
+func (g *GitQueryCommitsCriteria) GetCompareVersion() *GitVersionDescriptor {
+	if g == nil {
+		return nil
+	}
+	return g.CompareVersion
+}
+
+// GetItemVersion returns the ItemVersion field.
+func (g *GitQueryCommitsCriteria) GetItemVersion() *GitVersionDescriptor {
+	if g == nil {
+		return nil
+	}
+	return g.ItemVersion
+}
+
+// GetSkip returns the Skip field if it's non-nil, zero value otherwise.
+func (g *GitQueryCommitsCriteria) GetSkip() int {
+	if g == nil || g.Skip == nil {
+		return 0
+	}
+	return *g.Skip
+}
+
+// GetTop returns the Top field if it's non-nil, zero value otherwise.
+func (g *GitQueryCommitsCriteria) GetTop() int {
+	if g == nil || g.Top == nil {
+		return 0
+	}
+	return *g.Top
+}
+
 // GetCreator returns the Creator field.
 func (g *GitRef) GetCreator() *IdentityRef {
 	if g == nil {
@@ -2159,6 +3015,78 @@ func (g *GitRefUpdate) GetRepositoryID() string {
 	return *g.RepositoryID
 }
 
+// GetCustomMessage returns the CustomMessage field if it's non-nil, zero value otherwise.
+func (g *GitRefUpdateResult) GetCustomMessage() string {
+	if g == nil || g.CustomMessage == nil {
+		return ""
+	}
+	return *g.CustomMessage
+}
+
+// GetIsLocked returns the IsLocked field if it's non-nil, zero value otherwise.
+func (g *GitRefUpdateResult) GetIsLocked() bool {
+	if g == nil || g.IsLocked == nil {
+		return false
+	}
+	return *g.IsLocked
+}
+
+// GetName returns the Name field if it's non-nil, zero value otherwise.
+func (g *GitRefUpdateResult) GetName() string {
+	if g == nil || g.Name == nil {
+		return ""
+	}
+	return *g.Name
+}
+
+// GetNewObjectID returns the NewObjectID field if it's non-nil, zero value otherwise.
+func (g *GitRefUpdateResult) GetNewObjectID() string {
+	if g == nil || g.NewObjectID == nil {
+		return ""
+	}
+	return *g.NewObjectID
+}
+
+// GetOldObjectID returns the OldObjectID field if it's non-nil, zero value otherwise.
+func (g *GitRefUpdateResult) GetOldObjectID() string {
+	if g == nil || g.OldObjectID == nil {
+		return ""
+	}
+	return *g.OldObjectID
+}
+
+// GetRejectedBy returns the RejectedBy field if it's non-nil, zero value otherwise.
+func (g *GitRefUpdateResult) GetRejectedBy() string {
+	if g == nil || g.RejectedBy == nil {
+		return ""
+	}
+	return *g.RejectedBy
+}
+
+// GetRepositoryID returns the RepositoryID field if it's non-nil, zero value otherwise.
+func (g *GitRefUpdateResult) GetRepositoryID() string {
+	if g == nil || g.RepositoryID == nil {
+		return ""
+	}
+	return *g.RepositoryID
+}
+
+// GetSuccess returns the Success field if it's non-nil, zero value otherwise.
+func (g *GitRefUpdateResult) GetSuccess() bool {
+	if g == nil || g.Success == nil {
+		return false
+	}
+	return *g.Success
+}
+
+// GetUpdateStatus returns the UpdateStatus field if it's non-nil, zero value otherwise.
+func (g *GitRefUpdateResult) GetUpdateStatus() string {
+	if g == nil || g.UpdateStatus == nil {
+		return ""
+	}
+	return *g.UpdateStatus
+}
+
 // GetDefaultBranch returns the DefaultBranch field if it's non-nil, zero value otherwise.
 func (g *GitRepository) GetDefaultBranch() string {
 	if g == nil || g.DefaultBranch == nil {
@@ -2255,6 +3183,30 @@ func (g *GitRepository) GetWebURL() string {
 	return *g.WebURL
 }
 
+// GetName returns the Name field if it's non-nil, zero value otherwise.
+func (g *GitRepositoryCreateOptions) GetName() string {
+	if g == nil || g.Name == nil {
+		return ""
+	}
+	return *g.Name
+}
+
+// GetParentRepository returns the ParentRepository field.
+func (g *GitRepositoryCreateOptions) GetParentRepository() *GitRepositoryRef {
+	if g == nil {
+		return nil
+	}
+	return g.ParentRepository
+}
+
+// GetProject returns the Project field.
+func (g *GitRepositoryCreateOptions) GetProject() *TeamProjectReference {
+	if g == nil {
+		return nil
+	}
+	return g.Project
+}
+
 // GetCollection returns the Collection field.
 func (g *GitRepositoryRef) GetCollection() *TeamProjectCollectionReference {
 	if g == nil {
@@ -2319,6 +3271,54 @@ func (g *GitRepositoryRef) GetURL() string {
 	return *g.URL
 }
 
+// GetDetailedStatus returns the DetailedStatus field.
+func (g *GitRevert) GetDetailedStatus() *GitAsyncRefOperationDetail {
+	if g == nil {
+		return nil
+	}
+	return g.DetailedStatus
+}
+
+// GetLinks returns the Links field if it's non-nil, zero value otherwise.
+func (g *GitRevert) GetLinks() map[string]Link {
+	if g == nil || g.Links == nil {
+		return map[string]Link{}
+	}
+	return *g.Links
+}
+
+// GetParameters returns the Parameters field.
+func (g *GitRevert) GetParameters() *GitAsyncRefOperationParameters {
+	if g == nil {
+		return nil
+	}
+	return g.Parameters
+}
+
+// GetRevertID returns the RevertID field if it's non-nil, zero value otherwise.
+func (g *GitRevert) GetRevertID() int {
+	if g == nil || g.RevertID == nil {
+		return 0
+	}
+	return *g.RevertID
+}
+
+// GetStatus returns the Status field if it's non-nil, zero value otherwise.
+func (g *GitRevert) GetStatus() string {
+	if g == nil || g.Status == nil {
+		return ""
+	}
+	return *g.Status
+}
+
+// GetURL returns the URL field if it's non-nil, zero value otherwise.
+func (g *GitRevert) GetURL() string {
+	if g == nil || g.URL == nil {
+		return ""
+	}
+	return *g.URL
+}
+
 // GetContext returns the Context field.
 func (g *GitStatus) GetContext() *GitStatusContext {
 	if g == nil {
@@ -2391,36 +3391,132 @@ func (g *GitStatus) GetUpdatedDate() *Time {
 	return g.UpdatedDate
 }
 
-// GetGenre returns the Genre field if it's non-nil, zero value otherwise.
-func (g *GitStatusContext) GetGenre() string {
-	if g == nil || g.Genre == nil {
+// GetGenre returns the Genre field if it's non-nil, zero value otherwise.
+func (g *GitStatusContext) GetGenre() string {
+	if g == nil || g.Genre == nil {
+		return ""
+	}
+	return *g.Genre
+}
+
+// GetName returns the Name field if it's non-nil, zero value otherwise.
+func (g *GitStatusContext) GetName() string {
+	if g == nil || g.Name == nil {
+		return ""
+	}
+	return *g.Name
+}
+
+// GetSkip returns the Skip field if it's non-nil, zero value otherwise.
+func (g *GitStatusListOptions) GetSkip() int {
+	if g == nil || g.Skip == nil {
+		return 0
+	}
+	return *g.Skip
+}
+
+// GetTop returns the Top field if it's non-nil, zero value otherwise.
+func (g *GitStatusListOptions) GetTop() int {
+	if g == nil || g.Top == nil {
+		return 0
+	}
+	return *g.Top
+}
+
+// GetName returns the Name field if it's non-nil, zero value otherwise.
+func (g *GitTemplate) GetName() string {
+	if g == nil || g.Name == nil {
+		return ""
+	}
+	return *g.Name
+}
+
+// GetType returns the Type field if it's non-nil, zero value otherwise.
+func (g *GitTemplate) GetType() string {
+	if g == nil || g.Type == nil {
+		return ""
+	}
+	return *g.Type
+}
+
+// GetGitObjectType returns the GitObjectType field if it's non-nil, zero value otherwise.
+func (g *GitTreeEntryRef) GetGitObjectType() string {
+	if g == nil || g.GitObjectType == nil {
+		return ""
+	}
+	return *g.GitObjectType
+}
+
+// GetMode returns the Mode field if it's non-nil, zero value otherwise.
+func (g *GitTreeEntryRef) GetMode() string {
+	if g == nil || g.Mode == nil {
+		return ""
+	}
+	return *g.Mode
+}
+
+// GetObjectID returns the ObjectID field if it's non-nil, zero value otherwise.
+func (g *GitTreeEntryRef) GetObjectID() string {
+	if g == nil || g.ObjectID == nil {
+		return ""
+	}
+	return *g.ObjectID
+}
+
+// GetRelativePath returns the RelativePath field if it's non-nil, zero value otherwise.
+func (g *GitTreeEntryRef) GetRelativePath() string {
+	if g == nil || g.RelativePath == nil {
+		return ""
+	}
+	return *g.RelativePath
+}
+
+// GetSize returns the Size field if it's non-nil, zero value otherwise.
+func (g *GitTreeEntryRef) GetSize() int64 {
+	if g == nil || g.Size == nil {
+		return 0
+	}
+	return *g.Size
+}
+
+// GetURL returns the URL field if it's non-nil, zero value otherwise.
+func (g *GitTreeEntryRef) GetURL() string {
+	if g == nil || g.URL == nil {
 		return ""
 	}
-	return *g.Genre
+	return *g.URL
 }
 
-// GetName returns the Name field if it's non-nil, zero value otherwise.
-func (g *GitStatusContext) GetName() string {
-	if g == nil || g.Name == nil {
-		return ""
+// GetLinks returns the Links field if it's non-nil, zero value otherwise.
+func (g *GitTreeRef) GetLinks() map[string]Link {
+	if g == nil || g.Links == nil {
+		return map[string]Link{}
 	}
-	return *g.Name
+	return *g.Links
 }
 
-// GetName returns the Name field if it's non-nil, zero value otherwise.
-func (g *GitTemplate) GetName() string {
-	if g == nil || g.Name == nil {
+// GetObjectID returns the ObjectID field if it's non-nil, zero value otherwise.
+func (g *GitTreeRef) GetObjectID() string {
+	if g == nil || g.ObjectID == nil {
 		return ""
 	}
-	return *g.Name
+	return *g.ObjectID
 }
 
-// GetType returns the Type field if it's non-nil, zero value otherwise.
-func (g *GitTemplate) GetType() string {
-	if g == nil || g.Type == nil {
+// GetSize returns the Size field if it's non-nil, zero value otherwise.
+func (g *GitTreeRef) GetSize() int64 {
+	if g == nil || g.Size == nil {
+		return 0
+	}
+	return *g.Size
+}
+
+// GetURL returns the URL field if it's non-nil, zero value otherwise.
+func (g *GitTreeRef) GetURL() string {
+	if g == nil || g.URL == nil {
 		return ""
 	}
-	return *g.Type
+	return *g.URL
 }
 
 // GetDate returns the Date field.
@@ -2447,6 +3543,30 @@ func (g *GitUserDate) GetName() string {
 	return *g.Name
 }
 
+// GetVersion returns the Version field if it's non-nil, zero value otherwise.
+func (g *GitVersionDescriptor) GetVersion() string {
+	if g == nil || g.Version == nil {
+		return ""
+	}
+	return *g.Version
+}
+
+// GetVersionOptions returns the VersionOptions field if it's non-nil, zero value otherwise.
+func (g *GitVersionDescriptor) GetVersionOptions() string {
+	if g == nil || g.VersionOptions == nil {
+		return ""
+	}
+	return *g.VersionOptions
+}
+
+// GetVersionType returns the VersionType field if it's non-nil, zero value otherwise.
+func (g *GitVersionDescriptor) GetVersionType() string {
+	if g == nil || g.VersionType == nil {
+		return ""
+	}
+	return *g.VersionType
+}
+
 // GetValue returns the Value field if it's non-nil, zero value otherwise.
 func (g *GraphDescriptorResult) GetValue() string {
 	if g == nil || g.Value == nil {
@@ -2775,36 +3895,28 @@ func (i *IdentityRefWithVote) GetVote() int {
 	return *i.Vote
 }
 
-// GetContent returns the Content field if it's non-nil, zero value otherwise.
-func (i *ItemContent) GetContent() string {
-	if i == nil || i.Content == nil {
-		return ""
-	}
-	return *i.Content
-}
-
-// GetContentType returns the ContentType field.
-func (i *ItemContent) GetContentType() *ItemContentType {
+// GetImportRequest returns the ImportRequest field.
+func (i *ImportError) GetImportRequest() *GitImportRequest {
 	if i == nil {
 		return nil
 	}
-	return i.ContentType
+	return i.ImportRequest
 }
 
-// GetBase64Encoded returns the Base64Encoded field if it's non-nil, zero value otherwise.
-func (i *ItemContentType) GetBase64Encoded() string {
-	if i == nil || i.Base64Encoded == nil {
+// GetContent returns the Content field if it's non-nil, zero value otherwise.
+func (i *ItemContent) GetContent() string {
+	if i == nil || i.Content == nil {
 		return ""
 	}
-	return *i.Base64Encoded
+	return *i.Content
 }
 
-// GetRawText returns the RawText field if it's non-nil, zero value otherwise.
-func (i *ItemContentType) GetRawText() string {
-	if i == nil || i.RawText == nil {
+// GetContentType returns the ContentType field if it's non-nil, zero value otherwise.
+func (i *ItemContent) GetContentType() string {
+	if i == nil || i.ContentType == nil {
 		return ""
 	}
-	return *i.RawText
+	return *i.ContentType
 }
 
 // GetEndDate returns the EndDate field if it's non-nil, zero value otherwise.
@@ -2871,6 +3983,30 @@ func (i *IterationWorkItems) GetURL() string {
 	return *i.URL
 }
 
+// GetFrom returns the From field if it's non-nil, zero value otherwise.
+func (j *JSONPatchOperation) GetFrom() string {
+	if j == nil || j.From == nil {
+		return ""
+	}
+	return *j.From
+}
+
+// GetOp returns the Op field if it's non-nil, zero value otherwise.
+func (j *JSONPatchOperation) GetOp() string {
+	if j == nil || j.Op == nil {
+		return ""
+	}
+	return *j.Op
+}
+
+// GetPath returns the Path field if it's non-nil, zero value otherwise.
+func (j *JSONPatchOperation) GetPath() string {
+	if j == nil || j.Path == nil {
+		return ""
+	}
+	return *j.Path
+}
+
 // GetHref returns the Href field if it's non-nil, zero value otherwise.
 func (l *Link) GetHref() string {
 	if l == nil || l.Href == nil {
@@ -2911,6 +4047,174 @@ func (m *Message) GetText() string {
 	return *m.Text
 }
 
+// GetCreatedBy returns the CreatedBy field.
+func (p *PolicyConfiguration) GetCreatedBy() *IdentityRef {
+	if p == nil {
+		return nil
+	}
+	return p.CreatedBy
+}
+
+// GetCreatedDate returns the CreatedDate field if it's non-nil, zero value otherwise.
+func (p *PolicyConfiguration) GetCreatedDate() string {
+	if p == nil || p.CreatedDate == nil {
+		return ""
+	}
+	return *p.CreatedDate
+}
+
+// GetID returns the ID field if it's non-nil, zero value otherwise.
+func (p *PolicyConfiguration) GetID() int {
+	if p == nil || p.ID == nil {
+		return 0
+	}
+	return *p.ID
+}
+
+// GetIsBlocking returns the IsBlocking field if it's non-nil, zero value otherwise.
+func (p *PolicyConfiguration) GetIsBlocking() bool {
+	if p == nil || p.IsBlocking == nil {
+		return false
+	}
+	return *p.IsBlocking
+}
+
+// GetIsDeleted returns the IsDeleted field if it's non-nil, zero value otherwise.
+func (p *PolicyConfiguration) GetIsDeleted() bool {
+	if p == nil || p.IsDeleted == nil {
+		return false
+	}
+	return *p.IsDeleted
+}
+
+// GetIsEnabled returns the IsEnabled field if it's non-nil, zero value otherwise.
+func (p *PolicyConfiguration) GetIsEnabled() bool {
+	if p == nil || p.IsEnabled == nil {
+		return false
+	}
+	return *p.IsEnabled
+}
+
+// GetRevision returns the Revision field if it's non-nil, zero value otherwise.
+func (p *PolicyConfiguration) GetRevision() int {
+	if p == nil || p.Revision == nil {
+		return 0
+	}
+	return *p.Revision
+}
+
+// GetType returns the Type field.
+func (p *PolicyConfiguration) GetType() *PolicyTypeRef {
+	if p == nil {
+		return nil
+	}
+	return p.Type
+}
+
+// GetUrl returns the Url field if it's non-nil, zero value otherwise.
+func (p *PolicyConfiguration) GetUrl() string {
+	if p == nil || p.Url == nil {
+		return ""
+	}
+	return *p.Url
+}
+
+// GetArtifactID returns the ArtifactID field if it's non-nil, zero value otherwise.
+func (p *PolicyEvaluationRecord) GetArtifactID() string {
+	if p == nil || p.ArtifactID == nil {
+		return ""
+	}
+	return *p.ArtifactID
+}
+
+// GetCompletedDate returns the CompletedDate field if it's non-nil, zero value otherwise.
+func (p *PolicyEvaluationRecord) GetCompletedDate() string {
+	if p == nil || p.CompletedDate == nil {
+		return ""
+	}
+	return *p.CompletedDate
+}
+
+// GetConfiguration returns the Configuration field.
+func (p *PolicyEvaluationRecord) GetConfiguration() *PolicyConfiguration {
+	if p == nil {
+		return nil
+	}
+	return p.Configuration
+}
+
+// GetEvaluationID returns the EvaluationID field if it's non-nil, zero value otherwise.
+func (p *PolicyEvaluationRecord) GetEvaluationID() string {
+	if p == nil || p.EvaluationID == nil {
+		return ""
+	}
+	return *p.EvaluationID
+}
+
+// GetLinks returns the Links field if it's non-nil, zero value otherwise.
+func (p *PolicyEvaluationRecord) GetLinks() map[string]Link {
+	if p == nil || p.Links == nil {
+		return map[string]Link{}
+	}
+	return *p.Links
+}
+
+// GetStartedDate returns the StartedDate field if it's non-nil, zero value otherwise.
+func (p *PolicyEvaluationRecord) GetStartedDate() string {
+	if p == nil || p.StartedDate == nil {
+		return ""
+	}
+	return *p.StartedDate
+}
+
+// GetStatus returns the Status field if it's non-nil, zero value otherwise.
+func (p *PolicyEvaluationRecord) GetStatus() string {
+	if p == nil || p.Status == nil {
+		return ""
+	}
+	return *p.Status
+}
+
+// GetSkip returns the Skip field if it's non-nil, zero value otherwise.
+func (p *PolicyEvaluationsListOptions) GetSkip() int {
+	if p == nil || p.Skip == nil {
+		return 0
+	}
+	return *p.Skip
+}
+
+// GetTop returns the Top field if it's non-nil, zero value otherwise.
+func (p *PolicyEvaluationsListOptions) GetTop() int {
+	if p == nil || p.Top == nil {
+		return 0
+	}
+	return *p.Top
+}
+
+// GetDisplayName returns the DisplayName field if it's non-nil, zero value otherwise.
+func (p *PolicyTypeRef) GetDisplayName() string {
+	if p == nil || p.DisplayName == nil {
+		return ""
+	}
+	return *p.DisplayName
+}
+
+// GetID returns the ID field if it's non-nil, zero value otherwise.
+func (p *PolicyTypeRef) GetID() string {
+	if p == nil || p.ID == nil {
+		return ""
+	}
+	return *p.ID
+}
+
+// GetUrl returns the Url field if it's non-nil, zero value otherwise.
+func (p *PolicyTypeRef) GetUrl() string {
+	if p == nil || p.Url == nil {
+		return ""
+	}
+	return *p.Url
+}
+
 // GetDescription returns the Description field if it's non-nil, zero value otherwise.
 func (p *Project) GetDescription() string {
 	if p == nil || p.Description == nil {
@@ -2975,6 +4279,54 @@ func (p *Project) GetVisibility() string {
 	return *p.Visibility
 }
 
+// GetCompareTo returns the CompareTo field if it's non-nil, zero value otherwise.
+func (p *PullRequestIterationChangesOptions) GetCompareTo() int {
+	if p == nil || p.CompareTo == nil {
+		return 0
+	}
+	return *p.CompareTo
+}
+
+// GetSkip returns the Skip field if it's non-nil, zero value otherwise.
+func (p *PullRequestIterationChangesOptions) GetSkip() int {
+	if p == nil || p.Skip == nil {
+		return 0
+	}
+	return *p.Skip
+}
+
+// GetTop returns the Top field if it's non-nil, zero value otherwise.
+func (p *PullRequestIterationChangesOptions) GetTop() int {
+	if p == nil || p.Top == nil {
+		return 0
+	}
+	return *p.Top
+}
+
+// GetBaseIteration returns the BaseIteration field if it's non-nil, zero value otherwise.
+func (p *PullRequestThreadOptions) GetBaseIteration() int {
+	if p == nil || p.BaseIteration == nil {
+		return 0
+	}
+	return *p.BaseIteration
+}
+
+// GetIteration returns the Iteration field if it's non-nil, zero value otherwise.
+func (p *PullRequestThreadOptions) GetIteration() int {
+	if p == nil || p.Iteration == nil {
+		return 0
+	}
+	return *p.Iteration
+}
+
+// GetDetail returns the Detail field.
+func (r *RefOperationError) GetDetail() *GitAsyncRefOperationDetail {
+	if r == nil {
+		return nil
+	}
+	return r.Detail
+}
+
 // GetAccount returns the Account field.
 func (r *ResourceContainers) GetAccount() *ResourceRef {
 	if r == nil {
@@ -3023,6 +4375,30 @@ func (r *ResourceRef) GetURL() string {
 	return *r.URL
 }
 
+// GetCreator returns the Creator field.
+func (s *StaleBranch) GetCreator() *IdentityRef {
+	if s == nil {
+		return nil
+	}
+	return s.Creator
+}
+
+// GetLastCommit returns the LastCommit field.
+func (s *StaleBranch) GetLastCommit() *GitCommitRef {
+	if s == nil {
+		return nil
+	}
+	return s.LastCommit
+}
+
+// GetBaseVersion returns the BaseVersion field.
+func (s *StaleBranchOptions) GetBaseVersion() *GitVersionDescriptor {
+	if s == nil {
+		return nil
+	}
+	return s.BaseVersion
+}
+
 // GetID returns the ID field if it's non-nil, zero value otherwise.
 func (t *TaskAgentPoolReference) GetID() int {
 	if t == nil || t.ID == nil {
@@ -3311,6 +4687,14 @@ func (t *TestsListOptions) GetCount() int {
 	return *t.Count
 }
 
+// GetSkip returns the Skip field if it's non-nil, zero value otherwise.
+func (t *TestsListOptions) GetSkip() int {
+	if t == nil || t.Skip == nil {
+		return 0
+	}
+	return *t.Skip
+}
+
 // GetCiMessage returns the CiMessage field if it's non-nil, zero value otherwise.
 func (t *TriggerInfo) GetCiMessage() string {
 	if t == nil || t.CiMessage == nil {
@@ -3335,46 +4719,6 @@ func (t *TriggerInfo) GetCiSourceSha() string {
 	return *t.CiSourceSha
 }
 
-// GetIsLocked returns the IsLocked field if it's non-nil, zero value otherwise.
-func (u *UpdateRefsBody) GetIsLocked() bool {
-	if u == nil || u.IsLocked == nil {
-		return false
-	}
-	return *u.IsLocked
-}
-
-// GetName returns the Name field if it's non-nil, zero value otherwise.
-func (u *UpdateRefsBody) GetName() string {
-	if u == nil || u.Name == nil {
-		return ""
-	}
-	return *u.Name
-}
-
-// GetNewObjectID returns the NewObjectID field if it's non-nil, zero value otherwise.
-func (u *UpdateRefsBody) GetNewObjectID() string {
-	if u == nil || u.NewObjectID == nil {
-		return ""
-	}
-	return *u.NewObjectID
-}
-
-// GetOldObjectID returns the OldObjectID field if it's non-nil, zero value otherwise.
-func (u *UpdateRefsBody) GetOldObjectID() string {
-	if u == nil || u.OldObjectID == nil {
-		return ""
-	}
-	return *u.OldObjectID
-}
-
-// GetRepositoryID returns the RepositoryID field if it's non-nil, zero value otherwise.
-func (u *UpdateRefsBody) GetRepositoryID() string {
-	if u == nil || u.RepositoryID == nil {
-		return ""
-	}
-	return *u.RepositoryID
-}
-
 // GetMessage returns the Message field if it's non-nil, zero value otherwise.
 func (v *ValidationResult) GetMessage() string {
 	if v == nil || v.Message == nil {
@@ -4,13 +4,15 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"net/http"
 	"net/url"
-	"path"
 	"reflect"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/google/go-querystring/query"
@@ -39,6 +41,39 @@ type Client struct {
 	// Account Default tenant identifier
 	Account string
 
+	// APIVersions holds the api-version requested for each REST resource.
+	// Entries may be overridden by callers, or populated from the server
+	// with DiscoverAPIVersions.
+	APIVersions *APIVersions
+
+	// MaxAPIVersion is the highest api-version sent to the server. Requests
+	// for a newer version are downgraded to it, keeping any -preview suffix.
+	// Empty means no limit. The client also lowers the limit automatically
+	// when a server reports that it does not support a requested version.
+	MaxAPIVersion string
+
+	versionMu        sync.Mutex
+	serverAPIVersion string // latest version reported by the server
+
+	// RetryPolicy controls how requests which fail because of throttling or
+	// transient server errors are retried. A nil RetryPolicy disables retries.
+	RetryPolicy *RetryPolicy
+
+	// Logger receives an entry for each request attempt at or below
+	// LogLevel. A nil Logger disables logging. Credentials are always
+	// redacted from logged URLs and headers.
+	Logger   Logger
+	LogLevel LogLevel
+	// LogBodies adds request and response bodies to entries at LogDebug.
+	LogBodies bool
+
+	// Cache stores GET responses carrying an ETag or Last-Modified header,
+	// which are then requested conditionally. Responses marked private or
+	// no-store are not cached. A nil Cache disables caching.
+	Cache CacheStore
+
+	middleware []Middleware // see Use
+
 	// Services used to proxy to other API endpoints
 	Boards            *BoardsService
 	BuildDefinitions  *BuildDefinitionsService
@@ -72,6 +107,8 @@ func NewClient(httpClient *http.Client) (*Client, error) {
 	c.BaseURL = *baseURL
 	c.VsspsBaseURL = *vsspsBaseURL
 	c.UserAgent = userAgent
+	c.RetryPolicy = DefaultRetryPolicy()
+	c.APIVersions = NewAPIVersions()
 
 	c.Boards = &BoardsService{client: c}
 	c.BuildDefinitions = &BuildDefinitionsService{client: c}
@@ -90,6 +127,40 @@ func NewClient(httpClient *http.Client) (*Client, error) {
 	return c, nil
 }
 
+// NewEnterpriseClient returns a new Azure DevOps API client for Azure DevOps
+// Server (formerly Team Foundation Server). baseURL is the URL of the server,
+// including any virtual directory, such as "https://tfs.example.com/tfs/".
+// collection is the name of the project collection, which is passed as the
+// owner argument to service methods in place of the organization. A baseURL
+// which already ends with the collection is also accepted.
+//
+// On-premises servers host the Graph and other vssps APIs within each
+// collection, so VsspsBaseURL is set to baseURL as well. Requests for an
+// api-version newer than the server supports are retried with the version
+// reported by the server, see MaxAPIVersion.
+func NewEnterpriseClient(baseURL, collection string, httpClient *http.Client) (*Client, error) {
+	baseEndpoint, err := url.Parse(baseURL)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.HasSuffix(baseEndpoint.Path, "/") {
+		baseEndpoint.Path += "/"
+	}
+	collection = strings.Trim(collection, "/")
+	if collection != "" && strings.HasSuffix(baseEndpoint.Path, "/"+collection+"/") {
+		baseEndpoint.Path = strings.TrimSuffix(baseEndpoint.Path, collection+"/")
+	}
+
+	c, err := NewClient(httpClient)
+	if err != nil {
+		return nil, err
+	}
+	c.BaseURL = *baseEndpoint
+	c.VsspsBaseURL = *baseEndpoint
+	c.Account = collection
+	return c, nil
+}
+
 // NewRequest creates an API request. A relative URL can be provided in urlStr,
 // in which case it is resolved relative to the BaseURL of the Client.
 // Relative URLs should always be specified without a preceding slash. If
@@ -104,6 +175,7 @@ func (c *Client) NewRequest(method, urlStr string, body interface{}) (*http.Requ
 	if err != nil {
 		return nil, err
 	}
+	c.limitAPIVersion(u)
 
 	var buf io.ReadWriter
 	if body != nil {
@@ -138,10 +210,36 @@ func (c *Client) NewRequest(method, urlStr string, body interface{}) (*http.Requ
 //
 // The provided ctx must be non-nil. If it is canceled or times out,
 // ctx.Err() will be returned.
-func (c *Client) Execute(ctx context.Context, req *http.Request, r interface{}) (*http.Response, error) {
+func (c *Client) Execute(ctx context.Context, req *http.Request, r interface{}) (*Response, error) {
 	req = req.WithContext(ctx)
-	debugReq(req)
-	resp, err := c.client.Do(req)
+	for i, m := range c.middleware {
+		if m.BeforeRequest == nil {
+			continue
+		}
+		if err := m.BeforeRequest(ctx, req); err != nil {
+			return nil, c.afterResponse(ctx, req, nil, err, i)
+		}
+	}
+	response, err := c.execute(ctx, req, r)
+	return response, c.afterResponse(ctx, req, response, err, len(c.middleware))
+}
+
+// execute sends req and decodes the response, without running middleware.
+func (c *Client) execute(ctx context.Context, req *http.Request, r interface{}) (*Response, error) {
+	key, cacheable := c.cacheKey(req, r)
+	var cached *CachedResponse
+	if cacheable {
+		cached = c.setConditionalHeaders(key, req)
+	}
+
+	resp, err := c.send(ctx, req)
+	if err == nil && resp.StatusCode == http.StatusBadRequest {
+		resp, err = c.fallbackAPIVersion(ctx, req, resp)
+	}
+	fromCache := false
+	if err == nil && cacheable {
+		resp, fromCache, err = c.updateCache(key, cached, resp)
+	}
 	if err != nil {
 		// If we got an error, and the context has been canceled,
 		// the context's error is probably more useful.
@@ -151,25 +249,23 @@ func (c *Client) Execute(ctx context.Context, req *http.Request, r interface{})
 		default:
 		}
 
-		// If the error type is *url.Error, sanitize its URL before returning.
-		if e, ok := err.(*url.Error); ok {
-			if url, err := url.Parse(e.URL); err == nil {
-				e.URL = sanitizeURL(url).String()
-				return nil, e
-			}
-		}
-
-		return nil, err
+		return nil, sanitizeError(err)
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode != 200 && resp.StatusCode != 201 {
-		return nil, fmt.Errorf("Request to %s responded with status %d", req.URL, resp.StatusCode)
+	response := newResponse(resp)
+	response.FromCache = fromCache
+
+	err = CheckResponse(resp)
+	if err != nil {
+		return response, err
 	}
 
 	if r != nil {
 		if w, ok := r.(io.Writer); ok {
-			io.Copy(w, resp.Body)
+			if _, copyErr := io.Copy(w, resp.Body); copyErr != nil {
+				err = copyErr
+			}
 		} else {
 			decErr := json.NewDecoder(resp.Body).Decode(r)
 			if decErr == io.EOF {
@@ -178,20 +274,181 @@ func (c *Client) Execute(ctx context.Context, req *http.Request, r interface{})
 			if decErr != nil {
 				err = decErr
 			}
+			response.Count = listCount(r)
 		}
 	}
 
-	return resp, err
+	return response, err
+}
+
+// send sends req, retrying it as allowed by the client's RetryPolicy. Any
+// response which is not returned has its body drained and closed.
+func (c *Client) send(ctx context.Context, req *http.Request) (*http.Response, error) {
+	for attempt := 1; ; attempt++ {
+		start := time.Now()
+		resp, err := c.client.Do(req)
+		c.logAttempt(req, resp, err, attempt, time.Since(start))
+		if err != nil && ctx.Err() != nil {
+			return nil, err
+		}
+
+		wait, retry := c.RetryPolicy.retry(req, resp, err, attempt)
+		if !retry {
+			return resp, err
+		}
+		if resp != nil {
+			io.Copy(ioutil.Discard, resp.Body)
+			resp.Body.Close()
+		}
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req.Body = body
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// Response is an Azure DevOps API response. This wraps the standard
+// http.Response returned from Azure DevOps and provides convenient access to
+// things like rate limit information.
+type Response struct {
+	*http.Response
+
+	// RateLimit describes the rate limit state reported by the service.
+	RateLimit RateLimit
+
+	// ContinuationToken requests the next page of results from endpoints
+	// which page with continuation tokens. It is empty on the last page.
+	ContinuationToken string
+
+	// Count is the number of items returned by a list endpoint, as reported
+	// in the response body.
+	Count int
+
+	// FromCache reports whether the body was served from the client's Cache
+	// after the service responded 304 Not Modified.
+	FromCache bool
+}
+
+// newResponse creates a new Response for the provided http.Response.
+func newResponse(r *http.Response) *Response {
+	response := &Response{Response: r}
+	response.RateLimit = parseRateLimit(r)
+	response.ContinuationToken = r.Header.Get(continuationTokenHeader)
+	return response
+}
+
+// Sentinel errors describing common kinds of API failure. An *ErrorResponse
+// matches them with errors.Is, based on its status code and the exception
+// type reported by Azure DevOps.
+var (
+	// ErrNotFound means the requested resource does not exist, or is not
+	// visible to the caller.
+	ErrNotFound = errors.New("azuredevops: resource not found")
+	// ErrUnauthorized means the credentials were missing or rejected, or
+	// lack the permissions required by the request.
+	ErrUnauthorized = errors.New("azuredevops: unauthorized")
+	// ErrConflict means the request conflicts with the current state of the
+	// resource, such as a stale ref update.
+	ErrConflict = errors.New("azuredevops: conflict")
+	// ErrPolicyViolation means the request was rejected by a branch or
+	// repository policy.
+	ErrPolicyViolation = errors.New("azuredevops: policy violation")
+	// ErrRateLimited means the request was blocked because the caller
+	// exceeded its rate limit.
+	ErrRateLimited = errors.New("azuredevops: rate limited")
+)
+
+// ErrorResponse reports an error caused by an API request. Azure DevOps
+// describes most errors with a JSON body, which is decoded into the fields
+// below when present.
+type ErrorResponse struct {
+	Response       *http.Response `json:"-"`         // HTTP response that caused this error
+	StatusCode     int            `json:"-"`         // HTTP status code of the response
+	TypeName       string         `json:"typeName"`  // fully qualified exception type
+	TypeKey        string         `json:"typeKey"`   // short exception type, e.g. GitRepositoryNotFoundException
+	ErrorCode      int            `json:"errorCode"` // service specific error code
+	Message        string         `json:"message"`   // error message, usually prefixed with a TFxxxxxx identifier
+	InnerException *ErrorResponse `json:"innerException,omitempty"`
+	EventID        int            `json:"eventId"`
+
+	// RateLimit describes the rate limit state reported with the response.
+	RateLimit RateLimit `json:"-"`
+}
+
+func (r *ErrorResponse) Error() string {
+	msg := fmt.Sprintf("Request responded with status %d", r.StatusCode)
+	if r.Response != nil && r.Response.Request != nil {
+		u := *r.Response.Request.URL
+		msg = fmt.Sprintf("Request to %s responded with status %d", sanitizeURL(&u), r.StatusCode)
+	}
+	if r.TypeKey != "" {
+		msg = fmt.Sprintf("%s: %s", msg, r.TypeKey)
+	}
+	if r.Message != "" {
+		msg = fmt.Sprintf("%s: %s", msg, r.Message)
+	}
+	return msg
+}
+
+// Is reports whether the error matches one of the sentinel errors ErrNotFound,
+// ErrUnauthorized, ErrConflict, ErrPolicyViolation or ErrRateLimited.
+func (r *ErrorResponse) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return r.StatusCode == http.StatusNotFound || strings.HasSuffix(r.TypeKey, "NotFoundException")
+	case ErrUnauthorized:
+		return r.StatusCode == http.StatusUnauthorized || r.StatusCode == http.StatusForbidden
+	case ErrConflict:
+		return r.StatusCode == http.StatusConflict
+	case ErrPolicyViolation:
+		return strings.Contains(r.TypeKey, "Policy")
+	case ErrRateLimited:
+		return r.StatusCode == http.StatusTooManyRequests
+	}
+	return false
+}
+
+// CheckResponse checks the API response for errors, and returns them if
+// present. A response is considered an error if it has a status code outside
+// the 200 range. The returned error is an *ErrorResponse, populated from the
+// response body when it contains a JSON error description.
+func CheckResponse(r *http.Response) error {
+	if c := r.StatusCode; 200 <= c && c <= 299 {
+		return nil
+	}
+	errorResponse := &ErrorResponse{Response: r}
+	data, err := ioutil.ReadAll(r.Body)
+	if err == nil && data != nil {
+		// Non-JSON bodies, such as sign-in pages, are ignored.
+		json.Unmarshal(data, errorResponse)
+	}
+	errorResponse.StatusCode = r.StatusCode
+	errorResponse.RateLimit = parseRateLimit(r)
+	return errorResponse
 }
 
 // BasicAuthTransport is an http.RoundTripper that authenticates all requests
-// using HTTP Basic Authentication with the provided username and password. It
-// additionally supports users who have two-factor authentication enabled on
-// their GitHub account.
+// using HTTP Basic Authentication with the provided username and password.
+// For a personal access token, leave Username empty and set Password to the
+// token, or use a TokenTransport with PersonalAccessToken.
 type BasicAuthTransport struct {
 	Username string // Azure Devops username
-	Password string // Azure Devops password
-	OTP      string // one-time password for users with two-factor auth enabled
+	Password string // Azure Devops password or personal access token
+
+	// Deprecated: Azure DevOps does not support one-time passwords, so OTP
+	// is ignored.
+	OTP string
 
 	// Transport is the underlying HTTP transport to use when making requests.
 	// It will default to http.DefaultTransport if nil.
@@ -213,8 +470,7 @@ func (t *BasicAuthTransport) RoundTrip(req *http.Request) (*http.Response, error
 		req2.Header[k] = append([]string(nil), s...)
 	}
 
-	//req2.SetBasicAuth(t.Username, t.Password)
-	req2.SetBasicAuth("", t.Password)
+	req2.SetBasicAuth(t.Username, t.Password)
 
 	return t.transport().RoundTrip(req2)
 }
@@ -260,36 +516,89 @@ func addOptions(s string, opt interface{}) (string, error) {
 }
 
 // formatRef helper function for API calls that need a branch reference
-// as an input parameter.  Doesn't do much error checking.
+// as an input parameter.  Names which are already fully qualified, such as
+// tags or branches with a slash, are left alone.
 // Examples:
 // *ref = "mybranch" => *ref = "refs/heads/mybranch"
 // *ref = "refs/heads/abranch" => *ref = "refs/heads/abranch"
+// *ref = "refs/tags/v1.0" => *ref = "refs/tags/v1.0"
 func formatRef(ref *string) error {
-	matched, err := path.Match("refs/heads/*", *ref)
-	if matched && err == nil {
-		return nil
-	} else if err != nil {
-		return err
-	} else {
-		*ref = fmt.Sprintf("refs/heads/%s", *ref)
-		return nil
+	if ref == nil {
+		return errors.New("formatRef: nil ref")
+	}
+	if !strings.HasPrefix(*ref, "refs/") {
+		*ref = "refs/heads/" + *ref
+	}
+	return nil
+}
+
+// sensitiveHeaders are headers which may carry credentials or cookies. They
+// are redacted from logs and recordings, and never cached.
+var sensitiveHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie"}
+
+// sensitiveParams are query parameters which may carry credentials.
+var sensitiveParams = []string{"client_secret", "access_token", "token", "sig"}
+
+// RedactHeader returns a copy of h with the values of headers which may carry
+// credentials replaced by REDACTED. It is used for logged headers, and by
+// tools which store requests, such as the azuredevopstest Recorder.
+func RedactHeader(h http.Header) http.Header {
+	r := make(http.Header, len(h))
+	for k, v := range h {
+		r[k] = append([]string(nil), v...)
+	}
+	for _, k := range sensitiveHeaders {
+		if _, ok := r[k]; ok {
+			r.Set(k, "REDACTED")
+		}
 	}
+	return r
+}
+
+// RedactURL redacts credentials from u in place, as in the URLs of logged
+// requests and returned errors, and returns u.
+func RedactURL(u *url.URL) *url.URL {
+	return sanitizeURL(u)
 }
 
-// sanitizeURL redacts the client_secret parameter from the URL which may be
-// exposed to the user.
+// sanitizeURL redacts credentials, such as a personal access token in the
+// user info or a client_secret parameter, from the URL which may be exposed
+// to the user.
 func sanitizeURL(uri *url.URL) *url.URL {
 	if uri == nil {
 		return nil
 	}
+	if uri.User != nil {
+		uri.User = url.User("REDACTED")
+	}
 	params := uri.Query()
-	if len(params.Get("client_secret")) > 0 {
-		params.Set("client_secret", "REDACTED")
+	redacted := false
+	for _, p := range sensitiveParams {
+		if len(params.Get(p)) > 0 {
+			params.Set(p, "REDACTED")
+			redacted = true
+		}
+	}
+	if redacted {
 		uri.RawQuery = params.Encode()
 	}
 	return uri
 }
 
+// sanitizeError returns err, or a copy of it with credentials redacted from
+// the URL if it is a *url.Error.
+func sanitizeError(err error) error {
+	e, ok := err.(*url.Error)
+	if !ok {
+		return err
+	}
+	u, perr := url.Parse(e.URL)
+	if perr != nil {
+		return err
+	}
+	return &url.Error{Op: e.Op, URL: sanitizeURL(u).String(), Err: e.Err}
+}
+
 type Time struct {
 	Time time.Time
 }
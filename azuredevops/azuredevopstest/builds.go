@@ -0,0 +1,90 @@
+package azuredevopstest
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mcdafydd/go-azuredevops/azuredevops"
+)
+
+// AddBuild seeds a build in a project, assigning it an ID if it has none,
+// and returns a copy of the stored build.
+func (s *Server) AddBuild(owner, project string, build *azuredevops.Build) *azuredevops.Build {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	b := s.addBuild(s.project(owner, project), build)
+	out := new(azuredevops.Build)
+	clone(b, out)
+	return out
+}
+
+func (s *Server) addBuild(p *project, build *azuredevops.Build) *azuredevops.Build {
+	b := new(azuredevops.Build)
+	clone(build, b)
+	if b.ID == nil {
+		b.ID = azuredevops.Int(s.id())
+	}
+	if b.Status == nil {
+		b.Status = azuredevops.String("notStarted")
+	}
+	p.builds = append(p.builds, b)
+	return b
+}
+
+func (s *Server) buildRoutes() {
+	s.handle("GET", `build/builds`, s.listBuilds)
+	s.handle("POST", `build/builds`, s.queueBuild)
+}
+
+// listBuilds lists the builds of a project, newest first.
+func (s *Server) listBuilds(c *call) {
+	p := c.project(s)
+	q := c.r.URL.Query()
+	builds := []*azuredevops.Build{}
+	for i := len(p.builds) - 1; i >= 0; i-- {
+		b := p.builds[i]
+		switch {
+		case !matchParam(q, "branchName", b.GetSourceBranch()),
+			!matchParam(q, "statusFilter", b.GetStatus()),
+			!matchParam(q, "resultFilter", b.GetResult()),
+			!matchParam(q, "buildNumber", b.GetBuildNumber()),
+			!matchParam(q, "repositoryId", b.GetRepository().GetID()),
+			!matchList(q.Get("definitions"), b.GetDefinition().GetID()),
+			!matchList(q.Get("buildIds"), b.GetID()):
+			continue
+		}
+		builds = append(builds, b)
+	}
+	start, end := c.continuationPage(len(builds))
+	c.list(builds[start:end], end-start)
+}
+
+// matchList reports whether id is in the comma-separated list, or the list
+// is empty.
+func matchList(list string, id int) bool {
+	if list == "" {
+		return true
+	}
+	for _, s := range strings.Split(list, ",") {
+		if s == strconv.Itoa(id) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Server) queueBuild(c *call) {
+	build := new(azuredevops.Build)
+	if !c.decode(build) {
+		return
+	}
+	if build.Definition == nil || build.Definition.ID == nil {
+		c.error(http.StatusBadRequest, "ArgumentNullException", "Value cannot be null.\r\nParameter name: build.Definition")
+		return
+	}
+	build.ID, build.Status = nil, nil
+	build.QueueTime = azuredevops.String(time.Now().UTC().Format(time.RFC3339))
+	c.json(http.StatusOK, s.addBuild(c.project(s), build))
+}
@@ -0,0 +1,612 @@
+package azuredevopstest
+
+import (
+	"crypto/sha1"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/mcdafydd/go-azuredevops/azuredevops"
+)
+
+// repository holds a repository and its refs.
+type repository struct {
+	repo   *azuredevops.GitRepository
+	refs   []*azuredevops.GitRef
+	pushes []*azuredevops.GitPush
+	tags   []*azuredevops.GitAnnotatedTag
+}
+
+// deletedRepository is a repository in the recycle bin of a project.
+type deletedRepository struct {
+	*repository
+	deletedDate time.Time
+}
+
+// AddRepository seeds a repository in a project, assigning it an ID if it
+// has none, and returns a copy of the stored repository.
+func (s *Server) AddRepository(owner, project string, repo *azuredevops.GitRepository) *azuredevops.GitRepository {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	p := s.project(owner, project)
+	r := new(azuredevops.GitRepository)
+	clone(repo, r)
+	if r.ID == nil {
+		r.ID = azuredevops.String(s.guid())
+	}
+	if r.Project == nil {
+		r.Project = &azuredevops.TeamProjectReference{Name: azuredevops.String(project)}
+	}
+	p.repos = append(p.repos, &repository{repo: r})
+	out := new(azuredevops.GitRepository)
+	clone(r, out)
+	return out
+}
+
+// AddRef seeds a ref, such as refs/heads/master, in a repository which was
+// added with AddRepository.
+func (s *Server) AddRef(owner, project, repo string, ref *azuredevops.GitRef) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	r := s.project(owner, project).repository(repo)
+	if r == nil {
+		panic("azuredevopstest: AddRef to unknown repository " + repo)
+	}
+	out := new(azuredevops.GitRef)
+	clone(ref, out)
+	r.refs = append(r.refs, out)
+}
+
+// Refs returns the refs of a repository in the server.
+func (s *Server) Refs(owner, project, repo string) []*azuredevops.GitRef {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	r := s.project(owner, project).repository(repo)
+	if r == nil {
+		return nil
+	}
+	var refs []*azuredevops.GitRef
+	clone(r.refs, &refs)
+	return refs
+}
+
+// repository finds a repository by name or ID.
+func (p *project) repository(nameOrID string) *repository {
+	for _, r := range p.repos {
+		if strings.EqualFold(r.repo.GetName(), nameOrID) || strings.EqualFold(r.repo.GetID(), nameOrID) {
+			return r
+		}
+	}
+	return nil
+}
+
+// repository returns the repository named by the first route parameter,
+// writing a 404 if it does not exist.
+func (c *call) repository(s *Server) *repository {
+	r := c.project(s).repository(c.params[1])
+	if r == nil {
+		c.notFound("GitRepositoryNotFoundException", "TF401019: The Git repository with name or identifier %s does not exist or you do not have permissions for the operation you are attempting.", c.params[1])
+	}
+	return r
+}
+
+func (s *Server) gitRoutes() {
+	s.handle("GET", `git/repositories`, s.listRepositories)
+	s.handle("POST", `git/repositories`, s.createRepository)
+	s.handle("GET", `git/repositories/([^/]+)`, s.getRepository)
+	s.handle("PATCH", `git/repositories/([^/]+)`, s.updateRepository)
+	s.handle("DELETE", `git/repositories/([^/]+)`, s.deleteRepository)
+	s.handle("GET", `git/repositories/([^/]+)/forks/([^/]+)`, s.listForks)
+	s.handle("GET", `git/recycleBin/repositories`, s.listRecycleBin)
+	s.handle("PATCH", `git/recycleBin/repositories/([^/]+)`, s.restoreRepository)
+	s.handle("DELETE", `git/recycleBin/repositories/([^/]+)`, s.purgeRepository)
+	s.handle("GET", `git/repositories/([^/]+)/refs(?:/(.*))?`, s.listRefs)
+	s.handle("POST", `git/repositories/([^/]+)/refs`, s.updateRefs)
+	s.handle("PATCH", `git/repositories/([^/]+)/refs`, s.updateRef)
+	s.handle("GET", `git/repositories/([^/]+)/pushes`, s.listPushes)
+	s.handle("POST", `git/repositories/([^/]+)/pushes`, s.createPush)
+	s.handle("GET", `git/repositories/([^/]+)/pushes/(\d+)`, s.getPush)
+	s.handle("POST", `git/repositories/([^/]+)/annotatedtags`, s.createAnnotatedTag)
+	s.handle("GET", `git/repositories/([^/]+)/annotatedtags/([0-9a-f]+)`, s.getAnnotatedTag)
+	s.handle("GET", `git/repositories/([^/]+)/commits`, s.listCommits)
+	s.handle("GET", `git/repositories/([^/]+)/commits/([0-9a-f]+)`, s.getCommit)
+}
+
+func (s *Server) listRepositories(c *call) {
+	p := c.project(s)
+	repos := make([]*azuredevops.GitRepository, 0, len(p.repos))
+	for _, r := range p.repos {
+		repos = append(repos, r.repo)
+	}
+	c.list(repos, len(repos))
+}
+
+func (s *Server) getRepository(c *call) {
+	if r := c.repository(s); r != nil {
+		c.json(http.StatusOK, r.repo)
+	}
+}
+
+// createRepository creates an empty repository, or a fork with the refs of
+// its parent. sourceRef limits the refs copied to a fork to one branch.
+func (s *Server) createRepository(c *call) {
+	opts := new(azuredevops.GitRepositoryCreateOptions)
+	if !c.decode(opts) {
+		return
+	}
+	p := c.project(s)
+	name := opts.GetName()
+	if name == "" {
+		c.error(http.StatusBadRequest, "InvalidArgumentValueException", "The repository name is required.")
+		return
+	}
+	if p.repository(name) != nil {
+		c.error(http.StatusConflict, "GitRepositoryNameAlreadyExistsException", fmt.Sprintf("TF400948: A Git repository with the name %s already exists.", name))
+		return
+	}
+
+	r := &repository{repo: &azuredevops.GitRepository{
+		ID:      azuredevops.String(s.guid()),
+		Name:    azuredevops.String(name),
+		Project: &azuredevops.TeamProjectReference{Name: azuredevops.String(p.name)},
+	}}
+	if parentRef := opts.ParentRepository; parentRef != nil {
+		parent := s.findRepository(c.owner(), parentRef.GetID())
+		if parent == nil {
+			c.notFound("GitRepositoryNotFoundException", "TF401019: The Git repository with name or identifier %s does not exist or you do not have permissions for the operation you are attempting.", parentRef.GetID())
+			return
+		}
+		r.repo.IsFork = azuredevops.Bool(true)
+		r.repo.ParentRepository = &azuredevops.GitRepositoryRef{
+			ID:      parent.repo.ID,
+			Name:    parent.repo.Name,
+			Project: parent.repo.Project,
+		}
+		r.repo.DefaultBranch = parent.repo.DefaultBranch
+		sourceRef := c.r.URL.Query().Get("sourceRef")
+		for _, ref := range parent.refs {
+			if sourceRef == "" || ref.GetName() == sourceRef {
+				out := new(azuredevops.GitRef)
+				clone(ref, out)
+				r.refs = append(r.refs, out)
+			}
+		}
+	}
+	p.repos = append(p.repos, r)
+	c.json(http.StatusCreated, r.repo)
+}
+
+// findRepository finds a repository by ID in any project of an
+// organization. s.mu must be held.
+func (s *Server) findRepository(owner, id string) *repository {
+	for _, p := range s.projects {
+		if !strings.EqualFold(p.owner, owner) {
+			continue
+		}
+		for _, r := range p.repos {
+			if strings.EqualFold(r.repo.GetID(), id) {
+				return r
+			}
+		}
+	}
+	return nil
+}
+
+func (s *Server) updateRepository(c *call) {
+	r := c.repository(s)
+	if r == nil {
+		return
+	}
+	update := new(azuredevops.GitRepository)
+	if !c.decode(update) {
+		return
+	}
+	if update.Name != nil {
+		r.repo.Name = update.Name
+	}
+	if update.DefaultBranch != nil {
+		r.repo.DefaultBranch = update.DefaultBranch
+	}
+	c.json(http.StatusOK, r.repo)
+}
+
+// deleteRepository moves a repository to the recycle bin. As in Azure
+// DevOps, the repository must be given by ID.
+func (s *Server) deleteRepository(c *call) {
+	p := c.project(s)
+	for i, r := range p.repos {
+		if strings.EqualFold(r.repo.GetID(), c.params[1]) {
+			p.repos = append(p.repos[:i], p.repos[i+1:]...)
+			p.recycleBin = append(p.recycleBin, &deletedRepository{r, time.Now().UTC()})
+			c.noContent()
+			return
+		}
+	}
+	c.notFound("GitRepositoryNotFoundException", "TF401019: The Git repository with name or identifier %s does not exist or you do not have permissions for the operation you are attempting.", c.params[1])
+}
+
+// listForks lists the forks of a repository in the organization. The
+// collection ID is ignored.
+func (s *Server) listForks(c *call) {
+	r := c.repository(s)
+	if r == nil {
+		return
+	}
+	forks := []*azuredevops.GitRepositoryRef{}
+	for _, p := range s.projects {
+		if !strings.EqualFold(p.owner, c.owner()) {
+			continue
+		}
+		for _, fork := range p.repos {
+			if fork.repo.GetParentRepository().GetID() == r.repo.GetID() {
+				forks = append(forks, &azuredevops.GitRepositoryRef{
+					ID:      fork.repo.ID,
+					IsFork:  fork.repo.IsFork,
+					Name:    fork.repo.Name,
+					Project: fork.repo.Project,
+				})
+			}
+		}
+	}
+	c.list(forks, len(forks))
+}
+
+func (s *Server) listRecycleBin(c *call) {
+	p := c.project(s)
+	repos := []*azuredevops.GitDeletedRepository{}
+	for _, d := range p.recycleBin {
+		repos = append(repos, &azuredevops.GitDeletedRepository{
+			ID:          d.repo.ID,
+			Name:        d.repo.Name,
+			Project:     d.repo.Project,
+			DeletedDate: &azuredevops.Time{Time: d.deletedDate},
+		})
+	}
+	c.list(repos, len(repos))
+}
+
+// recycleBinIndex returns the index of the deleted repository named by the
+// first route parameter, writing a 404 if it is not in the recycle bin.
+func (c *call) recycleBinIndex(s *Server) int {
+	p := c.project(s)
+	for i, d := range p.recycleBin {
+		if strings.EqualFold(d.repo.GetID(), c.params[1]) {
+			return i
+		}
+	}
+	c.notFound("GitRepositoryNotFoundException", "TF401019: The Git repository with name or identifier %s does not exist or you do not have permissions for the operation you are attempting.", c.params[1])
+	return -1
+}
+
+func (s *Server) restoreRepository(c *call) {
+	i := c.recycleBinIndex(s)
+	if i < 0 {
+		return
+	}
+	var body struct {
+		Deleted *bool `json:"deleted"`
+	}
+	if !c.decode(&body) {
+		return
+	}
+	p := c.project(s)
+	d := p.recycleBin[i]
+	if body.Deleted == nil || *body.Deleted {
+		c.json(http.StatusOK, d.repo)
+		return
+	}
+	p.recycleBin = append(p.recycleBin[:i], p.recycleBin[i+1:]...)
+	p.repos = append(p.repos, d.repository)
+	c.json(http.StatusOK, d.repo)
+}
+
+func (s *Server) purgeRepository(c *call) {
+	i := c.recycleBinIndex(s)
+	if i < 0 {
+		return
+	}
+	p := c.project(s)
+	p.recycleBin = append(p.recycleBin[:i], p.recycleBin[i+1:]...)
+	c.noContent()
+}
+
+func (s *Server) listRefs(c *call) {
+	r := c.repository(s)
+	if r == nil {
+		return
+	}
+	prefix := "refs/"
+	if c.params[2] != "" {
+		prefix += strings.TrimSuffix(c.params[2], "/")
+	}
+	if f := c.r.URL.Query().Get("filter"); f != "" {
+		prefix = "refs/" + strings.TrimPrefix(f, "refs/")
+	}
+
+	refs := []*azuredevops.GitRef{}
+	for _, ref := range r.refs {
+		if strings.HasPrefix(ref.GetName(), prefix) {
+			refs = append(refs, ref)
+		}
+	}
+	start, end := c.continuationPage(len(refs))
+	c.list(refs[start:end], end-start)
+}
+
+// updateRefs applies a batch of ref updates, checking each against the
+// current object ID of the ref.
+func (s *Server) updateRefs(c *call) {
+	r := c.repository(s)
+	if r == nil {
+		return
+	}
+	var updates []*azuredevops.GitRefUpdate
+	if !c.decode(&updates) {
+		return
+	}
+
+	results := make([]*azuredevops.GitRefUpdateResult, 0, len(updates))
+	for _, u := range updates {
+		status := r.updateRef(u)
+		results = append(results, &azuredevops.GitRefUpdateResult{
+			Name:         u.Name,
+			OldObjectID:  u.OldObjectID,
+			NewObjectID:  u.NewObjectID,
+			RepositoryID: r.repo.ID,
+			Success:      azuredevops.Bool(status == azuredevops.RefUpdateSucceeded),
+			UpdateStatus: azuredevops.String(status.String()),
+		})
+	}
+	c.list(results, len(results))
+}
+
+// updateRef applies a single ref update. The fake has no commit graph, so
+// every update is treated as a fast-forward.
+func (r *repository) updateRef(u *azuredevops.GitRefUpdate) azuredevops.GitRefUpdateStatus {
+	name := u.GetName()
+	if !strings.HasPrefix(name, "refs/") || strings.HasSuffix(name, "/") {
+		return azuredevops.RefUpdateInvalidRefName
+	}
+	index := -1
+	current := azuredevops.ZeroObjectID
+	for i, ref := range r.refs {
+		if ref.GetName() == name {
+			index, current = i, ref.GetObjectID()
+		}
+	}
+	if u.GetOldObjectID() != current {
+		return azuredevops.RefUpdateStaleOldObjectID
+	}
+	if index >= 0 && r.refs[index].GetIsLocked() {
+		return azuredevops.RefUpdateLocked
+	}
+
+	switch {
+	case u.GetNewObjectID() == azuredevops.ZeroObjectID:
+		if index >= 0 {
+			r.refs = append(r.refs[:index], r.refs[index+1:]...)
+		}
+	case index >= 0:
+		r.refs[index].ObjectID = u.NewObjectID
+	default:
+		r.refs = append(r.refs, &azuredevops.GitRef{Name: u.Name, ObjectID: u.NewObjectID})
+	}
+	return azuredevops.RefUpdateSucceeded
+}
+
+// updateRef locks or unlocks the ref named by the filter parameter.
+func (s *Server) updateRef(c *call) {
+	r := c.repository(s)
+	if r == nil {
+		return
+	}
+	update := new(azuredevops.GitRefUpdate)
+	if !c.decode(update) {
+		return
+	}
+	name := "refs/" + strings.TrimPrefix(c.r.URL.Query().Get("filter"), "refs/")
+	for _, ref := range r.refs {
+		if ref.GetName() == name {
+			if update.IsLocked != nil {
+				ref.IsLocked = update.IsLocked
+			}
+			c.json(http.StatusOK, ref)
+			return
+		}
+	}
+	c.notFound("GitRefNotFoundException", "TF401025: The ref %s does not exist.", name)
+}
+
+// createPush applies a push, giving each commit a new object ID and moving
+// the ref to the last commit. The content of changes is not stored.
+func (s *Server) createPush(c *call) {
+	r := c.repository(s)
+	if r == nil {
+		return
+	}
+	push := new(azuredevops.GitPush)
+	if !c.decode(push) {
+		return
+	}
+	if len(push.RefUpdates) != 1 || len(push.Commits) == 0 {
+		c.error(http.StatusBadRequest, "InvalidArgumentValueException", "A push must update one ref with at least one commit.")
+		return
+	}
+
+	update := push.RefUpdates[0]
+	parent := update.GetOldObjectID()
+	for _, commit := range push.Commits {
+		id := fmt.Sprintf("%x", sha1.Sum([]byte(fmt.Sprintf("%s %d", parent, s.id()))))
+		if parent != azuredevops.ZeroObjectID {
+			commit.Parents = []*string{azuredevops.String(parent)}
+		}
+		commit.CommitID = azuredevops.String(id)
+		parent = id
+	}
+	update.NewObjectID = azuredevops.String(parent)
+
+	switch r.updateRef(update) {
+	case azuredevops.RefUpdateSucceeded:
+	case azuredevops.RefUpdateStaleOldObjectID:
+		c.error(http.StatusConflict, "GitReferenceStaleException", fmt.Sprintf("TF401028: The reference '%s' has already been updated by another client, so you cannot update it. Please try again.", update.GetName()))
+		return
+	default:
+		c.error(http.StatusBadRequest, "GitReferenceUpdateException", fmt.Sprintf("The reference '%s' could not be updated.", update.GetName()))
+		return
+	}
+
+	push.PushID = azuredevops.Int(len(r.pushes) + 1)
+	push.Date = &azuredevops.Time{Time: time.Now().UTC()}
+	push.Repository = r.repo
+	r.pushes = append(r.pushes, push)
+	c.json(http.StatusCreated, push)
+}
+
+func (s *Server) getPush(c *call) {
+	r := c.repository(s)
+	if r == nil {
+		return
+	}
+	id := c.intParam(2)
+	if id < 1 || id > len(r.pushes) {
+		c.notFound("GitPushNotFoundException", "TF401180: The push %d was not found.", id)
+		return
+	}
+	c.json(http.StatusOK, r.pushes[id-1])
+}
+
+// listPushes lists the pushes to a repository, newest first.
+func (s *Server) listPushes(c *call) {
+	r := c.repository(s)
+	if r == nil {
+		return
+	}
+	q := c.r.URL.Query()
+	pushes := []*azuredevops.GitPush{}
+	for i := len(r.pushes) - 1; i >= 0; i-- {
+		p := r.pushes[i]
+		if name := q.Get("searchCriteria.refName"); name != "" && p.RefUpdates[0].GetName() != name {
+			continue
+		}
+		pushes = append(pushes, p)
+	}
+	start, end, ok := c.page("", len(pushes))
+	if !ok {
+		return
+	}
+	c.list(pushes[start:end], end-start)
+}
+
+// commitRef returns a copy of a pushed commit without its changes, as the
+// commits API returns it.
+func commitRef(commit *azuredevops.GitCommitRef) *azuredevops.GitCommitRef {
+	ref := *commit
+	ref.Changes = nil
+	ref.ChangeCounts = &map[string]int{}
+	for _, change := range commit.Changes {
+		if t := change.GetChangeType(); t != "" {
+			(*ref.ChangeCounts)[strings.ToUpper(t[:1])+t[1:]]++
+		}
+	}
+	return &ref
+}
+
+// listCommits lists the commits pushed to a repository, newest first,
+// filtered by the branch of searchCriteria.itemVersion and by
+// searchCriteria.author.
+func (s *Server) listCommits(c *call) {
+	r := c.repository(s)
+	if r == nil {
+		return
+	}
+	q := c.r.URL.Query()
+	branch := q.Get("searchCriteria.itemVersion.version")
+	author := q.Get("searchCriteria.author")
+	commits := []*azuredevops.GitCommitRef{}
+	for i := len(r.pushes) - 1; i >= 0; i-- {
+		p := r.pushes[i]
+		if branch != "" && p.RefUpdates[0].GetName() != "refs/heads/"+branch {
+			continue
+		}
+		for j := len(p.Commits) - 1; j >= 0; j-- {
+			commit := p.Commits[j]
+			if author != "" && commit.GetAuthor().GetName() != author && commit.GetAuthor().GetEmail() != author {
+				continue
+			}
+			commits = append(commits, commitRef(commit))
+		}
+	}
+	start, end, ok := c.page("searchCriteria.", len(commits))
+	if !ok {
+		return
+	}
+	c.list(commits[start:end], end-start)
+}
+
+func (s *Server) getCommit(c *call) {
+	r := c.repository(s)
+	if r == nil {
+		return
+	}
+	id := c.params[2]
+	for _, p := range r.pushes {
+		for _, commit := range p.Commits {
+			if commit.GetCommitID() == id {
+				c.json(http.StatusOK, commitRef(commit))
+				return
+			}
+		}
+	}
+	c.notFound("GitUnresolvableToCommitException", "TF401175: The version descriptor <Commit: %s> could not be resolved to a version in the repository.", id)
+}
+
+// createAnnotatedTag stores a tag object and creates its ref, whose peeled
+// object ID is the tagged object.
+func (s *Server) createAnnotatedTag(c *call) {
+	r := c.repository(s)
+	if r == nil {
+		return
+	}
+	tag := new(azuredevops.GitAnnotatedTag)
+	if !c.decode(tag) {
+		return
+	}
+	if tag.GetName() == "" || tag.GetTaggedObject().GetObjectID() == "" {
+		c.error(http.StatusBadRequest, "InvalidArgumentValueException", "A tag must have a name and a tagged object.")
+		return
+	}
+	tag.ObjectID = azuredevops.String(fmt.Sprintf("%x", sha1.Sum([]byte(fmt.Sprintf("tag %s %d", tag.GetName(), s.id())))))
+	tag.TaggedBy = &azuredevops.GitUserDate{Date: &azuredevops.Time{Time: time.Now().UTC()}}
+	name := "refs/tags/" + tag.GetName()
+	update := &azuredevops.GitRefUpdate{
+		Name:        azuredevops.String(name),
+		OldObjectID: azuredevops.String(azuredevops.ZeroObjectID),
+		NewObjectID: tag.ObjectID,
+	}
+	if r.updateRef(update) != azuredevops.RefUpdateSucceeded {
+		c.error(http.StatusConflict, "GitRefNameConflictException", fmt.Sprintf("TF401035: The ref %s already exists.", name))
+		return
+	}
+	for _, ref := range r.refs {
+		if ref.GetName() == name {
+			ref.PeeledObjectID = tag.TaggedObject.ObjectID
+		}
+	}
+	r.tags = append(r.tags, tag)
+	c.json(http.StatusCreated, tag)
+}
+
+func (s *Server) getAnnotatedTag(c *call) {
+	r := c.repository(s)
+	if r == nil {
+		return
+	}
+	for _, tag := range r.tags {
+		if tag.GetObjectID() == c.params[2] {
+			c.json(http.StatusOK, tag)
+			return
+		}
+	}
+	c.notFound("GitObjectNotFoundException", "TF401208: The object %s does not exist.", c.params[2])
+}
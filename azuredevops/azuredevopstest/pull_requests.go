@@ -0,0 +1,581 @@
+package azuredevopstest
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mcdafydd/go-azuredevops/azuredevops"
+)
+
+// pullRequest holds a pull request and its threads.
+type pullRequest struct {
+	project *project
+	pull    *azuredevops.GitPullRequest
+	threads []*azuredevops.GitPullRequestCommentThread
+}
+
+// AddPullRequest seeds a pull request in a repository which was added with
+// AddRepository. The pull request is assigned an ID, and defaults to the
+// active status. A copy of the stored pull request is returned.
+func (s *Server) AddPullRequest(owner, project, repo string, pull *azuredevops.GitPullRequest) *azuredevops.GitPullRequest {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	p := s.project(owner, project)
+	r := p.repository(repo)
+	if r == nil {
+		panic("azuredevopstest: AddPullRequest to unknown repository " + repo)
+	}
+	pr := s.addPullRequest(p, r, pull)
+	out := new(azuredevops.GitPullRequest)
+	clone(pr.pull, out)
+	return out
+}
+
+func (s *Server) addPullRequest(p *project, r *repository, pull *azuredevops.GitPullRequest) *pullRequest {
+	pr := &pullRequest{project: p, pull: new(azuredevops.GitPullRequest)}
+	clone(pull, pr.pull)
+	pr.pull.PullRequestID = azuredevops.Int(s.id())
+	pr.pull.Repository = r.repo
+	if pr.pull.Status == nil {
+		pr.pull.Status = azuredevops.String("active")
+	}
+	if pr.pull.CreationDate == nil {
+		pr.pull.CreationDate = &azuredevops.Time{Time: time.Now().UTC()}
+	}
+	s.pulls = append(s.pulls, pr)
+	return pr
+}
+
+// AddThread seeds a comment thread on a pull request, assigning IDs to the
+// thread and its comments, and returns a copy of the stored thread.
+func (s *Server) AddThread(pullRequestID int, thread *azuredevops.GitPullRequestCommentThread) *azuredevops.GitPullRequestCommentThread {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	pr := s.pullRequest(pullRequestID)
+	if pr == nil {
+		panic("azuredevopstest: AddThread to unknown pull request")
+	}
+	t := pr.addThread(thread)
+	out := new(azuredevops.GitPullRequestCommentThread)
+	clone(t, out)
+	return out
+}
+
+// Threads returns the comment threads of a pull request in the server.
+func (s *Server) Threads(pullRequestID int) []*azuredevops.GitPullRequestCommentThread {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	pr := s.pullRequest(pullRequestID)
+	if pr == nil {
+		return nil
+	}
+	var threads []*azuredevops.GitPullRequestCommentThread
+	clone(pr.threads, &threads)
+	return threads
+}
+
+func (pr *pullRequest) addThread(thread *azuredevops.GitPullRequestCommentThread) *azuredevops.GitPullRequestCommentThread {
+	t := new(azuredevops.GitPullRequestCommentThread)
+	clone(thread, t)
+	t.ID = azuredevops.Int(len(pr.threads) + 1)
+	now := &azuredevops.Time{Time: time.Now().UTC()}
+	t.PublishedDate, t.LastUpdatedDate = now, now
+	for i, comment := range t.Comments {
+		comment.ID = azuredevops.Int(i + 1)
+		comment.PublishedDate, comment.LastUpdatedDate = now, now
+	}
+	pr.threads = append(pr.threads, t)
+	return t
+}
+
+func (s *Server) pullRequest(id int) *pullRequest {
+	for _, pr := range s.pulls {
+		if pr.pull.GetPullRequestID() == id {
+			return pr
+		}
+	}
+	return nil
+}
+
+// pullRequest returns the pull request whose ID is route parameter i,
+// writing a 404 if it does not exist in the project.
+func (c *call) pullRequest(s *Server, i int) *pullRequest {
+	p := c.project(s)
+	pr := s.pullRequest(c.intParam(i))
+	if pr == nil || pr.project != p {
+		c.notFound("GitPullRequestNotFoundException", "TF401180: The requested pull request was not found.")
+		return nil
+	}
+	return pr
+}
+
+// thread returns the thread whose ID is route parameter i, writing a 404 if
+// it does not exist.
+func (c *call) thread(pr *pullRequest, i int) *azuredevops.GitPullRequestCommentThread {
+	id := c.intParam(i)
+	for _, t := range pr.threads {
+		if t.GetID() == id {
+			return t
+		}
+	}
+	c.notFound("CommentThreadNotFoundException", "The requested comment thread %d was not found.", id)
+	return nil
+}
+
+// comment returns the comment of thread t whose ID is route parameter i,
+// writing a 404 if it does not exist.
+func (c *call) comment(t *azuredevops.GitPullRequestCommentThread, i int) *azuredevops.Comment {
+	id := c.intParam(i)
+	for _, comment := range t.Comments {
+		if comment.GetID() == id {
+			return comment
+		}
+	}
+	c.notFound("CommentNotFoundException", "The requested comment %d was not found.", id)
+	return nil
+}
+
+// AuthenticatedUser is the identity the Server attributes likes to.
+var AuthenticatedUser = azuredevops.IdentityRef{
+	ID:          azuredevops.String("00000000-0000-0000-0000-000000000001"),
+	DisplayName: azuredevops.String("Fake User"),
+	UniqueName:  azuredevops.String("fake.user@example.com"),
+}
+
+func (s *Server) pullRequestRoutes() {
+	s.handle("GET", `git/pullrequests`, s.listPullRequests)
+	s.handle("GET", `git/pullrequests/(\d+)`, s.getPullRequest)
+	s.handle("GET", `git/repositories/([^/]+)/pullrequests`, s.listPullRequests)
+	s.handle("POST", `git/repositories/([^/]+)/pullrequests`, s.createPullRequest)
+	s.handle("GET", `git/repositories/([^/]+)/pullrequests/(\d+)`, s.getRepositoryPullRequest)
+	s.handle("PATCH", `git/repositories/([^/]+)/pullrequests/(\d+)`, s.updatePullRequest)
+	s.handle("GET", `git/repositories/([^/]+)/pullrequests/(\d+)/reviewers`, s.listReviewers)
+	s.handle("POST", `git/repositories/([^/]+)/pullrequests/(\d+)/reviewers`, s.addReviewers)
+	s.handle("GET", `git/repositories/([^/]+)/pullrequests/(\d+)/reviewers/([^/]+)`, s.getReviewer)
+	s.handle("PUT", `git/repositories/([^/]+)/pullrequests/(\d+)/reviewers/([^/]+)`, s.putReviewer)
+	s.handle("DELETE", `git/repositories/([^/]+)/pullrequests/(\d+)/reviewers/([^/]+)`, s.removeReviewer)
+	s.handle("GET", `git/repositories/([^/]+)/pullrequests/(\d+)/threads`, s.listThreads)
+	s.handle("POST", `git/repositories/([^/]+)/pullrequests/(\d+)/threads`, s.createThread)
+	s.handle("GET", `git/repositories/([^/]+)/pullrequests/(\d+)/threads/(\d+)`, s.getThread)
+	s.handle("PATCH", `git/repositories/([^/]+)/pullrequests/(\d+)/threads/(\d+)`, s.updateThread)
+	s.handle("POST", `git/repositories/([^/]+)/pullrequests/(\d+)/threads/(\d+)/comments`, s.createComment)
+	s.handle("PATCH", `git/repositories/([^/]+)/pullrequests/(\d+)/threads/(\d+)/comments/(\d+)`, s.updateComment)
+	s.handle("DELETE", `git/repositories/([^/]+)/pullrequests/(\d+)/threads/(\d+)/comments/(\d+)`, s.deleteComment)
+	s.handle("GET", `git/repositories/([^/]+)/pullrequests/(\d+)/threads/(\d+)/comments/(\d+)/likes`, s.listLikes)
+	s.handle("POST", `git/repositories/([^/]+)/pullrequests/(\d+)/threads/(\d+)/comments/(\d+)/likes`, s.likeComment)
+	s.handle("DELETE", `git/repositories/([^/]+)/pullrequests/(\d+)/threads/(\d+)/comments/(\d+)/likes`, s.unlikeComment)
+}
+
+// listPullRequests lists the pull requests of a project, or of the
+// repository named in the path, newest first.
+func (s *Server) listPullRequests(c *call) {
+	p := c.project(s)
+	var repo *repository
+	if len(c.params) > 1 {
+		if repo = c.repository(s); repo == nil {
+			return
+		}
+	}
+
+	q := c.r.URL.Query()
+	status := q.Get("searchCriteria.status")
+	if status == "" {
+		status = "active"
+	}
+	pulls := []*azuredevops.GitPullRequest{}
+	for _, pr := range s.pulls {
+		switch {
+		case pr.project != p,
+			repo != nil && pr.pull.Repository != repo.repo,
+			status != "all" && !strings.EqualFold(pr.pull.GetStatus(), status),
+			!matchParam(q, "searchCriteria.sourceRefName", pr.pull.GetSourceRefName()),
+			!matchParam(q, "searchCriteria.targetRefName", pr.pull.GetTargetRefName()),
+			!matchParam(q, "searchCriteria.repositoryId", pr.pull.Repository.GetID()),
+			!matchParam(q, "searchCriteria.creatorId", pr.pull.GetCreatedBy().GetID()):
+			continue
+		}
+		if reviewer := q.Get("searchCriteria.reviewerId"); reviewer != "" && !hasReviewer(pr.pull, reviewer) {
+			continue
+		}
+		pulls = append(pulls, pr.pull)
+	}
+	sort.SliceStable(pulls, func(i, j int) bool {
+		return pulls[i].GetPullRequestID() > pulls[j].GetPullRequestID()
+	})
+	start, end, ok := c.page("", len(pulls))
+	if !ok {
+		return
+	}
+	c.list(pulls[start:end], end-start)
+}
+
+func matchParam(q map[string][]string, key, value string) bool {
+	want := ""
+	if v := q[key]; len(v) > 0 {
+		want = v[0]
+	}
+	return want == "" || strings.EqualFold(want, value)
+}
+
+func hasReviewer(pull *azuredevops.GitPullRequest, id string) bool {
+	for _, r := range pull.Reviewers {
+		if strings.EqualFold(r.GetID(), id) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Server) getPullRequest(c *call) {
+	if pr := c.pullRequest(s, 1); pr != nil {
+		c.json(http.StatusOK, pr.pull)
+	}
+}
+
+func (s *Server) createPullRequest(c *call) {
+	repo := c.repository(s)
+	if repo == nil {
+		return
+	}
+	pull := new(azuredevops.GitPullRequest)
+	if !c.decode(pull) {
+		return
+	}
+	if pull.GetSourceRefName() == "" || pull.GetTargetRefName() == "" {
+		c.error(http.StatusBadRequest, "InvalidArgumentValueException", "The pull request must have a source and target ref.")
+		return
+	}
+	for _, pr := range s.pulls {
+		if pr.pull.Repository == repo.repo && pr.pull.GetStatus() == "active" &&
+			pr.pull.GetSourceRefName() == pull.GetSourceRefName() && pr.pull.GetTargetRefName() == pull.GetTargetRefName() {
+			c.error(http.StatusConflict, "GitPullRequestExistsException", "TF401179: An active pull request for the source and target branch already exists.")
+			return
+		}
+	}
+	pull.Status = nil
+	pr := s.addPullRequest(c.project(s), repo, pull)
+	c.json(http.StatusCreated, pr.pull)
+}
+
+func (s *Server) getRepositoryPullRequest(c *call) {
+	if c.repository(s) == nil {
+		return
+	}
+	if pr := c.pullRequest(s, 2); pr != nil {
+		c.json(http.StatusOK, pr.pull)
+	}
+}
+
+// updatePullRequest applies the fields set in the request body to the pull
+// request.
+func (s *Server) updatePullRequest(c *call) {
+	if c.repository(s) == nil {
+		return
+	}
+	pr := c.pullRequest(s, 2)
+	if pr == nil {
+		return
+	}
+	updated := new(azuredevops.GitPullRequest)
+	clone(pr.pull, updated)
+	if !c.decode(updated) {
+		return
+	}
+	updated.PullRequestID = pr.pull.PullRequestID
+	updated.Repository = pr.pull.Repository
+	if updated.GetStatus() != pr.pull.GetStatus() && updated.GetStatus() != "active" {
+		updated.ClosedDate = &azuredevops.Time{Time: time.Now().UTC()}
+	}
+	pr.pull = updated
+	c.json(http.StatusOK, pr.pull)
+}
+
+func (s *Server) listThreads(c *call) {
+	if c.repository(s) == nil {
+		return
+	}
+	if pr := c.pullRequest(s, 2); pr != nil {
+		threads := append([]*azuredevops.GitPullRequestCommentThread{}, pr.threads...)
+		c.list(threads, len(threads))
+	}
+}
+
+func (s *Server) createThread(c *call) {
+	if c.repository(s) == nil {
+		return
+	}
+	pr := c.pullRequest(s, 2)
+	if pr == nil {
+		return
+	}
+	thread := new(azuredevops.GitPullRequestCommentThread)
+	if !c.decode(thread) {
+		return
+	}
+	c.json(http.StatusOK, pr.addThread(thread))
+}
+
+func (s *Server) getThread(c *call) {
+	if c.repository(s) == nil {
+		return
+	}
+	if pr := c.pullRequest(s, 2); pr != nil {
+		if t := c.thread(pr, 3); t != nil {
+			c.json(http.StatusOK, t)
+		}
+	}
+}
+
+func (s *Server) createComment(c *call) {
+	if c.repository(s) == nil {
+		return
+	}
+	pr := c.pullRequest(s, 2)
+	if pr == nil {
+		return
+	}
+	t := c.thread(pr, 3)
+	if t == nil {
+		return
+	}
+	comment := new(azuredevops.Comment)
+	if !c.decode(comment) {
+		return
+	}
+	now := &azuredevops.Time{Time: time.Now().UTC()}
+	comment.ID = azuredevops.Int(len(t.Comments) + 1)
+	comment.PublishedDate, comment.LastUpdatedDate = now, now
+	t.Comments = append(t.Comments, comment)
+	t.LastUpdatedDate = now
+	c.json(http.StatusOK, comment)
+}
+
+// threadComment resolves the pull request, thread and comment named in the
+// route, writing an error if any of them does not exist.
+func (s *Server) threadComment(c *call) (*azuredevops.GitPullRequestCommentThread, *azuredevops.Comment) {
+	if c.repository(s) == nil {
+		return nil, nil
+	}
+	pr := c.pullRequest(s, 2)
+	if pr == nil {
+		return nil, nil
+	}
+	t := c.thread(pr, 3)
+	if t == nil {
+		return nil, nil
+	}
+	comment := c.comment(t, 4)
+	if comment == nil {
+		return nil, nil
+	}
+	return t, comment
+}
+
+// updateThread applies the status and properties of the request body to a
+// thread.
+func (s *Server) updateThread(c *call) {
+	if c.repository(s) == nil {
+		return
+	}
+	pr := c.pullRequest(s, 2)
+	if pr == nil {
+		return
+	}
+	t := c.thread(pr, 3)
+	if t == nil {
+		return
+	}
+	update := new(azuredevops.GitPullRequestCommentThread)
+	if !c.decode(update) {
+		return
+	}
+	if update.Status != nil {
+		t.Status = update.Status
+	}
+	for k, v := range update.Properties {
+		if t.Properties == nil {
+			t.Properties = make(map[string]interface{})
+		}
+		t.Properties[k] = v
+	}
+	t.LastUpdatedDate = &azuredevops.Time{Time: time.Now().UTC()}
+	c.json(http.StatusOK, t)
+}
+
+func (s *Server) updateComment(c *call) {
+	t, comment := s.threadComment(c)
+	if comment == nil {
+		return
+	}
+	update := new(azuredevops.Comment)
+	if !c.decode(update) {
+		return
+	}
+	now := &azuredevops.Time{Time: time.Now().UTC()}
+	comment.Content = update.Content
+	comment.LastContentUpdatedDate, comment.LastUpdatedDate = now, now
+	t.LastUpdatedDate = now
+	c.json(http.StatusOK, comment)
+}
+
+// deleteComment marks a comment deleted; like the service, it stays in its
+// thread.
+func (s *Server) deleteComment(c *call) {
+	t, comment := s.threadComment(c)
+	if comment == nil {
+		return
+	}
+	now := &azuredevops.Time{Time: time.Now().UTC()}
+	comment.IsDeleted = azuredevops.Bool(true)
+	comment.LastUpdatedDate, t.LastUpdatedDate = now, now
+	c.noContent()
+}
+
+func (s *Server) listLikes(c *call) {
+	if _, comment := s.threadComment(c); comment != nil {
+		likes := append([]*azuredevops.IdentityRef{}, comment.UsersLiked...)
+		c.list(likes, len(likes))
+	}
+}
+
+func (s *Server) likeComment(c *call) {
+	_, comment := s.threadComment(c)
+	if comment == nil {
+		return
+	}
+	for _, u := range comment.UsersLiked {
+		if u.GetID() == AuthenticatedUser.GetID() {
+			c.noContent()
+			return
+		}
+	}
+	user := AuthenticatedUser
+	comment.UsersLiked = append(comment.UsersLiked, &user)
+	c.noContent()
+}
+
+func (s *Server) unlikeComment(c *call) {
+	_, comment := s.threadComment(c)
+	if comment == nil {
+		return
+	}
+	likes := comment.UsersLiked[:0]
+	for _, u := range comment.UsersLiked {
+		if u.GetID() != AuthenticatedUser.GetID() {
+			likes = append(likes, u)
+		}
+	}
+	comment.UsersLiked = likes
+	c.noContent()
+}
+
+// repositoryPullRequest resolves the repository and pull request named in
+// the route, writing a 404 if either does not exist.
+func (s *Server) repositoryPullRequest(c *call) *pullRequest {
+	if c.repository(s) == nil {
+		return nil
+	}
+	return c.pullRequest(s, 2)
+}
+
+// reviewer returns the reviewer of pr with the given ID, or nil.
+func (pr *pullRequest) reviewer(id string) *azuredevops.IdentityRefWithVote {
+	for _, r := range pr.pull.Reviewers {
+		if strings.EqualFold(r.GetID(), id) {
+			return r
+		}
+	}
+	return nil
+}
+
+// setReviewer adds a reviewer to pr, or updates the vote and required flag
+// of an existing one, and returns the stored reviewer.
+func (pr *pullRequest) setReviewer(id string, update *azuredevops.IdentityRefWithVote) *azuredevops.IdentityRefWithVote {
+	r := pr.reviewer(id)
+	if r == nil {
+		r = &azuredevops.IdentityRefWithVote{
+			IdentityRef: azuredevops.IdentityRef{ID: azuredevops.String(id)},
+			IsRequired:  azuredevops.Bool(false),
+			Vote:        azuredevops.Int(0),
+		}
+		pr.pull.Reviewers = append(pr.pull.Reviewers, r)
+	}
+	if update.IsRequired != nil {
+		r.IsRequired = update.IsRequired
+	}
+	if update.Vote != nil {
+		r.Vote = update.Vote
+	}
+	return r
+}
+
+func (s *Server) listReviewers(c *call) {
+	if pr := s.repositoryPullRequest(c); pr != nil {
+		reviewers := append([]*azuredevops.IdentityRefWithVote{}, pr.pull.Reviewers...)
+		c.list(reviewers, len(reviewers))
+	}
+}
+
+func (s *Server) getReviewer(c *call) {
+	pr := s.repositoryPullRequest(c)
+	if pr == nil {
+		return
+	}
+	r := pr.reviewer(c.params[3])
+	if r == nil {
+		c.notFound("IdentityNotFoundException", "The reviewer %s was not found.", c.params[3])
+		return
+	}
+	c.json(http.StatusOK, r)
+}
+
+func (s *Server) putReviewer(c *call) {
+	pr := s.repositoryPullRequest(c)
+	if pr == nil {
+		return
+	}
+	update := new(azuredevops.IdentityRefWithVote)
+	if !c.decode(update) {
+		return
+	}
+	c.json(http.StatusOK, pr.setReviewer(c.params[3], update))
+}
+
+func (s *Server) addReviewers(c *call) {
+	pr := s.repositoryPullRequest(c)
+	if pr == nil {
+		return
+	}
+	var reviewers []*azuredevops.IdentityRefWithVote
+	if !c.decode(&reviewers) {
+		return
+	}
+	added := []*azuredevops.IdentityRefWithVote{}
+	for _, r := range reviewers {
+		if r.GetID() == "" {
+			c.error(http.StatusBadRequest, "InvalidArgumentValueException", "The reviewer must have an ID.")
+			return
+		}
+	}
+	for _, r := range reviewers {
+		added = append(added, pr.setReviewer(r.GetID(), r))
+	}
+	c.list(added, len(added))
+}
+
+func (s *Server) removeReviewer(c *call) {
+	pr := s.repositoryPullRequest(c)
+	if pr == nil {
+		return
+	}
+	reviewers := pr.pull.Reviewers[:0]
+	for _, r := range pr.pull.Reviewers {
+		if !strings.EqualFold(r.GetID(), c.params[3]) {
+			reviewers = append(reviewers, r)
+		}
+	}
+	pr.pull.Reviewers = reviewers
+	c.noContent()
+}
@@ -0,0 +1,398 @@
+package azuredevopstest
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/mcdafydd/go-azuredevops/azuredevops"
+)
+
+// RecorderMode selects whether a Recorder records or replays traffic.
+type RecorderMode int
+
+// RecorderMode enum values
+const (
+	// ModeReplay serves responses from the cassette, without network access.
+	ModeReplay RecorderMode = iota
+	// ModeRecord sends requests to the service and records the traffic.
+	ModeRecord
+)
+
+func (m RecorderMode) String() string {
+	return [...]string{"replay", "record"}[m]
+}
+
+// Cassette holds recorded interactions with the service.
+type Cassette struct {
+	Interactions []*Interaction `json:"interactions"`
+}
+
+// Interaction is a recorded request and the response it received.
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// RecordedRequest is a sanitized request in a Cassette.
+type RecordedRequest struct {
+	Method string      `json:"method"`
+	URL    string      `json:"url"`
+	Header http.Header `json:"header,omitempty"`
+	Body   Body        `json:"body,omitempty"`
+}
+
+// RecordedResponse is a sanitized response in a Cassette.
+type RecordedResponse struct {
+	StatusCode int         `json:"statusCode"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       Body        `json:"body,omitempty"`
+}
+
+// Body is a recorded body. It is stored as text when it is valid UTF-8, and
+// as base64 otherwise.
+type Body []byte
+
+// MarshalJSON implements json.Marshaler.
+func (b Body) MarshalJSON() ([]byte, error) {
+	if utf8.Valid(b) {
+		return json.Marshal(string(b))
+	}
+	return json.Marshal(map[string]string{"base64": base64.StdEncoding.EncodeToString(b)})
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (b *Body) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		*b = Body(s)
+		return nil
+	}
+	var encoded map[string]string
+	if err := json.Unmarshal(data, &encoded); err != nil {
+		return err
+	}
+	decoded, err := base64.StdEncoding.DecodeString(encoded["base64"])
+	*b = decoded
+	return err
+}
+
+// Recorder is an http.RoundTripper which records traffic with Azure DevOps
+// to a cassette file, or replays it from one. Pass the client returned by
+// Client to azuredevops.NewClient.
+//
+// Credentials are removed before interactions are stored: Authorization and
+// cookie headers, user info in URLs, and secrets such as client_secret and
+// access_token in query parameters and in form-encoded or JSON bodies, as
+// sent to and returned by token endpoints. Sanitize can scrub anything else.
+//
+// On replay, requests are matched by method, path and api-version, and
+// repeated requests receive the recorded responses in order. A request with
+// no match fails with an *UnmatchedRequestError. Clients replaying a
+// cassette should set RetryPolicy to nil, so such failures aren't retried.
+type Recorder struct {
+	// Mode selects recording or replaying.
+	Mode RecorderMode
+	// Path is the cassette file.
+	Path string
+	// Transport sends requests when recording. It will default to
+	// http.DefaultTransport if nil.
+	Transport http.RoundTripper
+	// Sanitize, if set, is called on each interaction before it is stored,
+	// after the built-in redaction.
+	Sanitize func(*Interaction)
+
+	mu       sync.Mutex
+	cassette *Cassette
+	used     []bool
+}
+
+// NewRecorder returns a Recorder for the cassette at path. In replay mode
+// the cassette is loaded, and must exist.
+func NewRecorder(path string, mode RecorderMode) (*Recorder, error) {
+	r := &Recorder{Mode: mode, Path: path, cassette: &Cassette{}}
+	if mode == ModeRecord {
+		return r, nil
+	}
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("azuredevopstest: loading cassette: %v", err)
+	}
+	if err := json.Unmarshal(b, r.cassette); err != nil {
+		return nil, fmt.Errorf("azuredevopstest: loading cassette %s: %v", path, err)
+	}
+	r.used = make([]bool, len(r.cassette.Interactions))
+	return r, nil
+}
+
+// Client returns an *http.Client which sends requests through the recorder.
+func (r *Recorder) Client() *http.Client {
+	return &http.Client{Transport: r}
+}
+
+// RoundTrip implements the RoundTripper interface.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	if r.Mode == ModeRecord {
+		return r.record(req)
+	}
+	return r.replay(req)
+}
+
+func (r *Recorder) record(req *http.Request) (*http.Response, error) {
+	var reqBody []byte
+	if req.Body != nil {
+		var err error
+		if reqBody, err = ioutil.ReadAll(req.Body); err != nil {
+			return nil, err
+		}
+		req.Body.Close()
+		req.Body = ioutil.NopCloser(bytes.NewReader(reqBody))
+	}
+
+	transport := r.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	respBody, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(respBody))
+
+	u := *req.URL
+	i := &Interaction{
+		Request: RecordedRequest{
+			Method: req.Method,
+			URL:    redactURL(&u).String(),
+			Header: azuredevops.RedactHeader(req.Header),
+		},
+		Response: RecordedResponse{
+			StatusCode: resp.StatusCode,
+			Header:     azuredevops.RedactHeader(resp.Header),
+		},
+	}
+	i.Request.Body = redactBody(i.Request.Header, reqBody)
+	i.Response.Body = redactBody(i.Response.Header, respBody)
+	if r.Sanitize != nil {
+		r.Sanitize(i)
+	}
+
+	r.mu.Lock()
+	r.cassette.Interactions = append(r.cassette.Interactions, i)
+	r.mu.Unlock()
+	return resp, nil
+}
+
+func (r *Recorder) replay(req *http.Request) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := matchKey(req.Method, req.URL)
+	for n, i := range r.cassette.Interactions {
+		if r.used[n] {
+			continue
+		}
+		u, err := url.Parse(i.Request.URL)
+		if err != nil || matchKey(i.Request.Method, u) != key {
+			continue
+		}
+		r.used[n] = true
+		header := make(http.Header, len(i.Response.Header))
+		for k, v := range i.Response.Header {
+			header[k] = append([]string(nil), v...)
+		}
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", i.Response.StatusCode, http.StatusText(i.Response.StatusCode)),
+			StatusCode:    i.Response.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        header,
+			Body:          ioutil.NopCloser(bytes.NewReader(i.Response.Body)),
+			ContentLength: int64(len(i.Response.Body)),
+			Request:       req,
+		}, nil
+	}
+
+	if req.Body != nil {
+		req.Body.Close()
+	}
+	return nil, &UnmatchedRequestError{
+		Method:     req.Method,
+		Path:       req.URL.Path,
+		APIVersion: req.URL.Query().Get("api-version"),
+		Cassette:   r.Path,
+	}
+}
+
+// matchKey identifies the interactions which may answer a request.
+func matchKey(method string, u *url.URL) string {
+	return strings.ToUpper(method) + " " + strings.ToLower(u.Path) + " " + u.Query().Get("api-version")
+}
+
+// UnmatchedRequestError is returned on replay for a request which does not
+// match any remaining recorded interaction.
+type UnmatchedRequestError struct {
+	Method     string
+	Path       string
+	APIVersion string
+	Cassette   string
+}
+
+func (e *UnmatchedRequestError) Error() string {
+	return fmt.Sprintf("azuredevopstest: no recorded interaction in %s matches %s %s?api-version=%s; re-record the cassette",
+		e.Cassette, e.Method, e.Path, e.APIVersion)
+}
+
+// Save writes the recorded interactions to the cassette file, creating its
+// directory if needed. It does nothing in replay mode.
+func (r *Recorder) Save() error {
+	if r.Mode != ModeRecord {
+		return nil
+	}
+	r.mu.Lock()
+	b, err := json.MarshalIndent(r.cassette, "", "  ")
+	r.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(r.Path), 0755); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(r.Path, append(b, '\n'), 0644)
+}
+
+// AssertAllReplayed reports an error to t for each recorded interaction
+// which was not replayed. It does nothing in record mode.
+func (r *Recorder) AssertAllReplayed(t TestingT) {
+	t.Helper()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.Mode != ModeReplay {
+		return
+	}
+	for n, used := range r.used {
+		if !used {
+			i := r.cassette.Interactions[n]
+			t.Errorf("azuredevopstest: recorded interaction %s %s was not replayed", i.Request.Method, i.Request.URL)
+		}
+	}
+}
+
+func redactURL(u *url.URL) *url.URL {
+	u.User = nil
+	return azuredevops.RedactURL(u)
+}
+
+// sensitiveBodyKeys are form fields and JSON properties which may carry
+// credentials, such as those of OAuth token requests and responses.
+var sensitiveBodyKeys = []string{
+	"access_token", "assertion", "client_assertion", "client_secret",
+	"id_token", "password", "refresh_token", "token",
+}
+
+func isSensitiveBodyKey(k string) bool {
+	for _, s := range sensitiveBodyKeys {
+		if strings.EqualFold(k, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// redactBody returns body with the values of sensitive form fields or JSON
+// properties replaced, according to the Content-Type in h. When the body
+// changes, its Content-Length is removed from h.
+func redactBody(h http.Header, body []byte) []byte {
+	if len(body) == 0 {
+		return body
+	}
+	var redacted []byte
+	contentType := h.Get("Content-Type")
+	switch {
+	case strings.HasPrefix(contentType, "application/x-www-form-urlencoded"):
+		redacted = redactForm(body)
+	case strings.Contains(contentType, "json"):
+		redacted = redactJSON(body)
+	}
+	if redacted == nil {
+		return body
+	}
+	h.Del("Content-Length")
+	return redacted
+}
+
+// redactForm returns a form-encoded body with sensitive fields replaced, or
+// nil if it has none.
+func redactForm(body []byte) []byte {
+	form, err := url.ParseQuery(string(body))
+	if err != nil {
+		return nil
+	}
+	redacted := false
+	for k := range form {
+		if isSensitiveBodyKey(k) {
+			form.Set(k, "REDACTED")
+			redacted = true
+		}
+	}
+	if !redacted {
+		return nil
+	}
+	return []byte(form.Encode())
+}
+
+// redactJSON returns a JSON body with sensitive properties replaced at any
+// depth, or nil if it has none.
+func redactJSON(body []byte) []byte {
+	var v interface{}
+	d := json.NewDecoder(bytes.NewReader(body))
+	d.UseNumber()
+	if err := d.Decode(&v); err != nil {
+		return nil
+	}
+	if !redactValue(v) {
+		return nil
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil
+	}
+	return b
+}
+
+func redactValue(v interface{}) bool {
+	redacted := false
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, e := range v {
+			if _, ok := e.(string); ok && isSensitiveBodyKey(k) {
+				v[k] = "REDACTED"
+				redacted = true
+			} else if redactValue(e) {
+				redacted = true
+			}
+		}
+	case []interface{}:
+		for _, e := range v {
+			if redactValue(e) {
+				redacted = true
+			}
+		}
+	}
+	return redacted
+}
@@ -0,0 +1,120 @@
+package azuredevopstest_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mcdafydd/go-azuredevops/azuredevops"
+	"github.com/mcdafydd/go-azuredevops/azuredevops/azuredevopstest"
+)
+
+func TestRecorder(t *testing.T) {
+	dir, err := ioutil.TempDir("", "azuredevopstest")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	cassette := filepath.Join(dir, "testdata", "teams.json")
+
+	srv := azuredevopstest.NewServer()
+	srv.AddTeam("o", "p", &azuredevops.Team{Name: azuredevops.String("t")})
+
+	rec, err := azuredevopstest.NewRecorder(cassette, azuredevopstest.ModeRecord)
+	if err != nil {
+		t.Fatalf("NewRecorder returned error: %v", err)
+	}
+	tp := &azuredevops.BasicAuthTransport{Password: "my-pat", Transport: rec}
+	client, _ := azuredevops.NewClient(tp.Client())
+	u, _ := url.Parse(srv.URL + "/")
+	client.BaseURL = *u
+
+	if _, _, err := client.Teams.List(context.Background(), "o", "p", nil); err != nil {
+		t.Fatalf("Teams.List while recording returned error: %v", err)
+	}
+
+	tokenSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("client_secret") != "my-client-secret" {
+			t.Errorf("Token request client_secret = %q", r.FormValue("client_secret"))
+		}
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		fmt.Fprint(w, `{"access_token":"my-access-token","refresh_token":"my-refresh-token","token_type":"Bearer","expires_in":3600}`)
+	}))
+	defer tokenSrv.Close()
+	source := &azuredevops.ClientCredentialsTokenSource{
+		ClientID:     "app",
+		ClientSecret: "my-client-secret",
+		TokenURL:     tokenSrv.URL + "/token",
+		HTTPClient:   rec.Client(),
+	}
+	if tok, err := source.Token(context.Background()); err != nil || tok.AccessToken != "my-access-token" {
+		t.Fatalf("Token while recording returned %+v, %v", tok, err)
+	}
+
+	if err := rec.Save(); err != nil {
+		t.Fatalf("Save returned error: %v", err)
+	}
+	srv.Close()
+
+	b, err := ioutil.ReadFile(cassette)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(b), "bXktcGF0") || strings.Contains(string(b), "my-pat") {
+		t.Errorf("Cassette contains the token: %s", b)
+	}
+	for _, secret := range []string{"my-client-secret", "my-access-token", "my-refresh-token"} {
+		if strings.Contains(string(b), secret) {
+			t.Errorf("Cassette contains %s: %s", secret, b)
+		}
+	}
+
+	rec, err = azuredevopstest.NewRecorder(cassette, azuredevopstest.ModeReplay)
+	if err != nil {
+		t.Fatalf("NewRecorder returned error: %v", err)
+	}
+	client, _ = azuredevops.NewClient(rec.Client())
+	client.BaseURL = *u
+	client.RetryPolicy = nil
+
+	teams, _, err := client.Teams.List(context.Background(), "o", "p", nil)
+	if err != nil {
+		t.Fatalf("Teams.List while replaying returned error: %v", err)
+	}
+	if len(teams) != 1 || teams[0].GetName() != "t" {
+		t.Errorf("Teams.List replayed %+v", teams)
+	}
+	source = &azuredevops.ClientCredentialsTokenSource{
+		ClientID:     "app",
+		ClientSecret: "my-client-secret",
+		TokenURL:     tokenSrv.URL + "/token",
+		HTTPClient:   rec.Client(),
+	}
+	if tok, err := source.Token(context.Background()); err != nil || tok.AccessToken != "REDACTED" {
+		t.Errorf("Token while replaying returned %+v, %v", tok, err)
+	}
+	rec.AssertAllReplayed(t)
+
+	_, _, err = client.Teams.List(context.Background(), "o", "p", nil)
+	var unmatched *azuredevopstest.UnmatchedRequestError
+	if !errors.As(err, &unmatched) {
+		t.Fatalf("Unrecorded request returned %v, want *UnmatchedRequestError", err)
+	}
+	if unmatched.Path != "/o/p/_apis/teams" || unmatched.APIVersion == "" {
+		t.Errorf("UnmatchedRequestError = %+v", unmatched)
+	}
+}
+
+func TestNewRecorder_missingCassette(t *testing.T) {
+	if _, err := azuredevopstest.NewRecorder("testdata/missing.json", azuredevopstest.ModeReplay); err == nil {
+		t.Error("Expected error replaying a missing cassette")
+	}
+}
@@ -0,0 +1,409 @@
+// Package azuredevopstest provides an in-memory fake of the Azure DevOps REST
+// API for testing code which uses the azuredevops package.
+//
+// The fake is stateful: data seeded with the Add methods, or created through
+// the API, is returned by later requests. It covers repositories, refs and
+// pushes, pull requests and their threads, builds, work items, team
+// iterations, teams and users. Every request is recorded, so tests can assert
+// on what was sent.
+//
+//	srv := azuredevopstest.NewServer()
+//	defer srv.Close()
+//	srv.AddRepository("org", "project", &azuredevops.GitRepository{Name: azuredevops.String("repo")})
+//	client := srv.Client()
+//	// exercise code using client
+//	srv.AssertRequested(t, "GET", "/org/project/_apis/git/repositories/repo")
+package azuredevopstest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/mcdafydd/go-azuredevops/azuredevops"
+)
+
+// Server is a fake Azure DevOps service. It is safe for concurrent use.
+type Server struct {
+	*httptest.Server
+
+	// PageSize limits the number of items returned by each request to
+	// endpoints which page with continuation tokens, to exercise paging.
+	// Zero means no limit.
+	PageSize int
+
+	mu        sync.Mutex
+	projects  map[string]*project // keyed by lowercase "owner/project"
+	users     map[string][]*azuredevops.GraphUser
+	pulls     []*pullRequest
+	nextID    int
+	requests  []*Request
+	failures  []*failure
+	routeList []*route
+}
+
+// Request is a request received by a Server.
+type Request struct {
+	Method string
+	Path   string // unescaped path, without the query
+	Query  url.Values
+	Header http.Header
+	Body   []byte
+}
+
+// DecodeBody decodes the JSON body of the request into v.
+func (r *Request) DecodeBody(v interface{}) error {
+	return json.Unmarshal(r.Body, v)
+}
+
+type failure struct {
+	method, path string
+	status       int
+	body         *azuredevops.ErrorResponse
+}
+
+// NewServer starts and returns a new Server. The caller should call Close
+// when finished, to shut it down.
+func NewServer() *Server {
+	s := &Server{
+		projects: make(map[string]*project),
+		users:    make(map[string][]*azuredevops.GraphUser),
+		nextID:   1,
+	}
+	s.routes()
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// Client returns a client whose BaseURL and VsspsBaseURL point at the server.
+func (s *Server) Client() *azuredevops.Client {
+	client, _ := azuredevops.NewClient(s.Server.Client())
+	u, _ := url.Parse(s.URL + "/")
+	client.BaseURL = *u
+	client.VsspsBaseURL = *u
+	return client
+}
+
+// Requests returns the requests received by the server, in order.
+func (s *Server) Requests() []*Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]*Request(nil), s.requests...)
+}
+
+// ResetRequests forgets the requests received so far.
+func (s *Server) ResetRequests() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests = nil
+}
+
+// TestingT is the subset of testing.TB used by the assertion helpers.
+type TestingT interface {
+	Helper()
+	Errorf(format string, args ...interface{})
+}
+
+// AssertRequested reports an error to t unless the server received a
+// request with the given method and path, and returns the last such
+// request.
+func (s *Server) AssertRequested(t TestingT, method, path string) *Request {
+	t.Helper()
+	var found *Request
+	requests := s.Requests()
+	for _, r := range requests {
+		if r.Method == method && r.Path == path {
+			found = r
+		}
+	}
+	if found == nil {
+		var got []string
+		for _, r := range requests {
+			got = append(got, r.Method+" "+r.Path)
+		}
+		t.Errorf("azuredevopstest: no request %s %s, received %q", method, path, got)
+	}
+	return found
+}
+
+// AssertNotRequested reports an error to t if the server received a request
+// with the given method and path.
+func (s *Server) AssertNotRequested(t TestingT, method, path string) {
+	t.Helper()
+	for _, r := range s.Requests() {
+		if r.Method == method && r.Path == path {
+			t.Errorf("azuredevopstest: unexpected request %s %s", method, path)
+			return
+		}
+	}
+}
+
+// Fail makes the server answer requests with the given method and path with
+// an error status and an Azure DevOps error body, until Reset is called.
+func (s *Server) Fail(method, path string, status int, typeKey, message string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failures = append(s.failures, &failure{method, path, status, &azuredevops.ErrorResponse{
+		TypeKey: typeKey,
+		Message: message,
+	}})
+}
+
+// Reset removes all data, recorded requests and failures from the server.
+func (s *Server) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.projects = make(map[string]*project)
+	s.users = make(map[string][]*azuredevops.GraphUser)
+	s.pulls = nil
+	s.nextID = 1
+	s.requests = nil
+	s.failures = nil
+}
+
+// project holds the data of a single project.
+type project struct {
+	owner, name string
+	repos       []*repository
+	recycleBin  []*deletedRepository
+	builds      []*azuredevops.Build
+	workItems   []*azuredevops.WorkItem
+	teams       []*azuredevops.Team
+	iterations  map[string][]*iteration // keyed by lowercase team name
+}
+
+// project returns the project, creating it if needed. s.mu must be held.
+func (s *Server) project(owner, name string) *project {
+	key := strings.ToLower(owner + "/" + name)
+	p, ok := s.projects[key]
+	if !ok {
+		p = &project{owner: owner, name: name, iterations: make(map[string][]*iteration)}
+		s.projects[key] = p
+	}
+	return p
+}
+
+// id returns a new unique ID. s.mu must be held.
+func (s *Server) id() int {
+	id := s.nextID
+	s.nextID++
+	return id
+}
+
+// guid returns a new unique GUID. s.mu must be held.
+func (s *Server) guid() string {
+	return fmt.Sprintf("00000000-0000-0000-0000-%012d", s.id())
+}
+
+// call describes a request being served.
+type call struct {
+	w      http.ResponseWriter
+	r      *http.Request
+	body   []byte
+	scope  []string // path segments before _apis: owner, project and team
+	params []string // submatches of the route pattern
+
+	pageSize int
+}
+
+func (c *call) owner() string { return c.scope[0] }
+
+// project returns the project named in the path.
+func (c *call) project(s *Server) *project {
+	return s.project(c.scope[0], c.scope[1])
+}
+
+func (c *call) decode(v interface{}) bool {
+	if err := json.Unmarshal(c.body, v); err != nil {
+		c.error(http.StatusBadRequest, "InvalidArgumentValueException", "invalid request body: "+err.Error())
+		return false
+	}
+	return true
+}
+
+func (c *call) intParam(i int) int {
+	n, _ := strconv.Atoi(c.params[i])
+	return n
+}
+
+func (c *call) json(status int, v interface{}) {
+	c.w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	c.w.WriteHeader(status)
+	json.NewEncoder(c.w).Encode(v)
+}
+
+func (c *call) error(status int, typeKey, message string) {
+	c.json(status, &azuredevops.ErrorResponse{
+		TypeName: "Microsoft.TeamFoundation." + typeKey,
+		TypeKey:  typeKey,
+		Message:  message,
+	})
+}
+
+func (c *call) notFound(typeKey, format string, args ...interface{}) {
+	c.error(http.StatusNotFound, typeKey, fmt.Sprintf(format, args...))
+}
+
+// noContent writes an empty 204 response.
+func (c *call) noContent() {
+	c.w.WriteHeader(http.StatusNoContent)
+}
+
+// list writes a count/value list response.
+func (c *call) list(items interface{}, count int) {
+	c.json(http.StatusOK, map[string]interface{}{"count": count, "value": items})
+}
+
+// route maps a method and a path below _apis to a handler.
+type route struct {
+	method  string
+	pattern *regexp.Regexp
+	handler func(*call)
+	project bool // whether the path must name a project
+}
+
+// handle adds a route for an API of a project.
+func (s *Server) handle(method, pattern string, handler func(*call)) {
+	s.routeList = append(s.routeList, &route{method, regexp.MustCompile("(?i)^" + pattern + "$"), handler, true})
+}
+
+// handleOrg adds a route for an API of an organization.
+func (s *Server) handleOrg(method, pattern string, handler func(*call)) {
+	s.routeList = append(s.routeList, &route{method, regexp.MustCompile("(?i)^" + pattern + "$"), handler, false})
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := ioutil.ReadAll(r.Body)
+	path := r.URL.Path
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests = append(s.requests, &Request{
+		Method: r.Method,
+		Path:   path,
+		Query:  r.URL.Query(),
+		Header: r.Header.Clone(),
+		Body:   body,
+	})
+
+	c := &call{w: w, r: r, body: bytes.TrimSpace(body), pageSize: s.PageSize}
+	for _, f := range s.failures {
+		if f.method == r.Method && f.path == path {
+			c.json(f.status, f.body)
+			return
+		}
+	}
+
+	i := strings.Index(path, "/_apis/")
+	if i < 0 {
+		c.notFound("ResourceNotFoundException", "%s is not an API path", path)
+		return
+	}
+	scope, api := strings.Trim(path[:i], "/"), path[i+len("/_apis/"):]
+	if scope == "" {
+		c.notFound("ResourceNotFoundException", "%s has no organization", path)
+		return
+	}
+	c.scope = strings.Split(scope, "/")
+
+	for _, rt := range s.routeList {
+		m := rt.pattern.FindStringSubmatch(api)
+		if m == nil || rt.method != r.Method {
+			continue
+		}
+		if rt.project && len(c.scope) < 2 {
+			c.notFound("ProjectDoesNotExistWithNameException", "%s does not name a project", path)
+			return
+		}
+		c.params = m
+		rt.handler(c)
+		return
+	}
+	c.notFound("ApiResourceNotFoundException", "the fake server does not support %s %s", r.Method, path)
+}
+
+func (s *Server) routes() {
+	s.gitRoutes()
+	s.pullRequestRoutes()
+	s.buildRoutes()
+	s.workRoutes()
+	s.userRoutes()
+}
+
+// clone returns a deep copy of v, so that callers can't modify server state.
+func clone(v, out interface{}) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		panic(err)
+	}
+	if err := json.Unmarshal(b, out); err != nil {
+		panic(err)
+	}
+}
+
+// page returns the bounds of the page of n items requested with $top and
+// $skip, whose names are prefixed with prefix. A negative or malformed value
+// is rejected with a 400 response, and ok is false.
+func (c *call) page(prefix string, n int) (start, end int, ok bool) {
+	q := c.r.URL.Query()
+	skip, ok := c.pageParam(q, prefix+"$skip", 0)
+	if !ok {
+		return 0, 0, false
+	}
+	top, ok := c.pageParam(q, prefix+"$top", n)
+	if !ok {
+		return 0, 0, false
+	}
+	if skip > n {
+		skip = n
+	}
+	end = n
+	if top < n-skip {
+		end = skip + top
+	}
+	return skip, end, true
+}
+
+// pageParam parses a non-negative paging parameter, returning def when it is
+// absent.
+func (c *call) pageParam(q url.Values, name string, def int) (int, bool) {
+	v := q.Get(name)
+	if v == "" {
+		return def, true
+	}
+	i, err := strconv.Atoi(v)
+	if err != nil || i < 0 {
+		c.error(http.StatusBadRequest, "InvalidArgumentValueException", fmt.Sprintf("The value %q of parameter %s is invalid.", v, name))
+		return 0, false
+	}
+	return i, true
+}
+
+// continuationPage returns the bounds of the page of n items requested with
+// $top and continuationToken, and sets the continuation token header if more
+// items remain.
+func (c *call) continuationPage(n int) (int, int) {
+	q := c.r.URL.Query()
+	start, _ := strconv.Atoi(q.Get("continuationToken"))
+	if start > n || start < 0 {
+		start = n
+	}
+	size, err := strconv.Atoi(q.Get("$top"))
+	if err != nil || size <= 0 || c.pageSize > 0 && size > c.pageSize {
+		size = c.pageSize
+	}
+	end := n
+	if size > 0 && start+size < n {
+		end = start + size
+		c.w.Header().Set("X-Ms-Continuationtoken", strconv.Itoa(end))
+	}
+	return start, end
+}
@@ -0,0 +1,603 @@
+package azuredevopstest_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/mcdafydd/go-azuredevops/azuredevops"
+	"github.com/mcdafydd/go-azuredevops/azuredevops/azuredevopstest"
+)
+
+func TestServer_repositoriesAndRefs(t *testing.T) {
+	srv := azuredevopstest.NewServer()
+	defer srv.Close()
+
+	repo := srv.AddRepository("o", "p", &azuredevops.GitRepository{Name: azuredevops.String("r")})
+	srv.AddRef("o", "p", "r", &azuredevops.GitRef{Name: azuredevops.String("refs/heads/master"), ObjectID: azuredevops.String("1")})
+	srv.AddRef("o", "p", "r", &azuredevops.GitRef{Name: azuredevops.String("refs/heads/feature"), ObjectID: azuredevops.String("2")})
+	srv.AddRef("o", "p", "r", &azuredevops.GitRef{Name: azuredevops.String("refs/tags/v1"), ObjectID: azuredevops.String("3")})
+	client := srv.Client()
+
+	got, _, err := client.Git.GetRepository(context.Background(), "o", "p", repo.GetID())
+	if err != nil {
+		t.Fatalf("GetRepository returned error: %v", err)
+	}
+	if got.GetName() != "r" {
+		t.Errorf("GetRepository returned %+v", got)
+	}
+
+	refs, _, err := client.Git.ListRefs(context.Background(), "o", "p", "r", "heads", nil)
+	if err != nil {
+		t.Fatalf("ListRefs returned error: %v", err)
+	}
+	if len(refs) != 2 {
+		t.Errorf("ListRefs returned %d refs, want 2", len(refs))
+	}
+
+	srv.PageSize = 1
+	all, err := client.Git.ListAllRefs(context.Background(), "o", "p", "r", "", nil)
+	if err != nil {
+		t.Fatalf("ListAllRefs returned error: %v", err)
+	}
+	if len(all) != 3 {
+		t.Errorf("ListAllRefs returned %d refs, want 3", len(all))
+	}
+
+	_, _, err = client.Git.GetRepository(context.Background(), "o", "p", "missing")
+	if !errors.Is(err, azuredevops.ErrNotFound) {
+		t.Errorf("GetRepository of a missing repository returned %v, want ErrNotFound", err)
+	}
+
+	srv.AssertRequested(t, "GET", "/o/p/_apis/git/repositories/r/refs/heads")
+}
+
+func TestServer_updateRefs(t *testing.T) {
+	srv := azuredevopstest.NewServer()
+	defer srv.Close()
+
+	srv.AddRepository("o", "p", &azuredevops.GitRepository{Name: azuredevops.String("r")})
+	srv.AddRef("o", "p", "r", &azuredevops.GitRef{Name: azuredevops.String("refs/heads/master"), ObjectID: azuredevops.String("1")})
+	client := srv.Client()
+	ctx := context.Background()
+
+	results, _, err := client.Git.UpdateRefs(ctx, "o", "p", "r", []*azuredevops.GitRefUpdate{
+		{Name: azuredevops.String("refs/heads/feature"), OldObjectID: azuredevops.String(azuredevops.ZeroObjectID), NewObjectID: azuredevops.String("1")},
+		{Name: azuredevops.String("refs/heads/master"), OldObjectID: azuredevops.String("stale"), NewObjectID: azuredevops.String("2")},
+	})
+	if err != nil {
+		t.Fatalf("UpdateRefs returned error: %v", err)
+	}
+	if !results[0].GetSuccess() || results[1].GetUpdateStatus() != azuredevops.RefUpdateStaleOldObjectID.String() {
+		t.Errorf("UpdateRefs returned %+v, %+v", results[0], results[1])
+	}
+
+	if _, _, err := client.Git.LockBranch(ctx, "o", "p", "r", "master"); err != nil {
+		t.Fatalf("LockBranch returned error: %v", err)
+	}
+	result, _, err := client.Git.ForceUpdateRef(ctx, "o", "p", "r", "master", "3")
+	var refErr *azuredevops.RefUpdateError
+	if !errors.As(err, &refErr) || !errors.Is(err, azuredevops.ErrConflict) {
+		t.Fatalf("ForceUpdateRef of a locked branch returned error %v, want a RefUpdateError", err)
+	}
+	if result.GetUpdateStatus() != azuredevops.RefUpdateLocked.String() {
+		t.Errorf("ForceUpdateRef of a locked branch returned status %q", result.GetUpdateStatus())
+	}
+
+	client.Git.UnlockBranch(ctx, "o", "p", "r", "master")
+	if result, _, _ = client.Git.ForceUpdateRef(ctx, "o", "p", "r", "master", "3"); !result.GetSuccess() {
+		t.Errorf("ForceUpdateRef returned %+v", result)
+	}
+	refs := srv.Refs("o", "p", "r")
+	if len(refs) != 2 || refs[0].GetObjectID() != "3" {
+		t.Errorf("Refs = %+v", refs)
+	}
+}
+
+func TestServer_pushes(t *testing.T) {
+	srv := azuredevopstest.NewServer()
+	defer srv.Close()
+
+	srv.AddRepository("o", "p", &azuredevops.GitRepository{Name: azuredevops.String("r")})
+	client := srv.Client()
+	ctx := context.Background()
+
+	push := azuredevops.NewPush("master", azuredevops.ZeroObjectID)
+	push.Commit("Initial commit").Add("/README.md", "# r\n")
+	push.Commit("Add license").Add("/LICENSE", "MIT\n")
+	created, _, err := client.Git.CreatePush(ctx, "o", "p", "r", push.Push())
+	if err != nil {
+		t.Fatalf("CreatePush returned error: %v", err)
+	}
+	head := created.RefUpdates[0].GetNewObjectID()
+	if head != created.Commits[1].GetCommitID() || *created.Commits[1].Parents[0] != created.Commits[0].GetCommitID() {
+		t.Errorf("CreatePush returned %+v", created)
+	}
+	if refs := srv.Refs("o", "p", "r"); len(refs) != 1 || refs[0].GetObjectID() != head {
+		t.Errorf("Refs = %+v, want master at %s", refs, head)
+	}
+
+	stale := azuredevops.NewPush("master", azuredevops.ZeroObjectID)
+	stale.Commit("Conflicting commit").Add("/README.md", "# conflict\n")
+	if _, _, err := client.Git.CreatePush(ctx, "o", "p", "r", stale.Push()); !errors.Is(err, azuredevops.ErrConflict) {
+		t.Errorf("CreatePush from a stale object ID returned %v, want ErrConflict", err)
+	}
+
+	pushes, _, err := client.Git.ListPushes(ctx, "o", "p", "r", &azuredevops.GitPushListOptions{RefName: "refs/heads/master"})
+	if err != nil || len(pushes) != 1 {
+		t.Errorf("ListPushes returned %d pushes, %v", len(pushes), err)
+	}
+	if got, _, err := client.Git.GetPush(ctx, "o", "p", "r", created.GetPushID(), nil); err != nil || got.GetPushID() != created.GetPushID() {
+		t.Errorf("GetPush returned %+v, %v", got, err)
+	}
+
+	commits, err := client.Git.ListAllCommits(ctx, "o", "p", "r", &azuredevops.GitQueryCommitsCriteria{
+		ItemVersion: azuredevops.BranchVersion("master"),
+		Top:         azuredevops.Int(1),
+	})
+	if err != nil || len(commits) != 2 || commits[0].GetCommitID() != head {
+		t.Errorf("ListAllCommits returned %+v, %v", commits, err)
+	}
+	commit, _, err := client.Git.GetCommit(ctx, "o", "p", "r", head, nil)
+	if err != nil || commit.GetComment() != "Add license" || (*commit.ChangeCounts)["Add"] != 1 {
+		t.Errorf("GetCommit returned %+v, %v", commit, err)
+	}
+}
+
+func TestServer_annotatedTags(t *testing.T) {
+	srv := azuredevopstest.NewServer()
+	defer srv.Close()
+
+	srv.AddRepository("o", "p", &azuredevops.GitRepository{Name: azuredevops.String("r")})
+	client := srv.Client()
+	ctx := context.Background()
+
+	tag := azuredevops.NewAnnotatedTag("v1.0", "Release 1.0", "abc", azuredevops.Commit)
+	created, _, err := client.Git.CreateAnnotatedTag(ctx, "o", "p", "r", tag)
+	if err != nil {
+		t.Fatalf("CreateAnnotatedTag returned error: %v", err)
+	}
+	if _, _, err := client.Git.CreateAnnotatedTag(ctx, "o", "p", "r", tag); !errors.Is(err, azuredevops.ErrConflict) {
+		t.Errorf("CreateAnnotatedTag of an existing tag returned %v, want ErrConflict", err)
+	}
+	refs := srv.Refs("o", "p", "r")
+	if len(refs) != 1 || refs[0].GetObjectID() != created.GetObjectID() || refs[0].GetPeeledObjectID() != "abc" {
+		t.Errorf("Refs = %+v, want refs/tags/v1.0 peeled to abc", refs)
+	}
+	got, _, err := client.Git.GetAnnotatedTag(ctx, "o", "p", "r", created.GetObjectID())
+	if err != nil || got.GetMessage() != "Release 1.0" {
+		t.Errorf("GetAnnotatedTag returned %+v, %v", got, err)
+	}
+
+	result, _, err := client.Git.DeleteTag(ctx, "o", "p", "r", "v1.0")
+	if err != nil || !result.GetSuccess() {
+		t.Errorf("DeleteTag returned %+v, %v", result, err)
+	}
+	if refs := srv.Refs("o", "p", "r"); len(refs) != 0 {
+		t.Errorf("Refs = %+v after DeleteTag, want none", refs)
+	}
+	if _, _, err := client.Git.DeleteTag(ctx, "o", "p", "r", "v1.0"); !errors.Is(err, azuredevops.ErrNotFound) {
+		t.Errorf("DeleteTag of a deleted tag returned %v, want ErrNotFound", err)
+	}
+}
+
+func TestServer_repositoryLifecycle(t *testing.T) {
+	srv := azuredevopstest.NewServer()
+	defer srv.Close()
+
+	client := srv.Client()
+	ctx := context.Background()
+
+	repo, _, err := client.Git.CreateRepository(ctx, "o", "p", &azuredevops.GitRepositoryCreateOptions{Name: azuredevops.String("svc")})
+	if err != nil {
+		t.Fatalf("CreateRepository returned error: %v", err)
+	}
+	if _, _, err := client.Git.CreateRepository(ctx, "o", "p", &azuredevops.GitRepositoryCreateOptions{Name: azuredevops.String("svc")}); !errors.Is(err, azuredevops.ErrConflict) {
+		t.Errorf("CreateRepository with a duplicate name returned %v, want ErrConflict", err)
+	}
+	srv.AddRef("o", "p", "svc", &azuredevops.GitRef{Name: azuredevops.String("refs/heads/master"), ObjectID: azuredevops.String("abc")})
+	srv.AddRef("o", "p", "svc", &azuredevops.GitRef{Name: azuredevops.String("refs/heads/topic"), ObjectID: azuredevops.String("def")})
+
+	parent := &azuredevops.GitRepositoryRef{ID: repo.ID}
+	fork, _, err := client.Git.CreateFork(ctx, "o", "q", "svc-fork", parent, "refs/heads/master")
+	if err != nil {
+		t.Fatalf("CreateFork returned error: %v", err)
+	}
+	if refs := srv.Refs("o", "q", "svc-fork"); len(refs) != 1 || refs[0].GetName() != "refs/heads/master" {
+		t.Errorf("fork refs = %+v, want only master", refs)
+	}
+	forks, _, err := client.Git.ListForks(ctx, "o", "p", "svc", "c1")
+	if err != nil || len(forks) != 1 || forks[0].GetID() != fork.GetID() {
+		t.Errorf("ListForks returned %+v, %v", forks, err)
+	}
+
+	update := &azuredevops.GitRepository{Name: azuredevops.String("renamed"), DefaultBranch: azuredevops.String("topic")}
+	if repo, _, err = client.Git.UpdateRepository(ctx, "o", "p", repo.GetID(), update); err != nil || repo.GetDefaultBranch() != "refs/heads/topic" {
+		t.Errorf("UpdateRepository returned %+v, %v", repo, err)
+	}
+
+	if _, err := client.Git.DeleteRepository(ctx, "o", "p", repo.GetID()); err != nil {
+		t.Fatalf("DeleteRepository returned error: %v", err)
+	}
+	if _, _, err := client.Git.GetRepository(ctx, "o", "p", "renamed"); !errors.Is(err, azuredevops.ErrNotFound) {
+		t.Errorf("GetRepository of a deleted repository returned %v, want ErrNotFound", err)
+	}
+	deleted, _, err := client.Git.ListRecycleBinRepositories(ctx, "o", "p")
+	if err != nil || len(deleted) != 1 || deleted[0].GetName() != "renamed" {
+		t.Errorf("ListRecycleBinRepositories returned %+v, %v", deleted, err)
+	}
+	if _, _, err := client.Git.RestoreRepository(ctx, "o", "p", repo.GetID()); err != nil {
+		t.Errorf("RestoreRepository returned error: %v", err)
+	}
+	repos, _, err := client.Git.ListRepositories(ctx, "o", "p", nil)
+	if err != nil || len(repos) != 1 {
+		t.Errorf("ListRepositories returned %+v, %v", repos, err)
+	}
+
+	client.Git.DeleteRepository(ctx, "o", "p", repo.GetID())
+	if _, err := client.Git.PurgeRepository(ctx, "o", "p", repo.GetID()); err != nil {
+		t.Errorf("PurgeRepository returned error: %v", err)
+	}
+	if _, _, err := client.Git.RestoreRepository(ctx, "o", "p", repo.GetID()); !errors.Is(err, azuredevops.ErrNotFound) {
+		t.Errorf("RestoreRepository of a purged repository returned %v, want ErrNotFound", err)
+	}
+}
+
+func TestServer_pullRequestsAndThreads(t *testing.T) {
+	srv := azuredevopstest.NewServer()
+	defer srv.Close()
+
+	srv.AddRepository("o", "p", &azuredevops.GitRepository{Name: azuredevops.String("r")})
+	client := srv.Client()
+	ctx := context.Background()
+
+	pull, _, err := client.PullRequests.Create(ctx, "o", "p", "r", &azuredevops.GitPullRequest{
+		Title:         azuredevops.String("Add feature"),
+		Description:   azuredevops.String("Adds a feature"),
+		SourceRefName: azuredevops.String("refs/heads/feature"),
+		TargetRefName: azuredevops.String("refs/heads/master"),
+	})
+	if err != nil {
+		t.Fatalf("Create returned error: %v", err)
+	}
+	if pull.GetPullRequestID() == 0 || pull.GetStatus() != "active" {
+		t.Errorf("Create returned %+v", pull)
+	}
+
+	_, _, err = client.PullRequests.Create(ctx, "o", "p", "r", &azuredevops.GitPullRequest{
+		Title:         azuredevops.String("Again"),
+		Description:   azuredevops.String("Again"),
+		SourceRefName: azuredevops.String("refs/heads/feature"),
+		TargetRefName: azuredevops.String("refs/heads/master"),
+	})
+	if !errors.Is(err, azuredevops.ErrConflict) {
+		t.Errorf("Creating a duplicate pull request returned %v, want ErrConflict", err)
+	}
+
+	pulls, _, err := client.PullRequests.List(ctx, "o", "p", &azuredevops.PullRequestListOptions{
+		SourceRefName: "refs/heads/feature",
+	})
+	if err != nil {
+		t.Fatalf("List returned error: %v", err)
+	}
+	if len(pulls) != 1 || pulls[0].GetTitle() != "Add feature" {
+		t.Errorf("List returned %+v", pulls)
+	}
+
+	thread, _, err := client.PullRequests.CreateComments(ctx, "o", "p", "r", pull.GetPullRequestID(), &azuredevops.GitPullRequestCommentThread{
+		Comments: []*azuredevops.Comment{{Content: azuredevops.String("Looks good")}},
+	})
+	if err != nil {
+		t.Fatalf("CreateComments returned error: %v", err)
+	}
+	_, _, err = client.PullRequests.CreateComment(ctx, "o", "p", "r", pull.GetPullRequestID(), thread.GetID(), &azuredevops.Comment{
+		Content:         azuredevops.String("Thanks"),
+		ParentCommentID: azuredevops.Int(1),
+	})
+	if err != nil {
+		t.Fatalf("CreateComment returned error: %v", err)
+	}
+
+	threads := srv.Threads(pull.GetPullRequestID())
+	if len(threads) != 1 || len(threads[0].Comments) != 2 {
+		t.Fatalf("Threads = %+v, want one thread with two comments", threads)
+	}
+	if got := threads[0].Comments[1].GetContent(); got != "Thanks" {
+		t.Errorf("Reply content = %q, want Thanks", got)
+	}
+
+	req := srv.AssertRequested(t, "POST", "/o/p/_apis/git/repositories/r/pullrequests")
+	var body azuredevops.GitPullRequest
+	if err := req.DecodeBody(&body); err != nil || body.GetTitle() != "Again" {
+		t.Errorf("Create request body = %+v, %v", body, err)
+	}
+}
+
+func TestServer_threadManagement(t *testing.T) {
+	srv := azuredevopstest.NewServer()
+	defer srv.Close()
+
+	srv.AddRepository("o", "p", &azuredevops.GitRepository{Name: azuredevops.String("r")})
+	pull := srv.AddPullRequest("o", "p", "r", &azuredevops.GitPullRequest{Title: azuredevops.String("Add feature")})
+	id := pull.GetPullRequestID()
+	srv.AddThread(id, &azuredevops.GitPullRequestCommentThread{
+		Status:   azuredevops.String("active"),
+		Comments: []*azuredevops.Comment{{Content: azuredevops.String("Typo")}, {Content: azuredevops.String("Where?")}},
+	})
+	client := srv.Client()
+	ctx := context.Background()
+
+	thread, _, err := client.PullRequests.SetThreadStatus(ctx, "o", "p", "r", id, 1, azuredevops.Fixed)
+	if err != nil {
+		t.Fatalf("SetThreadStatus returned error: %v", err)
+	}
+	if thread.GetStatus() != "fixed" || len(thread.Comments) != 2 {
+		t.Errorf("SetThreadStatus returned %+v", thread)
+	}
+
+	comment, _, err := client.PullRequests.UpdateComment(ctx, "o", "p", "r", id, 1, 1, &azuredevops.Comment{Content: azuredevops.String("Typo in README")})
+	if err != nil {
+		t.Fatalf("UpdateComment returned error: %v", err)
+	}
+	if comment.GetContent() != "Typo in README" || comment.LastContentUpdatedDate == nil {
+		t.Errorf("UpdateComment returned %+v", comment)
+	}
+	if _, err := client.PullRequests.DeleteComment(ctx, "o", "p", "r", id, 1, 2); err != nil {
+		t.Fatalf("DeleteComment returned error: %v", err)
+	}
+	if _, err := client.PullRequests.LikeComment(ctx, "o", "p", "r", id, 1, 1); err != nil {
+		t.Fatalf("LikeComment returned error: %v", err)
+	}
+	likes, _, err := client.PullRequests.ListCommentLikes(ctx, "o", "p", "r", id, 1, 1)
+	if err != nil || len(likes) != 1 || likes[0].GetID() != azuredevopstest.AuthenticatedUser.GetID() {
+		t.Errorf("ListCommentLikes returned %+v, %v", likes, err)
+	}
+	if _, err := client.PullRequests.UnlikeComment(ctx, "o", "p", "r", id, 1, 1); err != nil {
+		t.Fatalf("UnlikeComment returned error: %v", err)
+	}
+
+	threads, _, err := client.PullRequests.ListThreads(ctx, "o", "p", "r", id, nil)
+	if err != nil {
+		t.Fatalf("ListThreads returned error: %v", err)
+	}
+	if len(threads) != 1 || !threads[0].Comments[1].GetIsDeleted() || len(threads[0].Comments[0].UsersLiked) != 0 {
+		t.Errorf("ListThreads returned %+v", threads)
+	}
+
+	_, _, err = client.PullRequests.GetThread(ctx, "o", "p", "r", id, 9, nil)
+	if !errors.Is(err, azuredevops.ErrNotFound) {
+		t.Errorf("GetThread of a missing thread returned %v, want ErrNotFound", err)
+	}
+}
+
+func TestServer_reviewers(t *testing.T) {
+	srv := azuredevopstest.NewServer()
+	defer srv.Close()
+
+	srv.AddRepository("o", "p", &azuredevops.GitRepository{Name: azuredevops.String("r")})
+	pull := srv.AddPullRequest("o", "p", "r", &azuredevops.GitPullRequest{Title: azuredevops.String("Add feature")})
+	id := pull.GetPullRequestID()
+	client := srv.Client()
+	ctx := context.Background()
+
+	_, _, err := client.PullRequests.AddReviewers(ctx, "o", "p", "r", id, []*azuredevops.IdentityRefWithVote{
+		azuredevops.RequiredReviewer("lead"),
+		azuredevops.OptionalReviewer("peer"),
+	})
+	if err != nil {
+		t.Fatalf("AddReviewers returned error: %v", err)
+	}
+	if _, _, err := client.PullRequests.Vote(ctx, "o", "p", "r", id, "lead", azuredevops.VoteApprove); err != nil {
+		t.Fatalf("Vote returned error: %v", err)
+	}
+	if _, err := client.PullRequests.RemoveReviewer(ctx, "o", "p", "r", id, "peer"); err != nil {
+		t.Fatalf("RemoveReviewer returned error: %v", err)
+	}
+
+	reviewers, _, err := client.PullRequests.ListReviewers(ctx, "o", "p", "r", id)
+	if err != nil {
+		t.Fatalf("ListReviewers returned error: %v", err)
+	}
+	if len(reviewers) != 1 || reviewers[0].GetID() != "lead" || !reviewers[0].GetIsRequired() ||
+		reviewers[0].PullRequestVote() != azuredevops.VoteApprove {
+		t.Errorf("ListReviewers returned %+v", reviewers)
+	}
+
+	pulls, _, err := client.PullRequests.List(ctx, "o", "p", &azuredevops.PullRequestListOptions{ReviewerID: "lead"})
+	if err != nil || len(pulls) != 1 {
+		t.Errorf("List by reviewer returned %+v, %v", pulls, err)
+	}
+
+	_, _, err = client.PullRequests.GetReviewer(ctx, "o", "p", "r", id, "peer")
+	if !errors.Is(err, azuredevops.ErrNotFound) {
+		t.Errorf("GetReviewer of a removed reviewer returned %v, want ErrNotFound", err)
+	}
+}
+
+func TestServer_builds(t *testing.T) {
+	srv := azuredevopstest.NewServer()
+	defer srv.Close()
+
+	srv.AddBuild("o", "p", &azuredevops.Build{SourceBranch: azuredevops.String("refs/heads/master"), Status: azuredevops.String("completed")})
+	srv.AddBuild("o", "p", &azuredevops.Build{SourceBranch: azuredevops.String("refs/heads/feature"), Status: azuredevops.String("completed")})
+	client := srv.Client()
+	ctx := context.Background()
+
+	queued, _, err := client.Builds.Queue(ctx, "o", "p", &azuredevops.Build{
+		Definition:   &azuredevops.BuildDefinition{ID: azuredevops.Int(7)},
+		SourceBranch: azuredevops.String("refs/heads/master"),
+	}, nil)
+	if err != nil {
+		t.Fatalf("Queue returned error: %v", err)
+	}
+	if queued.GetStatus() != "notStarted" {
+		t.Errorf("Queue returned status %q, want notStarted", queued.GetStatus())
+	}
+
+	builds, _, err := client.Builds.List(ctx, "o", "p", &azuredevops.BuildsListOptions{Branch: azuredevops.String("refs/heads/master")})
+	if err != nil {
+		t.Fatalf("List returned error: %v", err)
+	}
+	if len(builds) != 2 || builds[0].GetID() != queued.GetID() {
+		t.Errorf("List returned %d builds, want 2 newest first", len(builds))
+	}
+
+	srv.PageSize = 2
+	all, err := client.Builds.ListAll(ctx, "o", "p", nil)
+	if err != nil {
+		t.Fatalf("ListAll returned error: %v", err)
+	}
+	if len(all) != 3 {
+		t.Errorf("ListAll returned %d builds, want 3", len(all))
+	}
+}
+
+func TestServer_workItemsAndIterations(t *testing.T) {
+	srv := azuredevopstest.NewServer()
+	defer srv.Close()
+
+	item := srv.AddWorkItem("o", "p", &azuredevops.WorkItem{
+		Fields: &map[string]interface{}{"System.Title": "Fix bug", "System.Rev": 3},
+	})
+	srv.AddIteration("o", "p", "t", &azuredevops.Iteration{Name: azuredevops.String("Sprint 1")}, item.GetID())
+	srv.AddTeam("o", "p", &azuredevops.Team{Name: azuredevops.String("t")})
+	client := srv.Client()
+	ctx := context.Background()
+
+	iteration, _, err := client.Iterations.GetByName(ctx, "o", "p", "t", "Sprint 1")
+	if err != nil || iteration == nil {
+		t.Fatalf("GetByName returned %v, %v", iteration, err)
+	}
+	items, _, err := client.WorkItems.GetForIteration(ctx, "o", "p", "t", *iteration)
+	if err != nil {
+		t.Fatalf("GetForIteration returned error: %v", err)
+	}
+	if len(items) != 1 || (*items[0].Fields)["System.Title"] != "Fix bug" {
+		t.Errorf("GetForIteration returned %+v", items)
+	}
+	if _, ok := (*items[0].Fields)["System.Rev"]; ok {
+		t.Error("Expected fields not requested to be omitted")
+	}
+
+	teams, _, err := client.Teams.List(ctx, "o", "p", nil)
+	if err != nil {
+		t.Fatalf("Teams.List returned error: %v", err)
+	}
+	if len(teams) != 1 || teams[0].GetName() != "t" {
+		t.Errorf("Teams.List returned %+v", teams)
+	}
+}
+
+func TestServer_users(t *testing.T) {
+	srv := azuredevopstest.NewServer()
+	defer srv.Close()
+
+	for _, name := range []string{"a", "b", "c"} {
+		u := &azuredevops.GraphUser{}
+		u.DisplayName = azuredevops.String(name)
+		srv.AddUser("o", u)
+	}
+	srv.PageSize = 2
+	client := srv.Client()
+
+	users, err := client.Users.ListAll(context.Background(), "o")
+	if err != nil {
+		t.Fatalf("ListAll returned error: %v", err)
+	}
+	if len(users) != 3 {
+		t.Fatalf("ListAll returned %d users, want 3", len(users))
+	}
+
+	user, _, err := client.Users.Get(context.Background(), "o", users[1].GetDescriptor())
+	if err != nil {
+		t.Fatalf("Get returned error: %v", err)
+	}
+	if user.GetDisplayName() != "b" {
+		t.Errorf("Get returned %+v", user)
+	}
+}
+
+func TestServer_Fail(t *testing.T) {
+	srv := azuredevopstest.NewServer()
+	defer srv.Close()
+
+	srv.Fail("GET", "/o/p/_apis/teams", http.StatusUnauthorized, "UnauthorizedRequestException", "denied")
+	_, _, err := srv.Client().Teams.List(context.Background(), "o", "p", nil)
+
+	var errResp *azuredevops.ErrorResponse
+	if !errors.As(err, &errResp) || errResp.Message != "denied" {
+		t.Fatalf("Teams.List returned %v, want the injected error", err)
+	}
+	if !errors.Is(err, azuredevops.ErrUnauthorized) {
+		t.Error("Expected error to match ErrUnauthorized")
+	}
+
+	srv.Reset()
+	if _, _, err := srv.Client().Teams.List(context.Background(), "o", "p", nil); err != nil {
+		t.Errorf("Teams.List after Reset returned error: %v", err)
+	}
+}
+
+func TestServer_paging(t *testing.T) {
+	srv := azuredevopstest.NewServer()
+	defer srv.Close()
+
+	for _, name := range []string{"a", "b", "c"} {
+		srv.AddTeam("o", "p", &azuredevops.Team{Name: azuredevops.String(name)})
+	}
+	client := srv.Client()
+	ctx := context.Background()
+
+	teams, _, err := client.Teams.List(ctx, "o", "p", &azuredevops.TeamsListOptions{Skip: azuredevops.Int(1), Top: azuredevops.Int(5)})
+	if err != nil || len(teams) != 2 || teams[0].GetName() != "b" {
+		t.Errorf("Teams.List returned %+v, %v, want teams b and c", teams, err)
+	}
+	teams, _, err = client.Teams.List(ctx, "o", "p", &azuredevops.TeamsListOptions{Skip: azuredevops.Int(5)})
+	if err != nil || len(teams) != 0 {
+		t.Errorf("Teams.List past the end returned %+v, %v, want no teams", teams, err)
+	}
+
+	for _, opts := range []*azuredevops.TeamsListOptions{
+		{Skip: azuredevops.Int(-1)},
+		{Top: azuredevops.Int(-1)},
+	} {
+		_, resp, err := client.Teams.List(ctx, "o", "p", opts)
+		var errResp *azuredevops.ErrorResponse
+		if !errors.As(err, &errResp) || resp == nil || resp.StatusCode != http.StatusBadRequest {
+			t.Errorf("Teams.List with skip %d, top %d returned %v, want a 400 error", opts.GetSkip(), opts.GetTop(), err)
+		}
+	}
+}
+
+type recordingT struct {
+	errors []string
+}
+
+func (r *recordingT) Helper() {}
+
+func (r *recordingT) Errorf(format string, args ...interface{}) {
+	r.errors = append(r.errors, format)
+}
+
+func TestServer_AssertRequested(t *testing.T) {
+	srv := azuredevopstest.NewServer()
+	defer srv.Close()
+
+	srv.Client().Teams.List(context.Background(), "o", "p", nil)
+
+	rt := &recordingT{}
+	if srv.AssertRequested(rt, "GET", "/o/p/_apis/teams") == nil || len(rt.errors) != 0 {
+		t.Error("AssertRequested failed for a received request")
+	}
+	srv.AssertRequested(rt, "POST", "/o/p/_apis/teams")
+	srv.AssertNotRequested(rt, "GET", "/o/p/_apis/teams")
+	if len(rt.errors) != 2 {
+		t.Errorf("Assertions reported %d errors, want 2", len(rt.errors))
+	}
+
+	srv.ResetRequests()
+	if n := len(srv.Requests()); n != 0 {
+		t.Errorf("Requests returned %d requests after ResetRequests", n)
+	}
+}
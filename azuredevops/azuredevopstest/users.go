@@ -0,0 +1,50 @@
+package azuredevopstest
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/mcdafydd/go-azuredevops/azuredevops"
+)
+
+// AddUser seeds a user in an organization, assigning it a descriptor if it
+// has none, and returns a copy of the stored user.
+func (s *Server) AddUser(owner string, user *azuredevops.GraphUser) *azuredevops.GraphUser {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	u := new(azuredevops.GraphUser)
+	clone(user, u)
+	if u.Descriptor == nil {
+		u.Descriptor = azuredevops.String(fmt.Sprintf("aad.%d", s.id()))
+	}
+	if u.SubjectKind == nil {
+		u.SubjectKind = azuredevops.String("user")
+	}
+	key := strings.ToLower(owner)
+	s.users[key] = append(s.users[key], u)
+	out := new(azuredevops.GraphUser)
+	clone(u, out)
+	return out
+}
+
+func (s *Server) userRoutes() {
+	s.handleOrg("GET", `graph/users`, s.listUsers)
+	s.handleOrg("GET", `graph/users/([^/]+)`, s.getUser)
+}
+
+func (s *Server) listUsers(c *call) {
+	users := s.users[strings.ToLower(c.owner())]
+	start, end := c.continuationPage(len(users))
+	c.list(append([]*azuredevops.GraphUser{}, users[start:end]...), end-start)
+}
+
+func (s *Server) getUser(c *call) {
+	for _, u := range s.users[strings.ToLower(c.owner())] {
+		if u.GetDescriptor() == c.params[1] {
+			c.json(http.StatusOK, u)
+			return
+		}
+	}
+	c.notFound("GraphSubjectNotFoundException", "VS403325: The subject with descriptor %s could not be found.", c.params[1])
+}
@@ -0,0 +1,187 @@
+package azuredevopstest
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/mcdafydd/go-azuredevops/azuredevops"
+)
+
+// iteration holds a team iteration and the IDs of its work items.
+type iteration struct {
+	iteration *azuredevops.Iteration
+	workItems []int
+}
+
+// AddWorkItem seeds a work item in a project, assigning it an ID if it has
+// none, and returns a copy of the stored work item.
+func (s *Server) AddWorkItem(owner, project string, item *azuredevops.WorkItem) *azuredevops.WorkItem {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	p := s.project(owner, project)
+	w := new(azuredevops.WorkItem)
+	clone(item, w)
+	if w.ID == nil {
+		w.ID = azuredevops.Int(s.id())
+	}
+	if w.Rev == nil {
+		w.Rev = azuredevops.Int(1)
+	}
+	p.workItems = append(p.workItems, w)
+	out := new(azuredevops.WorkItem)
+	clone(w, out)
+	return out
+}
+
+// AddTeam seeds a team in a project, assigning it an ID if it has none, and
+// returns a copy of the stored team.
+func (s *Server) AddTeam(owner, project string, team *azuredevops.Team) *azuredevops.Team {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	p := s.project(owner, project)
+	t := new(azuredevops.Team)
+	clone(team, t)
+	if t.ID == nil {
+		t.ID = azuredevops.String(s.guid())
+	}
+	p.teams = append(p.teams, t)
+	out := new(azuredevops.Team)
+	clone(t, out)
+	return out
+}
+
+// AddIteration seeds an iteration of a team, assigning it an ID if it has
+// none, and assigns the given work items to it. A copy of the stored
+// iteration is returned.
+func (s *Server) AddIteration(owner, project, team string, it *azuredevops.Iteration, workItemIDs ...int) *azuredevops.Iteration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	p := s.project(owner, project)
+	i := &iteration{iteration: new(azuredevops.Iteration), workItems: workItemIDs}
+	clone(it, i.iteration)
+	if i.iteration.ID == nil {
+		i.iteration.ID = azuredevops.String(s.guid())
+	}
+	key := strings.ToLower(team)
+	p.iterations[key] = append(p.iterations[key], i)
+	out := new(azuredevops.Iteration)
+	clone(i.iteration, out)
+	return out
+}
+
+func (s *Server) workRoutes() {
+	s.handle("GET", `wit/workitems`, s.listWorkItems)
+	s.handle("GET", `wit/workitems/(\d+)`, s.getWorkItem)
+	s.handle("GET", `teams`, s.listTeams)
+	s.handle("GET", `work/teamsettings/iterations`, s.listIterations)
+	s.handle("GET", `work/teamsettings/iterations/([^/]+)/workitems`, s.listIterationWorkItems)
+}
+
+func (p *project) workItem(id int) *azuredevops.WorkItem {
+	for _, w := range p.workItems {
+		if w.GetID() == id {
+			return w
+		}
+	}
+	return nil
+}
+
+// withFields returns a copy of w holding only the given fields, or w itself
+// if fields is empty.
+func withFields(w *azuredevops.WorkItem, fields string) *azuredevops.WorkItem {
+	if fields == "" || w.Fields == nil {
+		return w
+	}
+	out := *w
+	selected := make(map[string]interface{})
+	for _, f := range strings.Split(fields, ",") {
+		if v, ok := (*w.Fields)[f]; ok {
+			selected[f] = v
+		}
+	}
+	out.Fields = &selected
+	return &out
+}
+
+func (s *Server) listWorkItems(c *call) {
+	p := c.project(s)
+	q := c.r.URL.Query()
+	items := []*azuredevops.WorkItem{}
+	for _, id := range strings.Split(q.Get("ids"), ",") {
+		n, err := strconv.Atoi(id)
+		if err != nil {
+			c.error(http.StatusBadRequest, "InvalidArgumentValueException", fmt.Sprintf("The value %q of parameter ids is invalid.", id))
+			return
+		}
+		w := p.workItem(n)
+		if w == nil {
+			c.notFound("WorkItemUnauthorizedAccessException", "TF401232: Work item %d does not exist, or you do not have permissions to read it.", n)
+			return
+		}
+		items = append(items, withFields(w, q.Get("fields")))
+	}
+	c.list(items, len(items))
+}
+
+func (s *Server) getWorkItem(c *call) {
+	w := c.project(s).workItem(c.intParam(1))
+	if w == nil {
+		c.notFound("WorkItemUnauthorizedAccessException", "TF401232: Work item %s does not exist, or you do not have permissions to read it.", c.params[1])
+		return
+	}
+	c.json(http.StatusOK, withFields(w, c.r.URL.Query().Get("fields")))
+}
+
+func (s *Server) listTeams(c *call) {
+	teams := c.project(s).teams
+	start, end, ok := c.page("", len(teams))
+	if !ok {
+		return
+	}
+	c.list(append([]*azuredevops.Team{}, teams[start:end]...), end-start)
+}
+
+// iterations returns the iterations of the team named in the path, writing
+// a 404 if there is no team.
+func (c *call) iterations(s *Server) ([]*iteration, bool) {
+	if len(c.scope) < 3 {
+		c.notFound("TeamNotFoundException", "The team does not exist.")
+		return nil, false
+	}
+	return c.project(s).iterations[strings.ToLower(c.scope[2])], true
+}
+
+func (s *Server) listIterations(c *call) {
+	iterations, ok := c.iterations(s)
+	if !ok {
+		return
+	}
+	out := []*azuredevops.Iteration{}
+	for _, i := range iterations {
+		out = append(out, i.iteration)
+	}
+	c.list(out, len(out))
+}
+
+func (s *Server) listIterationWorkItems(c *call) {
+	iterations, ok := c.iterations(s)
+	if !ok {
+		return
+	}
+	for _, i := range iterations {
+		if !strings.EqualFold(i.iteration.GetID(), c.params[1]) {
+			continue
+		}
+		relations := []*azuredevops.WorkItemLink{}
+		for _, id := range i.workItems {
+			relations = append(relations, &azuredevops.WorkItemLink{
+				Target: &azuredevops.WorkItemReference{ID: azuredevops.Int(id)},
+			})
+		}
+		c.json(http.StatusOK, &azuredevops.IterationWorkItems{WorkItemRelations: relations})
+		return
+	}
+	c.notFound("CurrentIterationDoesNotExistException", "The iteration %s does not exist.", c.params[1])
+}
@@ -3,7 +3,6 @@ package azuredevops
 import (
 	"context"
 	"fmt"
-	"net/http"
 	"net/url"
 )
 
@@ -85,12 +84,13 @@ type FieldReference struct {
 
 // List returns list of the boards
 // utilising https://docs.microsoft.com/en-gb/rest/api/vsts/work/boards/list
-func (s *BoardsService) List(ctx context.Context, owner, project, team string) ([]*BoardReference, *http.Response, error) {
+func (s *BoardsService) List(ctx context.Context, owner, project, team string) ([]*BoardReference, *Response, error) {
 	URL := fmt.Sprintf(
-		"%s/%s/%s/_apis/work/boards?api-version=5.1-preview.1",
+		"%s/%s/%s/_apis/work/boards?api-version=%s",
 		owner,
 		project,
 		url.PathEscape(team),
+		s.client.apiVersion("work", "boards"),
 	)
 
 	req, err := s.client.NewRequest("GET", URL, nil)
@@ -104,13 +104,14 @@ func (s *BoardsService) List(ctx context.Context, owner, project, team string) (
 }
 
 // Get returns a single board utilising https://docs.microsoft.com/en-gb/rest/api/vsts/work/boards/get
-func (s *BoardsService) Get(ctx context.Context, owner, project, team, id string) (*Board, *http.Response, error) {
+func (s *BoardsService) Get(ctx context.Context, owner, project, team, id string) (*Board, *Response, error) {
 	URL := fmt.Sprintf(
-		"%s/%s/%s/_apis/work/boards/%s?api-version=5.1-preview.1",
+		"%s/%s/%s/_apis/work/boards/%s?api-version=%s",
 		owner,
 		project,
 		url.PathEscape(team),
 		id,
+		s.client.apiVersion("work", "boards"),
 	)
 
 	req, err := s.client.NewRequest("GET", URL, nil)
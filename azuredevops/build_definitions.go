@@ -3,7 +3,6 @@ package azuredevops
 import (
 	"context"
 	"fmt"
-	"net/http"
 )
 
 // BuildDefinitionsService handles communication with the build definitions methods on the API
@@ -42,14 +41,17 @@ type BuildDefinition struct {
 type BuildDefinitionsListOptions struct {
 	Path                 *string `url:"path,omitempty"`
 	IncludeAllProperties *bool   `url:"includeAllProperties,omitempty"`
+	Top                  *int    `url:"$top,omitempty"`
+	ContinuationToken    *string `url:"continuationToken,omitempty"`
 }
 
 // List returns a list of build definitions
 // utilising https://docs.microsoft.com/en-gb/rest/api/vsts/build/definitions/list
-func (s *BuildDefinitionsService) List(ctx context.Context, owner string, project string, opts *BuildDefinitionsListOptions) ([]*BuildDefinition, *http.Response, error) {
-	URL := fmt.Sprintf("%s/%s/_apis/build/definitions?api-version=5.1-preview.1",
+func (s *BuildDefinitionsService) List(ctx context.Context, owner string, project string, opts *BuildDefinitionsListOptions) ([]*BuildDefinition, *Response, error) {
+	URL := fmt.Sprintf("%s/%s/_apis/build/definitions?api-version=%s",
 		owner,
 		project,
+		s.client.apiVersion("build", "definitions"),
 	)
 	URL, err := addOptions(URL, opts)
 
@@ -62,3 +64,33 @@ func (s *BuildDefinitionsService) List(ctx context.Context, owner string, projec
 
 	return r.BuildDefinitions, resp, err
 }
+
+// ListPages walks every page of build definitions matching opts, calling fn
+// with each page. Pages are requested with continuation tokens.
+func (s *BuildDefinitionsService) ListPages(ctx context.Context, owner string, project string, opts *BuildDefinitionsListOptions, fn func([]*BuildDefinition, *Response) error) error {
+	o := BuildDefinitionsListOptions{}
+	if opts != nil {
+		o = *opts
+	}
+	return walkContinuation(o.GetContinuationToken(), func(token string) (*Response, error) {
+		if token != "" {
+			o.ContinuationToken = String(token)
+		}
+		definitions, resp, err := s.List(ctx, owner, project, &o)
+		if err != nil {
+			return nil, err
+		}
+		return resp, fn(definitions, resp)
+	})
+}
+
+// ListAll returns every build definition matching opts, walking all pages of
+// results.
+func (s *BuildDefinitionsService) ListAll(ctx context.Context, owner string, project string, opts *BuildDefinitionsListOptions) ([]*BuildDefinition, error) {
+	var all []*BuildDefinition
+	err := s.ListPages(ctx, owner, project, opts, func(definitions []*BuildDefinition, _ *Response) error {
+		all = append(all, definitions...)
+		return nil
+	})
+	return all, err
+}
@@ -3,7 +3,6 @@ package azuredevops
 import (
 	"context"
 	"fmt"
-	"net/http"
 )
 
 // BuildsService handles communication with the builds methods on the API
@@ -153,10 +152,11 @@ type BuildLogReference struct {
 
 // List returns list of the builds
 // utilising https://docs.microsoft.com/en-gb/rest/api/vsts/build/builds/list
-func (s *BuildsService) List(ctx context.Context, owner string, project string, opts *BuildsListOptions) ([]*Build, *http.Response, error) {
-	URL := fmt.Sprintf("%s/%s/_apis/build/builds?api-version=5.1-preview.1",
+func (s *BuildsService) List(ctx context.Context, owner string, project string, opts *BuildsListOptions) ([]*Build, *Response, error) {
+	URL := fmt.Sprintf("%s/%s/_apis/build/builds?api-version=%s",
 		owner,
 		project,
+		s.client.apiVersion("build", "builds"),
 	)
 	URL, err := addOptions(URL, opts)
 
@@ -181,10 +181,11 @@ type QueueBuildOptions struct {
 // Example body:
 // {"definition": {"id": 1}, "sourceBranch": "refs/heads/master"}
 // utilising https://docs.microsoft.com/en-us/rest/api/azure/devops/build/Builds/Queue
-func (s *BuildsService) Queue(ctx context.Context, owner string, project string, build *Build, opts *QueueBuildOptions) (*Build, *http.Response, error) {
-	URL := fmt.Sprintf("%s/%s/_apis/build/builds?api-version=5.1-preview.5",
+func (s *BuildsService) Queue(ctx context.Context, owner string, project string, build *Build, opts *QueueBuildOptions) (*Build, *Response, error) {
+	URL := fmt.Sprintf("%s/%s/_apis/build/builds?api-version=%s",
 		owner,
 		project,
+		s.client.apiVersion("build", "builds.queue"),
 	)
 	URL, err := addOptions(URL, opts)
 
@@ -201,3 +202,32 @@ func (s *BuildsService) Queue(ctx context.Context, owner string, project string,
 
 	return r, resp, err
 }
+
+// ListPages walks every page of builds matching opts, calling fn with each
+// page. Pages are requested with continuation tokens.
+func (s *BuildsService) ListPages(ctx context.Context, owner string, project string, opts *BuildsListOptions, fn func([]*Build, *Response) error) error {
+	o := BuildsListOptions{}
+	if opts != nil {
+		o = *opts
+	}
+	return walkContinuation(o.GetToken(), func(token string) (*Response, error) {
+		if token != "" {
+			o.Token = String(token)
+		}
+		builds, resp, err := s.List(ctx, owner, project, &o)
+		if err != nil {
+			return nil, err
+		}
+		return resp, fn(builds, resp)
+	})
+}
+
+// ListAll returns every build matching opts, walking all pages of results.
+func (s *BuildsService) ListAll(ctx context.Context, owner string, project string, opts *BuildsListOptions) ([]*Build, error) {
+	var all []*Build
+	err := s.ListPages(ctx, owner, project, opts, func(builds []*Build, _ *Response) error {
+		all = append(all, builds...)
+		return nil
+	})
+	return all, err
+}
@@ -55,6 +55,9 @@ func TestBuildsService_List(t *testing.T) {
 
 			mux.HandleFunc(tc.URL, func(w http.ResponseWriter, r *http.Request) {
 				testMethod(t, r, "GET")
+				if got := r.URL.Query().Get("api-version"); got != "5.1-preview.1" {
+					t.Errorf("Builds.List requested api-version %s, want 5.1-preview.1", got)
+				}
 				json := tc.response
 				fmt.Fprint(w, json)
 			})
@@ -112,6 +115,9 @@ func TestBuildsService_Queue(t *testing.T) {
 
 			testMethod(t, r, "POST")
 			testBody(t, r, queueBuildResponse+"\n")
+			if got := r.URL.Query().Get("api-version"); got != "5.1-preview.5" {
+				t.Errorf("Builds.Queue requested api-version %s, want 5.1-preview.5", got)
+			}
 
 			fmt.Fprint(w, queueBuildResponse)
 		})
@@ -137,3 +143,49 @@ func TestBuildsService_Queue(t *testing.T) {
 		}
 	})
 }
+
+func TestBuildsService_ListAll(t *testing.T) {
+	c, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc(buildListURL, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		switch token := r.URL.Query().Get("continuationToken"); token {
+		case "":
+			w.Header().Set("x-ms-continuationtoken", "page2")
+			fmt.Fprint(w, `{"count": 2, "value": [{"id": 1}, {"id": 2}]}`)
+		case "page2":
+			fmt.Fprint(w, `{"count": 1, "value": [{"id": 3}]}`)
+		default:
+			t.Errorf("unexpected continuation token %q", token)
+		}
+	})
+
+	var pages []int
+	err := c.Builds.ListPages(context.Background(), "o", "p", nil, func(builds []*azuredevops.Build, resp *azuredevops.Response) error {
+		if resp.Count != len(builds) {
+			t.Errorf("Response.Count = %d, want %d", resp.Count, len(builds))
+		}
+		pages = append(pages, len(builds))
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+	if len(pages) != 2 {
+		t.Errorf("expected 2 pages, got %d", len(pages))
+	}
+
+	builds, err := c.Builds.ListAll(context.Background(), "o", "p", nil)
+	if err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+	for i, build := range builds {
+		if build.GetID() != i+1 {
+			t.Errorf("builds[%d].ID = %d, want %d", i, build.GetID(), i+1)
+		}
+	}
+	if len(builds) != 3 {
+		t.Errorf("expected 3 builds, got %d", len(builds))
+	}
+}
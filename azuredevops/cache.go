@@ -0,0 +1,333 @@
+package azuredevops
+
+import (
+	"bytes"
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CachedResponse is a response stored by a CacheStore.
+type CachedResponse struct {
+	ETag         string      `json:"etag,omitempty"`
+	LastModified string      `json:"lastModified,omitempty"`
+	StatusCode   int         `json:"statusCode"`
+	Header       http.Header `json:"header"`
+	Body         []byte      `json:"body"`
+}
+
+// size approximates the memory used by the response.
+func (r *CachedResponse) size() int64 {
+	n := len(r.ETag) + len(r.LastModified) + len(r.Body)
+	for k, v := range r.Header {
+		n += len(k)
+		for _, s := range v {
+			n += len(s)
+		}
+	}
+	return int64(n)
+}
+
+// CacheStore stores responses for conditional requests, keyed by request URL
+// and credentials. Implementations must be safe for concurrent use.
+type CacheStore interface {
+	Get(key string) (*CachedResponse, bool)
+	Set(key string, r *CachedResponse)
+	Delete(key string)
+}
+
+// cacheKey returns the key of req, and whether its response may be cached.
+// Only GET requests decoded into a value are cached, so that responses
+// streamed to an io.Writer are never buffered. Requests which already carry
+// conditional headers are left to the caller.
+//
+// The key includes a hash of the Authorization header, so that requests made
+// with different credentials never share a response. Credentials added by
+// the http.Client's transport are not visible here; clients using different
+// identities through their transports should not share a CacheStore.
+func (c *Client) cacheKey(req *http.Request, r interface{}) (string, bool) {
+	if c.Cache == nil || req.Method != "GET" || r == nil {
+		return "", false
+	}
+	if _, ok := r.(io.Writer); ok {
+		return "", false
+	}
+	if req.Header.Get("If-None-Match") != "" || req.Header.Get("If-Modified-Since") != "" {
+		return "", false
+	}
+	key := req.URL.String()
+	if auth := req.Header.Get("Authorization"); auth != "" {
+		sum := sha256.Sum256([]byte(auth))
+		key += " " + hex.EncodeToString(sum[:])
+	}
+	return key, true
+}
+
+// setConditionalHeaders makes req conditional on the cached response for
+// key, which it returns, if there is one.
+func (c *Client) setConditionalHeaders(key string, req *http.Request) *CachedResponse {
+	cached, ok := c.Cache.Get(key)
+	if !ok {
+		return nil
+	}
+	if cached.ETag != "" {
+		req.Header.Set("If-None-Match", cached.ETag)
+	}
+	if cached.LastModified != "" {
+		req.Header.Set("If-Modified-Since", cached.LastModified)
+	}
+	return cached
+}
+
+// updateCache serves a 304 Not Modified response from cached, or stores a
+// successful response carrying validators. It returns the response to decode
+// and whether it was served from the cache.
+func (c *Client) updateCache(key string, cached *CachedResponse, resp *http.Response) (*http.Response, bool, error) {
+	switch {
+	case resp.StatusCode == http.StatusNotModified && cached != nil:
+		io.Copy(ioutil.Discard, resp.Body)
+		resp.Body.Close()
+
+		// Headers of the 304, such as rate limits, take precedence over
+		// those stored with the body.
+		header := make(http.Header, len(cached.Header))
+		for k, v := range cached.Header {
+			header[k] = append([]string(nil), v...)
+		}
+		for k, v := range resp.Header {
+			header[k] = v
+		}
+		resp.StatusCode = cached.StatusCode
+		resp.Status = http.StatusText(cached.StatusCode)
+		resp.Header = header
+		resp.ContentLength = int64(len(cached.Body))
+		resp.Body = ioutil.NopCloser(bytes.NewReader(cached.Body))
+		return resp, true, nil
+
+	case resp.StatusCode == http.StatusOK:
+		etag, lastModified := resp.Header.Get("ETag"), resp.Header.Get("Last-Modified")
+		cacheControl := resp.Header.Get("Cache-Control")
+		if etag == "" && lastModified == "" || strings.Contains(cacheControl, "no-store") || strings.Contains(cacheControl, "private") {
+			return resp, false, nil
+		}
+		body, err := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, false, err
+		}
+		c.Cache.Set(key, &CachedResponse{
+			ETag:         etag,
+			LastModified: lastModified,
+			StatusCode:   resp.StatusCode,
+			Header:       cacheableHeader(resp.Header),
+			Body:         body,
+		})
+		resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+	}
+	return resp, false, nil
+}
+
+// cacheableHeader returns a copy of h without the headers which carry
+// credentials or cookies, which are never stored.
+func cacheableHeader(h http.Header) http.Header {
+	r := make(http.Header, len(h))
+	for k, v := range h {
+		r[k] = append([]string(nil), v...)
+	}
+	for _, k := range sensitiveHeaders {
+		r.Del(k)
+	}
+	return r
+}
+
+// MemoryCache is an in-memory CacheStore which evicts the least recently used
+// responses once their total size exceeds MaxBytes.
+type MemoryCache struct {
+	// MaxBytes limits the total size of stored responses. Zero means no
+	// limit.
+	MaxBytes int64
+
+	mu    sync.Mutex
+	size  int64
+	order *list.List // of *memoryCacheEntry, most recently used first
+	items map[string]*list.Element
+}
+
+type memoryCacheEntry struct {
+	key      string
+	response *CachedResponse
+}
+
+// NewMemoryCache returns a MemoryCache holding at most maxBytes of responses.
+func NewMemoryCache(maxBytes int64) *MemoryCache {
+	return &MemoryCache{MaxBytes: maxBytes}
+}
+
+// Get returns the response stored for key.
+func (m *MemoryCache) Get(key string) (*CachedResponse, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	e, ok := m.items[key]
+	if !ok {
+		return nil, false
+	}
+	m.order.MoveToFront(e)
+	return e.Value.(*memoryCacheEntry).response, true
+}
+
+// Set stores r for key, evicting older responses as needed. A response
+// larger than MaxBytes is not stored.
+func (m *MemoryCache) Set(key string, r *CachedResponse) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.items == nil {
+		m.items = make(map[string]*list.Element)
+		m.order = list.New()
+	}
+	m.remove(key)
+	if m.MaxBytes > 0 && r.size() > m.MaxBytes {
+		return
+	}
+	m.items[key] = m.order.PushFront(&memoryCacheEntry{key, r})
+	m.size += r.size()
+	for m.MaxBytes > 0 && m.size > m.MaxBytes {
+		m.remove(m.order.Back().Value.(*memoryCacheEntry).key)
+	}
+}
+
+// Delete removes the response stored for key.
+func (m *MemoryCache) Delete(key string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.remove(key)
+}
+
+func (m *MemoryCache) remove(key string) {
+	if e, ok := m.items[key]; ok {
+		m.size -= e.Value.(*memoryCacheEntry).response.size()
+		m.order.Remove(e)
+		delete(m.items, key)
+	}
+}
+
+// FileCache is a CacheStore which stores responses as files in Dir. Once the
+// files exceed MaxBytes, the least recently used are removed.
+type FileCache struct {
+	// Dir is the directory holding the cache, which is created if needed.
+	Dir string
+	// MaxBytes limits the total size of the cache files. Zero means no
+	// limit.
+	MaxBytes int64
+
+	mu sync.Mutex
+}
+
+// NewFileCache returns a FileCache storing at most maxBytes in dir.
+func NewFileCache(dir string, maxBytes int64) *FileCache {
+	return &FileCache{Dir: dir, MaxBytes: maxBytes}
+}
+
+func (f *FileCache) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(f.Dir, hex.EncodeToString(sum[:])+".json")
+}
+
+// Get returns the response stored for key.
+func (f *FileCache) Get(key string) (*CachedResponse, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	path := f.path(key)
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, false
+	}
+	r := new(CachedResponse)
+	if err := json.Unmarshal(b, r); err != nil {
+		os.Remove(path)
+		return nil, false
+	}
+	now := time.Now()
+	os.Chtimes(path, now, now)
+	return r, true
+}
+
+// Set stores r for key, removing older files as needed. Errors writing the
+// cache are ignored, leaving the response uncached.
+func (f *FileCache) Set(key string, r *CachedResponse) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	b, err := json.Marshal(r)
+	if err != nil || f.MaxBytes > 0 && int64(len(b)) > f.MaxBytes {
+		return
+	}
+	if err := os.MkdirAll(f.Dir, 0700); err != nil {
+		return
+	}
+	path := f.path(key)
+	tmp, err := ioutil.TempFile(f.Dir, "tmp-")
+	if err != nil {
+		return
+	}
+	_, err = tmp.Write(b)
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil || os.Rename(tmp.Name(), path) != nil {
+		os.Remove(tmp.Name())
+		return
+	}
+	f.evict(path)
+}
+
+// Delete removes the response stored for key.
+func (f *FileCache) Delete(key string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	os.Remove(f.path(key))
+}
+
+// evict removes the least recently used files until the cache fits in
+// MaxBytes, keeping the file at keep.
+func (f *FileCache) evict(keep string) {
+	if f.MaxBytes <= 0 {
+		return
+	}
+	files, err := ioutil.ReadDir(f.Dir)
+	if err != nil {
+		return
+	}
+	var total int64
+	var entries []os.FileInfo
+	for _, fi := range files {
+		if fi.IsDir() || filepath.Ext(fi.Name()) != ".json" {
+			continue
+		}
+		total += fi.Size()
+		entries = append(entries, fi)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].ModTime().Before(entries[j].ModTime())
+	})
+	for _, fi := range entries {
+		if total <= f.MaxBytes {
+			break
+		}
+		path := filepath.Join(f.Dir, fi.Name())
+		if path == keep {
+			continue
+		}
+		if os.Remove(path) == nil {
+			total -= fi.Size()
+		}
+	}
+}
@@ -0,0 +1,193 @@
+package azuredevops_test
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/mcdafydd/go-azuredevops/azuredevops"
+)
+
+func TestClient_Cache(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	calls := 0
+	mux.HandleFunc("/o/p/_apis/build/builds", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("X-RateLimit-Remaining", fmt.Sprint(100-calls))
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Header().Set("X-Ms-Continuationtoken", "next")
+		fmt.Fprint(w, `{"count":1,"value":[{"id":1}]}`)
+	})
+
+	client.Cache = azuredevops.NewMemoryCache(1 << 20)
+	for i := 0; i < 2; i++ {
+		builds, resp, err := client.Builds.List(context.Background(), "o", "p", nil)
+		if err != nil {
+			t.Fatalf("Builds.List returned error: %v", err)
+		}
+		if len(builds) != 1 || *builds[0].ID != 1 {
+			t.Errorf("Builds.List call %d returned %+v", i, builds)
+		}
+		if want := i == 1; resp.FromCache != want {
+			t.Errorf("Call %d: FromCache = %v, want %v", i, resp.FromCache, want)
+		}
+		if resp.ContinuationToken != "next" {
+			t.Errorf("Call %d: ContinuationToken = %q, want next", i, resp.ContinuationToken)
+		}
+		if want := 99 - i; resp.RateLimit.Remaining != want {
+			t.Errorf("Call %d: RateLimit.Remaining = %d, want %d", i, resp.RateLimit.Remaining, want)
+		}
+	}
+	if calls != 2 {
+		t.Errorf("Server received %d calls, want 2", calls)
+	}
+}
+
+func TestClient_CacheSeparatesCredentials(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/o/p/_apis/build/builds", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") != "" {
+			t.Errorf("Request with %q was conditional on another identity's response", r.Header.Get("Authorization"))
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Header().Set("Set-Cookie", "session=secret")
+		fmt.Fprint(w, `{"count":1,"value":[{"id":1}]}`)
+	})
+
+	cache := &recordingCache{CacheStore: azuredevops.NewMemoryCache(1 << 20)}
+	client.Cache = cache
+	for _, auth := range []string{"Bearer alice", "Bearer bob"} {
+		req, _ := client.NewRequest("GET", "o/p/_apis/build/builds", nil)
+		req.Header.Set("Authorization", auth)
+		builds := new(azuredevops.BuildsListResponse)
+		resp, err := client.Execute(context.Background(), req, builds)
+		if err != nil {
+			t.Fatalf("Execute returned error: %v", err)
+		}
+		if resp.FromCache {
+			t.Errorf("Response for %q was served from the cache", auth)
+		}
+	}
+
+	if len(cache.set) != 2 || cache.set[0] == cache.set[1] {
+		t.Fatalf("Cached under keys %q, want one key per credential", cache.set)
+	}
+	for _, key := range cache.set {
+		if strings.Contains(key, "alice") || strings.Contains(key, "bob") {
+			t.Errorf("Cache key %q contains the credential", key)
+		}
+		cached, _ := cache.Get(key)
+		if got := cached.Header.Get("Set-Cookie"); got != "" {
+			t.Errorf("Cached Set-Cookie header %q", got)
+		}
+	}
+}
+
+func TestClient_CacheSkipsPrivate(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/o/p/_apis/build/builds", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") != "" {
+			t.Error("Private response was cached")
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Header().Set("Cache-Control", "private, max-age=0")
+		fmt.Fprint(w, `{"count":1,"value":[{"id":1}]}`)
+	})
+
+	client.Cache = azuredevops.NewMemoryCache(1 << 20)
+	for i := 0; i < 2; i++ {
+		if _, _, err := client.Builds.List(context.Background(), "o", "p", nil); err != nil {
+			t.Fatalf("Builds.List returned error: %v", err)
+		}
+	}
+}
+
+func TestMemoryCache_evictsLeastRecentlyUsed(t *testing.T) {
+	cache := azuredevops.NewMemoryCache(20)
+	cache.Set("a", &azuredevops.CachedResponse{Body: []byte("0123456789")})
+	cache.Set("b", &azuredevops.CachedResponse{Body: []byte("0123456789")})
+	cache.Get("a")
+	cache.Set("c", &azuredevops.CachedResponse{Body: []byte("0123456789")})
+
+	if _, ok := cache.Get("b"); ok {
+		t.Error("Expected b to be evicted")
+	}
+	for _, key := range []string{"a", "c"} {
+		if _, ok := cache.Get(key); !ok {
+			t.Errorf("Expected %s to be cached", key)
+		}
+	}
+
+	cache.Set("big", &azuredevops.CachedResponse{Body: make([]byte, 21)})
+	if _, ok := cache.Get("big"); ok {
+		t.Error("Expected response larger than MaxBytes not to be cached")
+	}
+}
+
+func TestFileCache(t *testing.T) {
+	dir, err := ioutil.TempDir("", "azuredevops-cache")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	cache := azuredevops.NewFileCache(dir, 0)
+	want := &azuredevops.CachedResponse{
+		ETag:       `"v1"`,
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": {"application/json"}},
+		Body:       []byte(`{"id":1}`),
+	}
+	cache.Set("key", want)
+
+	got, ok := azuredevops.NewFileCache(dir, 0).Get("key")
+	if !ok {
+		t.Fatal("Expected response to be read from the cache directory")
+	}
+	if got.ETag != want.ETag || string(got.Body) != string(want.Body) || got.Header.Get("Content-Type") != "application/json" {
+		t.Errorf("Get returned %+v, want %+v", got, want)
+	}
+
+	cache.Delete("key")
+	if _, ok := cache.Get("key"); ok {
+		t.Error("Expected response to be deleted")
+	}
+}
+
+func TestFileCache_maxBytes(t *testing.T) {
+	dir, err := ioutil.TempDir("", "azuredevops-cache")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	cache := azuredevops.NewFileCache(dir, 150)
+	for _, key := range []string{"a", "b", "c"} {
+		cache.Set(key, &azuredevops.CachedResponse{Body: make([]byte, 30)})
+	}
+	if _, ok := cache.Get("c"); !ok {
+		t.Error("Expected the newest response to be cached")
+	}
+	files, _ := ioutil.ReadDir(dir)
+	var total int64
+	for _, fi := range files {
+		total += fi.Size()
+	}
+	if total > 150 {
+		t.Errorf("Cache holds %d bytes, want at most 150", total)
+	}
+}
@@ -3,7 +3,6 @@ package azuredevops
 import (
 	"context"
 	"fmt"
-	"net/http"
 	"time"
 )
 
@@ -64,10 +63,11 @@ type DeliveryPlansListOptions struct {
 }
 
 // List returns a list of delivery plans
-func (s *DeliveryPlansService) List(ctx context.Context, owner string, project string, opts *DeliveryPlansListOptions) ([]*DeliveryPlan, *http.Response, error) {
-	URL := fmt.Sprintf("%s/%s/_apis/work/plans?api-version=5.1-preview.1",
+func (s *DeliveryPlansService) List(ctx context.Context, owner string, project string, opts *DeliveryPlansListOptions) ([]*DeliveryPlan, *Response, error) {
+	URL := fmt.Sprintf("%s/%s/_apis/work/plans?api-version=%s",
 		owner,
 		project,
+		s.client.apiVersion("work", "plans"),
 	)
 	URL, err := addOptions(URL, opts)
 
@@ -82,12 +82,13 @@ func (s *DeliveryPlansService) List(ctx context.Context, owner string, project s
 }
 
 // GetTimeLine will fetch the details about a specific delivery plan
-func (s *DeliveryPlansService) GetTimeLine(ctx context.Context, owner string, project string, ID string, startDate, endDate string) (*DeliveryPlanTimeLine, *http.Response, error) {
+func (s *DeliveryPlansService) GetTimeLine(ctx context.Context, owner string, project string, ID string, startDate, endDate string) (*DeliveryPlanTimeLine, *Response, error) {
 	URL := fmt.Sprintf(
-		"%s/%s/_apis/work/plans/%s/deliverytimeline?api-version=5.1-preview.1",
+		"%s/%s/_apis/work/plans/%s/deliverytimeline?api-version=%s",
 		owner,
 		project,
 		ID,
+		s.client.apiVersion("work", "deliveryTimeline"),
 	)
 
 	if startDate == "" {
@@ -0,0 +1,255 @@
+package azuredevops
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// UnifiedDiff renders the differences between two versions of a text file
+// in unified format, with the given number of lines of context. The names
+// are used in the --- and +++ header lines; use /dev/null for a version of
+// a file that does not exist. It returns an empty string if the versions
+// are equal.
+func UnifiedDiff(oldName, newName string, oldText, newText []byte, context int) string {
+	a, b := splitLines(oldText), splitLines(newText)
+	ops := diffLines(a, b)
+
+	var buf strings.Builder
+	for i := 0; i < len(ops); {
+		// Find the next change, and extend the hunk until a run of more
+		// than twice the context of equal lines.
+		for i < len(ops) && ops[i].kind == ' ' {
+			i++
+		}
+		if i == len(ops) {
+			break
+		}
+		start := i - context
+		if start < 0 {
+			start = 0
+		}
+		end := i
+		for j := i; j < len(ops); j++ {
+			if ops[j].kind != ' ' {
+				end = j + 1
+			} else if j-end >= 2*context {
+				break
+			}
+		}
+		i = end
+		end += context
+		if end > len(ops) {
+			end = len(ops)
+		}
+
+		if buf.Len() == 0 {
+			fmt.Fprintf(&buf, "--- %s\n+++ %s\n", oldName, newName)
+		}
+		writeHunk(&buf, a, b, ops[start:end])
+	}
+	return buf.String()
+}
+
+// writeHunk writes a hunk header and the lines of ops.
+func writeHunk(buf *strings.Builder, a, b []string, ops []diffOp) {
+	var oldLen, newLen int
+	for _, op := range ops {
+		if op.kind != '+' {
+			oldLen++
+		}
+		if op.kind != '-' {
+			newLen++
+		}
+	}
+	fmt.Fprintf(buf, "@@ -%s +%s @@\n", hunkRange(ops[0].a, oldLen), hunkRange(ops[0].b, newLen))
+	for _, op := range ops {
+		var line string
+		if op.kind == '+' {
+			line = b[op.b]
+		} else {
+			line = a[op.a]
+		}
+		buf.WriteByte(op.kind)
+		buf.WriteString(line)
+		if !strings.HasSuffix(line, "\n") {
+			buf.WriteString("\n\\ No newline at end of file\n")
+		}
+	}
+}
+
+// hunkRange formats the range of lines of a hunk header. An empty range
+// starts at the line before it, as in GNU diff.
+func hunkRange(start, n int) string {
+	if n == 0 {
+		return fmt.Sprintf("%d,0", start)
+	}
+	if n == 1 {
+		return fmt.Sprintf("%d", start+1)
+	}
+	return fmt.Sprintf("%d,%d", start+1, n)
+}
+
+// splitLines splits text into lines, keeping the newline of each line so
+// that a missing newline at the end of the text is a difference.
+func splitLines(text []byte) []string {
+	var lines []string
+	for len(text) > 0 {
+		i := bytes.IndexByte(text, '\n') + 1
+		if i == 0 {
+			i = len(text)
+		}
+		lines = append(lines, string(text[:i]))
+		text = text[i:]
+	}
+	return lines
+}
+
+// diffOp is an equal (' '), deleted ('-') or inserted ('+') line, at
+// index a of the old lines and index b of the new lines.
+type diffOp struct {
+	kind byte
+	a, b int
+}
+
+// diffLines returns the shortest edit script from a to b, using the
+// linear space variant of the algorithm of Myers' "An O(ND) Difference
+// Algorithm and Its Variations": each step finds the middle snake of an
+// optimal path, then recurses on the parts before and after it.
+func diffLines(a, b []string) []diffOp {
+	d := &differ{a: a, b: b}
+	n := len(a) + len(b) + 1
+	d.vf, d.vb = make([]int, 2*n+1), make([]int, 2*n+1)
+	d.compare(0, len(a), 0, len(b))
+	return deletionsFirst(d.ops)
+}
+
+// deletionsFirst reorders each run of changed lines in ops so that its
+// deletions precede its insertions, as diff and git print them.
+func deletionsFirst(ops []diffOp) []diffOp {
+	for i := 0; i < len(ops); {
+		if ops[i].kind == ' ' {
+			i++
+			continue
+		}
+		j := i
+		var dels, ins []int
+		for ; j < len(ops) && ops[j].kind != ' '; j++ {
+			if ops[j].kind == '-' {
+				dels = append(dels, ops[j].a)
+			} else {
+				ins = append(ins, ops[j].b)
+			}
+		}
+		x, y := ops[i].a, ops[i].b
+		k := i
+		for _, a := range dels {
+			ops[k] = diffOp{'-', a, y}
+			k++
+		}
+		for _, b := range ins {
+			ops[k] = diffOp{'+', x + len(dels), b}
+			k++
+		}
+		i = j
+	}
+	return ops
+}
+
+// differ holds the state of diffLines: the lines compared, the forward and
+// backward furthest reaching paths indexed by diagonal, and the edit script.
+type differ struct {
+	a, b   []string
+	vf, vb []int
+	ops    []diffOp
+}
+
+// compare appends the edit script from a[aLo:aHi] to b[bLo:bHi].
+func (d *differ) compare(aLo, aHi, bLo, bHi int) {
+	for aLo < aHi && bLo < bHi && d.a[aLo] == d.b[bLo] {
+		d.ops = append(d.ops, diffOp{' ', aLo, bLo})
+		aLo++
+		bLo++
+	}
+	suffix := 0
+	for aLo < aHi && bLo < bHi && d.a[aHi-1] == d.b[bHi-1] {
+		aHi--
+		bHi--
+		suffix++
+	}
+
+	switch {
+	case aLo == aHi:
+		for y := bLo; y < bHi; y++ {
+			d.ops = append(d.ops, diffOp{'+', aLo, y})
+		}
+	case bLo == bHi:
+		for x := aLo; x < aHi; x++ {
+			d.ops = append(d.ops, diffOp{'-', x, bLo})
+		}
+	default:
+		x, y, u, v := d.middleSnake(aLo, aHi, bLo, bHi)
+		d.compare(aLo, x, bLo, y)
+		for ; x < u; x, y = x+1, y+1 {
+			d.ops = append(d.ops, diffOp{' ', x, y})
+		}
+		d.compare(u, aHi, v, bHi)
+	}
+
+	for i := 0; i < suffix; i++ {
+		d.ops = append(d.ops, diffOp{' ', aHi + i, bHi + i})
+	}
+}
+
+// middleSnake returns the start (x, y) and end (u, v) of the middle snake
+// of a shortest edit path from a[aLo:aHi] to b[bLo:bHi], which must differ
+// in their first and last lines. Both points are strictly inside the
+// ranges, so the parts before and after the snake are smaller problems.
+func (d *differ) middleSnake(aLo, aHi, bLo, bHi int) (x, y, u, v int) {
+	n, m := aHi-aLo, bHi-bLo
+	delta := n - m
+	odd := delta&1 != 0
+	// Diagonal k is stored at index off+k; the backward path on diagonal k
+	// counts lines from the ends of the ranges.
+	off := n + m + 1
+	d.vf[off+1], d.vb[off+1] = 0, 0
+	for e := 0; e <= (n+m+1)/2; e++ {
+		for k := -e; k <= e; k += 2 {
+			var px int
+			if k == -e || k != e && d.vf[off+k-1] < d.vf[off+k+1] {
+				px = d.vf[off+k+1]
+			} else {
+				px = d.vf[off+k-1] + 1
+			}
+			py := px - k
+			sx, sy := px, py
+			for sx < n && sy < m && d.a[aLo+sx] == d.b[bLo+sy] {
+				sx++
+				sy++
+			}
+			d.vf[off+k] = sx
+			if kb := delta - k; odd && kb >= -(e-1) && kb <= e-1 && sx+d.vb[off+kb] >= n {
+				return aLo + px, bLo + py, aLo + sx, bLo + sy
+			}
+		}
+		for k := -e; k <= e; k += 2 {
+			var px int
+			if k == -e || k != e && d.vb[off+k-1] < d.vb[off+k+1] {
+				px = d.vb[off+k+1]
+			} else {
+				px = d.vb[off+k-1] + 1
+			}
+			py := px - k
+			sx, sy := px, py
+			for sx < n && sy < m && d.a[aHi-1-sx] == d.b[bHi-1-sy] {
+				sx++
+				sy++
+			}
+			d.vb[off+k] = sx
+			if kf := delta - k; !odd && kf >= -e && kf <= e && sx+d.vf[off+kf] >= n {
+				return aHi - sx, bHi - sy, aHi - px, bHi - py
+			}
+		}
+	}
+	panic("azuredevops: no middle snake found")
+}
@@ -0,0 +1,87 @@
+package azuredevops_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/mcdafydd/go-azuredevops/azuredevops"
+)
+
+func TestUnifiedDiff(t *testing.T) {
+	tests := []struct {
+		name     string
+		old, new string
+		want     string
+	}{
+		{
+			name: "equal",
+			old:  "a\nb\n",
+			new:  "a\nb\n",
+			want: "",
+		},
+		{
+			name: "edit",
+			old:  "1\n2\n3\n4\n5\n6\n7\n8\n9\n",
+			new:  "1\n2\n3\n4\nfive\n6\n7\n8\n9\n",
+			want: "--- a/f\n+++ b/f\n@@ -2,7 +2,7 @@\n 2\n 3\n 4\n-5\n+five\n 6\n 7\n 8\n",
+		},
+		{
+			name: "separate hunks",
+			old:  "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n",
+			new:  "one\n2\n3\n4\n5\n6\n7\n8\n9\nten\n",
+			want: "--- a/f\n+++ b/f\n@@ -1,4 +1,4 @@\n-1\n+one\n 2\n 3\n 4\n@@ -7,4 +7,4 @@\n 7\n 8\n 9\n-10\n+ten\n",
+		},
+		{
+			name: "add",
+			old:  "",
+			new:  "a\nb\n",
+			want: "--- a/f\n+++ b/f\n@@ -0,0 +1,2 @@\n+a\n+b\n",
+		},
+		{
+			name: "delete",
+			old:  "a\n",
+			new:  "",
+			want: "--- a/f\n+++ b/f\n@@ -1 +0,0 @@\n-a\n",
+		},
+		{
+			name: "deletions first",
+			old:  "x\n",
+			new:  "a\nb\nc\n",
+			want: "--- a/f\n+++ b/f\n@@ -1 +1,3 @@\n-x\n+a\n+b\n+c\n",
+		},
+		{
+			name: "no newline at end of file",
+			old:  "a\nb",
+			new:  "a\nb\n",
+			want: "--- a/f\n+++ b/f\n@@ -1,2 +1,2 @@\n a\n-b\n\\ No newline at end of file\n+b\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := azuredevops.UnifiedDiff("a/f", "b/f", []byte(tt.old), []byte(tt.new), 3)
+			if got != tt.want {
+				t.Errorf("UnifiedDiff returned\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUnifiedDiff_largeRewrite(t *testing.T) {
+	// Every line differs, so the edit script has 2n edits. Keeping every
+	// round of the search, as the basic algorithm does, would need O(n^2)
+	// memory here.
+	const n = 5000
+	var old, new strings.Builder
+	for i := 0; i < n; i++ {
+		fmt.Fprintf(&old, "old %d\n", i)
+		fmt.Fprintf(&new, "new %d\n", i)
+	}
+	got := azuredevops.UnifiedDiff("a/f", "b/f", []byte(old.String()), []byte(new.String()), 3)
+	if want := fmt.Sprintf("@@ -1,%d +1,%d @@\n", n, n); !strings.Contains(got, want) {
+		t.Errorf("UnifiedDiff hunk header missing %q", want)
+	}
+	if c := strings.Count(got, "\n-old "); c != n {
+		t.Errorf("UnifiedDiff deleted %d lines, want %d", c, n)
+	}
+}
@@ -10,14 +10,21 @@ package azuredevops
 
 // ItemContent describes an item
 type ItemContent struct {
-	Content     *string          `json:"content,omitempty"`
-	ContentType *ItemContentType `json:"contentType,omitempty"`
+	Content     *string `json:"content,omitempty"`
+	ContentType *string `json:"contentType,omitempty"`
 }
 
-// ItemContentType describes an item content type
-type ItemContentType struct {
-	Base64Encoded *string `json:"base64Encoded,omitempty"`
-	RawText       *string `json:"rawText,omitempty"`
+// ItemContentType describes how the content of an item is encoded
+type ItemContentType int
+
+// ItemContentType enum values
+const (
+	RawText ItemContentType = iota
+	Base64Encoded
+)
+
+func (d ItemContentType) String() string {
+	return [...]string{"rawText", "base64Encoded"}[d]
 }
 
 // Link A single item in a collection of Links.
@@ -3,7 +3,6 @@ package azuredevops
 import (
 	"context"
 	"fmt"
-	"net/http"
 )
 
 // FavouritesService handles communication with the favourites methods on the API
@@ -27,7 +26,7 @@ type Favourite struct {
 }
 
 // List returns a list of the favourite items from for the user
-func (s *FavouritesService) List(ctx context.Context, owner, project string) ([]*Favourite, *http.Response, error) {
+func (s *FavouritesService) List(ctx context.Context, owner, project string) ([]*Favourite, *Response, error) {
 	URL := fmt.Sprintf(
 		"%s/%s/_apis/Favorite/Favorites?artifactType=%s",
 		owner,
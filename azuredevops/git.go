@@ -3,8 +3,8 @@ package azuredevops
 import (
 	"context"
 	"fmt"
-	"net/http"
 	"net/url"
+	"strings"
 )
 
 // VersionControlChangeType enum declaration
@@ -75,19 +75,87 @@ type GitRefsResponse struct {
 	GitRefs []*GitRef `json:"value"`
 }
 
-// GitRefsUpdateResponse describes the git list refs update response
+// GitRefsUpdateResponse describes the git refs update response
 type GitRefsUpdateResponse struct {
-	Count         int             `json:"count"`
-	GitRefsUpdate []*GitRefUpdate `json:"value"`
+	Count   int                   `json:"count"`
+	Results []*GitRefUpdateResult `json:"value"`
 }
 
-// UpdateRefsBody Request body for UpdateRefs()
-type UpdateRefsBody struct {
-	IsLocked     *bool   `json:"isLocked,omitempty"`
-	Name         *string `json:"name,omitempty"`
-	NewObjectID  *string `json:"newObjectId,omitempty"`
-	OldObjectID  *string `json:"oldObjectId,omitempty"`
-	RepositoryID *string `json:"repositoryId,omitempty"`
+// ZeroObjectID is the object ID of a ref which does not exist. Use it as the
+// OldObjectID of a GitRefUpdate to create a ref, or as the NewObjectID to
+// delete one.
+const ZeroObjectID = "0000000000000000000000000000000000000000"
+
+// GitRefUpdateStatus enum declaration
+type GitRefUpdateStatus int
+
+// GitRefUpdateStatus enum values
+const (
+	RefUpdateSucceeded GitRefUpdateStatus = iota
+	RefUpdateForcePushRequired
+	RefUpdateStaleOldObjectID
+	RefUpdateInvalidRefName
+	RefUpdateUnprocessed
+	RefUpdateUnresolvableToCommit
+	RefUpdateWritePermissionRequired
+	RefUpdateManageNotePermissionRequired
+	RefUpdateCreateBranchPermissionRequired
+	RefUpdateCreateTagPermissionRequired
+	RefUpdateRejectedByPlugin
+	RefUpdateLocked
+	RefUpdateRefNameConflict
+	RefUpdateRejectedByPolicy
+	RefUpdateSucceededNonExistentRef
+	RefUpdateSucceededCorruptRef
+)
+
+func (d GitRefUpdateStatus) String() string {
+	return [...]string{"succeeded", "forcePushRequired", "staleOldObjectId", "invalidRefName", "unprocessed", "unresolvableToCommit", "writePermissionRequired", "manageNotePermissionRequired", "createBranchPermissionRequired", "createTagPermissionRequired", "rejectedByPlugin", "locked", "refNameConflict", "rejectedByPolicy", "succeededNonExistentRef", "succeededCorruptRef"}[d]
+}
+
+// GitRefUpdateResult describes the result of updating a single ref
+type GitRefUpdateResult struct {
+	CustomMessage *string `json:"customMessage,omitempty"`
+	IsLocked      *bool   `json:"isLocked,omitempty"`
+	Name          *string `json:"name,omitempty"`
+	NewObjectID   *string `json:"newObjectId,omitempty"`
+	OldObjectID   *string `json:"oldObjectId,omitempty"`
+	RejectedBy    *string `json:"rejectedBy,omitempty"`
+	RepositoryID  *string `json:"repositoryId,omitempty"`
+	Success       *bool   `json:"success,omitempty"`
+	UpdateStatus  *string `json:"updateStatus,omitempty"`
+}
+
+// RefUpdateError is returned by ForceUpdateRef when the service rejects the
+// update of a ref. It matches ErrConflict if the ref has moved or conflicts
+// with another ref, ErrUnauthorized if the caller lacks a permission, and
+// ErrPolicyViolation if a policy rejected the update.
+type RefUpdateError struct {
+	Result *GitRefUpdateResult
+}
+
+func (e *RefUpdateError) Error() string {
+	msg := fmt.Sprintf("update of ref %s failed with status %s", e.Result.GetName(), e.Result.GetUpdateStatus())
+	if m := e.Result.GetCustomMessage(); m != "" {
+		msg += ": " + m
+	}
+	return msg
+}
+
+// Is reports whether the update failed for one of the reasons described by
+// target, for errors.Is.
+func (e *RefUpdateError) Is(target error) bool {
+	switch e.Result.GetUpdateStatus() {
+	case RefUpdateStaleOldObjectID.String(), RefUpdateRefNameConflict.String(), RefUpdateLocked.String():
+		return target == ErrConflict
+	case RefUpdateForcePushRequired.String(), RefUpdateWritePermissionRequired.String(),
+		RefUpdateManageNotePermissionRequired.String(), RefUpdateCreateBranchPermissionRequired.String(),
+		RefUpdateCreateTagPermissionRequired.String():
+		return target == ErrUnauthorized
+	case RefUpdateRejectedByPolicy.String(), RefUpdateRejectedByPlugin.String():
+		return target == ErrPolicyViolation
+	}
+	return false
 }
 
 // GitStatusesResponse describes the git statuses response
@@ -125,7 +193,7 @@ type GitCommitRef struct {
 	CommentTruncated *bool            `json:"commentTruncated,omitempty"`
 	URL              *string          `json:"url,omitempty"`
 	ChangeCounts     *map[string]int  `json:"changeCounts,omitempty"`
-	Changes          *GitChange       `json:"changes,omitempty"`
+	Changes          []*GitChange     `json:"changes,omitempty"`
 	Parents          []*string        `json:"parents,omitempty"`
 	Push             *GitPushRef      `json:"push,omitempty"`
 	RemoteURL        *string          `json:"remoteUrl,omitempty"`
@@ -203,7 +271,11 @@ type GitPullRequest struct {
 
 // GitPullRequestChange Change made in a pull request.
 type GitPullRequestChange struct {
-	ChangeTrackingID *int `json:"changeTrackingId,omitempty"`
+	ChangeID         *int     `json:"changeId,omitempty"`
+	ChangeTrackingID *int     `json:"changeTrackingId,omitempty"`
+	ChangeType       *string  `json:"changeType,omitempty"`
+	Item             *GitItem `json:"item,omitempty"`
+	OriginalPath     *string  `json:"originalPath,omitempty"`
 }
 
 // GitPullRequestIteration Provides properties that describe a Git pull request iteration. Iterations are created as a result of creating and pushing updates to a pull request.
@@ -374,6 +446,8 @@ type GitRefListOptions struct {
 	Filter             string `url:"filter,omitempty"`
 	IncludeStatuses    bool   `url:"includeStatuses,omitempty"`
 	LatestStatusesOnly bool   `url:"latestStatusesOnly,omitempty"`
+	Top                int    `url:"$top,omitempty"`
+	ContinuationToken  string `url:"continuationToken,omitempty"`
 }
 
 // GitStatusContext Status context that uniquely identifies the status.
@@ -412,44 +486,124 @@ func (d IterationReason) String() string {
 	return [...]string{"push", "forcePush", "create", "rebase", "unknown", "retarget"}[d]
 }
 
-// UpdateRefs returns a list of the references for a git repo
-func (s *GitService) UpdateRefs(ctx context.Context, owner, project, repo, refType string, opts *GitRefListOptions) ([]*GitRef, *http.Response, error) {
+// UpdateRefs creates, updates or deletes refs in a git repo. Each update
+// moves the ref Name from OldObjectID to NewObjectID, and is rejected with
+// status staleOldObjectId if the ref has moved since OldObjectID was read.
+// Use ZeroObjectID as OldObjectID to create a ref, or as NewObjectID to
+// delete one. Updates which are not fast-forwards fail with status
+// forcePushRequired unless the caller has the force push permission.
+// https://docs.microsoft.com/en-us/rest/api/azure/devops/git/refs/update%20refs?view=azure-devops-rest-5.1
+func (s *GitService) UpdateRefs(ctx context.Context, owner, project, repo string, updates []*GitRefUpdate) ([]*GitRefUpdateResult, *Response, error) {
 	URL := fmt.Sprintf(
-		"%s/%s/_apis/git/repositories/%s/refs/%s?api-version=5.1-preview.1",
+		"%s/%s/_apis/git/repositories/%s/refs?api-version=%s",
 		owner,
 		project,
 		repo,
-		refType,
+		s.client.apiVersion("git", "refs"),
 	)
 
-	URL, err := addOptions(URL, opts)
+	req, err := s.client.NewRequest("POST", URL, updates)
+	if err != nil {
+		return nil, nil, err
+	}
+	r := new(GitRefsUpdateResponse)
+	resp, err := s.client.Execute(ctx, req, r)
+
+	return r.Results, resp, err
+}
 
-	body := &UpdateRefsBody{
-		IsLocked:     Bool(false),
-		Name:         String("refs/heads/vsts-api-sample/answer-woman-flame"),
-		NewObjectID:  String(""),
-		OldObjectID:  String(""),
-		RepositoryID: String(repo),
+// ForceUpdateRef moves the ref name to newObjectID, whether or not it is a
+// fast-forward, by updating from the ref's current object ID. The caller
+// needs the force push permission to rewrite history. If the service rejects
+// the update, its result is returned with a *RefUpdateError.
+func (s *GitService) ForceUpdateRef(ctx context.Context, owner, project, repo, name, newObjectID string) (*GitRefUpdateResult, *Response, error) {
+	return s.forceUpdateRef(ctx, owner, project, repo, name, newObjectID, false)
+}
+
+// forceUpdateRef implements ForceUpdateRef. If mustExist is set, an error
+// matching ErrNotFound is returned when the ref does not exist.
+func (s *GitService) forceUpdateRef(ctx context.Context, owner, project, repo, name, newObjectID string, mustExist bool) (*GitRefUpdateResult, *Response, error) {
+	if err := formatRef(&name); err != nil {
+		return nil, nil, err
+	}
+	refs, resp, err := s.ListRefs(ctx, owner, project, repo, "", &GitRefListOptions{
+		Filter: strings.TrimPrefix(name, "refs/"),
+	})
+	if err != nil {
+		return nil, resp, err
+	}
+	oldObjectID := ZeroObjectID
+	for _, ref := range refs {
+		if ref.GetName() == name {
+			oldObjectID = ref.GetObjectID()
+		}
+	}
+	if mustExist && oldObjectID == ZeroObjectID {
+		return nil, resp, fmt.Errorf("ref %s does not exist: %w", name, ErrNotFound)
 	}
 
-	req, err := s.client.NewRequest("POST", URL, body)
+	results, resp, err := s.UpdateRefs(ctx, owner, project, repo, []*GitRefUpdate{{
+		Name:        String(name),
+		OldObjectID: String(oldObjectID),
+		NewObjectID: String(newObjectID),
+	}})
 	if err != nil {
+		return nil, resp, err
+	}
+	if len(results) == 0 {
+		return nil, resp, fmt.Errorf("Git.ForceUpdateRef: no result for the update of ref %s", name)
+	}
+	if !results[0].GetSuccess() {
+		return results[0], resp, &RefUpdateError{Result: results[0]}
+	}
+	return results[0], resp, nil
+}
+
+// LockBranch locks a branch, preventing others from updating it.
+// https://docs.microsoft.com/en-us/rest/api/azure/devops/git/refs/update%20ref?view=azure-devops-rest-5.1
+func (s *GitService) LockBranch(ctx context.Context, owner, project, repo, branch string) (*GitRef, *Response, error) {
+	return s.setBranchLock(ctx, owner, project, repo, branch, true)
+}
+
+// UnlockBranch unlocks a branch locked with LockBranch.
+// https://docs.microsoft.com/en-us/rest/api/azure/devops/git/refs/update%20ref?view=azure-devops-rest-5.1
+func (s *GitService) UnlockBranch(ctx context.Context, owner, project, repo, branch string) (*GitRef, *Response, error) {
+	return s.setBranchLock(ctx, owner, project, repo, branch, false)
+}
+
+func (s *GitService) setBranchLock(ctx context.Context, owner, project, repo, branch string, locked bool) (*GitRef, *Response, error) {
+	if err := formatRef(&branch); err != nil {
 		return nil, nil, err
 	}
-	r := new(GitRefsResponse)
+	URL := fmt.Sprintf(
+		"%s/%s/_apis/git/repositories/%s/refs?filter=%s&api-version=%s",
+		owner,
+		project,
+		repo,
+		url.QueryEscape(strings.TrimPrefix(branch, "refs/")),
+		s.client.apiVersion("git", "refs"),
+	)
+
+	body := &GitRefUpdate{IsLocked: Bool(locked)}
+	req, err := s.client.NewRequest("PATCH", URL, body)
+	if err != nil {
+		return nil, nil, err
+	}
+	r := new(GitRef)
 	resp, err := s.client.Execute(ctx, req, r)
 
-	return r.GitRefs, resp, err
+	return r, resp, err
 }
 
 // ListRefs returns a list of the references for a git repo
-func (s *GitService) ListRefs(ctx context.Context, owner, project, repo, refType string, opts *GitRefListOptions) ([]*GitRef, *http.Response, error) {
+func (s *GitService) ListRefs(ctx context.Context, owner, project, repo, refType string, opts *GitRefListOptions) ([]*GitRef, *Response, error) {
 	URL := fmt.Sprintf(
-		"%s/%s/_apis/git/repositories/%s/refs/%s?api-version=5.1-preview.1",
+		"%s/%s/_apis/git/repositories/%s/refs/%s?api-version=%s",
 		owner,
 		project,
 		repo,
 		refType,
+		s.client.apiVersion("git", "refs"),
 	)
 
 	URL, err := addOptions(URL, opts)
@@ -464,14 +618,43 @@ func (s *GitService) ListRefs(ctx context.Context, owner, project, repo, refType
 	return r.GitRefs, resp, err
 }
 
+// ListRefsPages walks every page of references matching opts, calling fn with
+// each page. Pages are requested with continuation tokens.
+func (s *GitService) ListRefsPages(ctx context.Context, owner, project, repo, refType string, opts *GitRefListOptions, fn func([]*GitRef, *Response) error) error {
+	o := GitRefListOptions{}
+	if opts != nil {
+		o = *opts
+	}
+	return walkContinuation(o.ContinuationToken, func(token string) (*Response, error) {
+		o.ContinuationToken = token
+		refs, resp, err := s.ListRefs(ctx, owner, project, repo, refType, &o)
+		if err != nil {
+			return nil, err
+		}
+		return resp, fn(refs, resp)
+	})
+}
+
+// ListAllRefs returns every reference matching opts, walking all pages of
+// results.
+func (s *GitService) ListAllRefs(ctx context.Context, owner, project, repo, refType string, opts *GitRefListOptions) ([]*GitRef, error) {
+	var all []*GitRef
+	err := s.ListRefsPages(ctx, owner, project, repo, refType, opts, func(refs []*GitRef, _ *Response) error {
+		all = append(all, refs...)
+		return nil
+	})
+	return all, err
+}
+
 // GetRepository Return a single GitRepository
 // https://docs.microsoft.com/en-us/rest/api/azure/devops/git/repositories/get%20repository?view=azure-devops-rest-5.1
-func (s *GitService) GetRepository(ctx context.Context, owner, project, repoName string) (*GitRepository, *http.Response, error) {
+func (s *GitService) GetRepository(ctx context.Context, owner, project, repoName string) (*GitRepository, *Response, error) {
 	URL := fmt.Sprintf(
-		"%s/%s/_apis/git/repositories/%s?api-version=5.1-preview.1",
+		"%s/%s/_apis/git/repositories/%s?api-version=%s",
 		owner,
 		project,
 		repoName,
+		s.client.apiVersion("git", "repositories"),
 	)
 
 	req, err := s.client.NewRequest("GET", URL, nil)
@@ -487,13 +670,14 @@ func (s *GitService) GetRepository(ctx context.Context, owner, project, repoName
 
 // GetChanges Return a single GitRepository
 // https://docs.microsoft.com/en-us/rest/api/azure/devops/git/commits/get%20changes?view=azure-devops-rest-5.1
-func (s *GitService) GetChanges(ctx context.Context, owner, project, repoName, commitID string) (*GitCommitChanges, *http.Response, error) {
+func (s *GitService) GetChanges(ctx context.Context, owner, project, repoName, commitID string) (*GitCommitChanges, *Response, error) {
 	URL := fmt.Sprintf(
-		"%s/%s/_apis/git/repositories/%s/commits/%s/changes?api-version=5.1-preview.1",
+		"%s/%s/_apis/git/repositories/%s/commits/%s/changes?api-version=%s",
 		owner,
 		project,
 		repoName,
 		commitID,
+		s.client.apiVersion("git", "changes"),
 	)
 
 	req, err := s.client.NewRequest("GET", URL, nil)
@@ -509,13 +693,14 @@ func (s *GitService) GetChanges(ctx context.Context, owner, project, repoName, c
 // CreateStatus creates a new status for a repository at the specified
 // reference. Ref can be a SHA, a branch name, or a tag name.
 // https://docs.microsoft.com/en-us/rest/api/azure/devops/git/statuses/create?view=azure-devops-rest-5.0
-func (s *GitService) CreateStatus(ctx context.Context, owner, project, repoName, ref string, status GitStatus) (*GitStatus, *http.Response, error) {
+func (s *GitService) CreateStatus(ctx context.Context, owner, project, repoName, ref string, status GitStatus) (*GitStatus, *Response, error) {
 	URL := fmt.Sprintf(
-		"%s/%s/_apis/git/repositories/%s/commits/%s/statuses?api-version=5.1-preview.1",
+		"%s/%s/_apis/git/repositories/%s/commits/%s/statuses?api-version=%s",
 		owner,
 		project,
 		repoName,
 		url.QueryEscape(ref),
+		s.client.apiVersion("git", "statuses"),
 	)
 
 	req, err := s.client.NewRequest("POST", URL, status)
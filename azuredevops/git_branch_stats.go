@@ -0,0 +1,189 @@
+package azuredevops
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// GitBranchStatsListResponse describes the git branch stats list response
+type GitBranchStatsListResponse struct {
+	Count       int               `json:"count"`
+	BranchStats []*GitBranchStats `json:"value"`
+}
+
+// GitBranchStats describes how far a branch is ahead of and behind a base
+// version
+type GitBranchStats struct {
+	AheadCount    *int          `json:"aheadCount,omitempty"`
+	BehindCount   *int          `json:"behindCount,omitempty"`
+	Commit        *GitCommitRef `json:"commit,omitempty"`
+	IsBaseVersion *bool         `json:"isBaseVersion,omitempty"`
+	Name          *string       `json:"name,omitempty"`
+}
+
+// GitBranchStatsOptions describes the parameters of the git branch stats
+// APIs
+type GitBranchStatsOptions struct {
+	// BaseVersion is the version branches are compared with. Defaults to
+	// the default branch.
+	BaseVersion *GitVersionDescriptor `url:"baseVersionDescriptor,omitempty"`
+}
+
+// gitBranchStatsGetOptions adds the branch name to GitBranchStatsOptions
+type gitBranchStatsGetOptions struct {
+	Name        string                `url:"name"`
+	BaseVersion *GitVersionDescriptor `url:"baseVersionDescriptor,omitempty"`
+}
+
+// GetBranchStats returns the statistics of a single branch, given by name
+// without the refs/heads/ prefix
+// https://docs.microsoft.com/en-us/rest/api/azure/devops/git/stats/get?view=azure-devops-rest-5.1
+func (s *GitService) GetBranchStats(ctx context.Context, owner, project, repo, name string, opts *GitBranchStatsOptions) (*GitBranchStats, *Response, error) {
+	URL := fmt.Sprintf(
+		"%s/%s/_apis/git/repositories/%s/stats/branches?api-version=%s",
+		owner,
+		project,
+		repo,
+		s.client.apiVersion("git", "stats"),
+	)
+	o := &gitBranchStatsGetOptions{Name: strings.TrimPrefix(name, "refs/heads/")}
+	if opts != nil {
+		o.BaseVersion = opts.BaseVersion
+	}
+	URL, err := addOptions(URL, o)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := s.client.NewRequest("GET", URL, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	r := new(GitBranchStats)
+	resp, err := s.client.Execute(ctx, req, r)
+
+	return r, resp, err
+}
+
+// ListBranchStats returns the statistics of every branch of a repository
+// https://docs.microsoft.com/en-us/rest/api/azure/devops/git/stats/list?view=azure-devops-rest-5.1
+func (s *GitService) ListBranchStats(ctx context.Context, owner, project, repo string, opts *GitBranchStatsOptions) ([]*GitBranchStats, *Response, error) {
+	URL := fmt.Sprintf(
+		"%s/%s/_apis/git/repositories/%s/stats/branches?api-version=%s",
+		owner,
+		project,
+		repo,
+		s.client.apiVersion("git", "stats"),
+	)
+	URL, err := addOptions(URL, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := s.client.NewRequest("GET", URL, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	r := new(GitBranchStatsListResponse)
+	resp, err := s.client.Execute(ctx, req, r)
+
+	return r.BranchStats, resp, err
+}
+
+// StaleBranchOptions describes which branches StaleBranches reports
+type StaleBranchOptions struct {
+	// BaseVersion is the version branches are compared with. Defaults to
+	// the default branch of each repository.
+	BaseVersion *GitVersionDescriptor
+	// OlderThan is the minimum age of the last commit of a stale branch.
+	OlderThan time.Duration
+	// Authors, if not empty, limits the report to branches whose last
+	// commit has one of these author names or emails.
+	Authors []string
+}
+
+// StaleBranch describes a branch reported by StaleBranches
+type StaleBranch struct {
+	Repository     string
+	Name           string
+	Creator        *IdentityRef
+	IsLocked       bool
+	LastCommit     *GitCommitRef
+	LastCommitDate time.Time
+	AheadCount     int
+	BehindCount    int
+}
+
+// StaleBranches reports the branches of the given repositories whose last
+// commit is older than opts.OlderThan, oldest first. The base branch of
+// each repository is never reported.
+func (s *GitService) StaleBranches(ctx context.Context, owner, project string, repos []string, opts *StaleBranchOptions) ([]*StaleBranch, error) {
+	o := StaleBranchOptions{}
+	if opts != nil {
+		o = *opts
+	}
+	cutoff := time.Now().Add(-o.OlderThan)
+
+	var stale []*StaleBranch
+	for _, repo := range repos {
+		refs, err := s.ListAllRefs(ctx, owner, project, repo, "heads", nil)
+		if err != nil {
+			return nil, err
+		}
+		stats, _, err := s.ListBranchStats(ctx, owner, project, repo, &GitBranchStatsOptions{BaseVersion: o.BaseVersion})
+		if err != nil {
+			return nil, err
+		}
+		byName := make(map[string]*GitBranchStats, len(stats))
+		for _, st := range stats {
+			byName["refs/heads/"+st.GetName()] = st
+		}
+
+		for _, ref := range refs {
+			st, ok := byName[ref.GetName()]
+			if !ok || st.GetIsBaseVersion() {
+				continue
+			}
+			commit := st.GetCommit()
+			date := commit.GetCommitter().GetDate()
+			if date == nil {
+				date = commit.GetAuthor().GetDate()
+			}
+			if date == nil || !date.Time.Before(cutoff) || !matchesAuthor(commit.GetAuthor(), o.Authors) {
+				continue
+			}
+			stale = append(stale, &StaleBranch{
+				Repository:     repo,
+				Name:           ref.GetName(),
+				Creator:        ref.GetCreator(),
+				IsLocked:       ref.GetIsLocked(),
+				LastCommit:     commit,
+				LastCommitDate: date.Time,
+				AheadCount:     st.GetAheadCount(),
+				BehindCount:    st.GetBehindCount(),
+			})
+		}
+	}
+
+	sort.SliceStable(stale, func(i, j int) bool {
+		return stale[i].LastCommitDate.Before(stale[j].LastCommitDate)
+	})
+	return stale, nil
+}
+
+// matchesAuthor reports whether the name or email of author is one of
+// authors, ignoring case. Every author matches an empty list.
+func matchesAuthor(author *GitUserDate, authors []string) bool {
+	if len(authors) == 0 {
+		return true
+	}
+	for _, a := range authors {
+		if strings.EqualFold(a, author.GetName()) || strings.EqualFold(a, author.GetEmail()) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,100 @@
+package azuredevops_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/mcdafydd/go-azuredevops/azuredevops"
+)
+
+func TestGitService_GetBranchStats(t *testing.T) {
+	c, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/o/p/_apis/git/repositories/r/stats/branches", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{
+			"name":                              "topic",
+			"baseVersionDescriptor.version":     "release",
+			"baseVersionDescriptor.versionType": "branch",
+		})
+		fmt.Fprint(w, `{"name": "topic", "aheadCount": 3, "behindCount": 1, "commit": {"commitId": "abc"}}`)
+	})
+
+	opts := &azuredevops.GitBranchStatsOptions{BaseVersion: azuredevops.BranchVersion("release")}
+	got, _, err := c.Git.GetBranchStats(context.Background(), "o", "p", "r", "refs/heads/topic", opts)
+	if err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+	if got.GetAheadCount() != 3 || got.GetBehindCount() != 1 || got.GetCommit().GetCommitID() != "abc" {
+		t.Errorf("Git.GetBranchStats returned %+v", got)
+	}
+}
+
+func TestGitService_ListBranchStats(t *testing.T) {
+	c, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/o/p/_apis/git/repositories/r/stats/branches", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{})
+		fmt.Fprint(w, `{"count": 2, "value": [
+			{"name": "master", "isBaseVersion": true},
+			{"name": "topic", "aheadCount": 3}
+		]}`)
+	})
+
+	got, _, err := c.Git.ListBranchStats(context.Background(), "o", "p", "r", nil)
+	if err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+	if len(got) != 2 || !got[0].GetIsBaseVersion() || got[1].GetAheadCount() != 3 {
+		t.Errorf("Git.ListBranchStats returned %+v", got)
+	}
+}
+
+func TestGitService_StaleBranches(t *testing.T) {
+	c, mux, _, teardown := setup()
+	defer teardown()
+
+	recent := time.Now().Add(-24 * time.Hour).UTC().Format(time.RFC3339)
+	mux.HandleFunc("/o/p/_apis/git/repositories/r/refs/heads", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"count": 5, "value": [
+			{"name": "refs/heads/master", "objectId": "1"},
+			{"name": "refs/heads/old", "objectId": "2", "isLocked": true, "creator": {"displayName": "Jane"}},
+			{"name": "refs/heads/older", "objectId": "3"},
+			{"name": "refs/heads/new", "objectId": "4"},
+			{"name": "refs/heads/other", "objectId": "5"}
+		]}`)
+	})
+	mux.HandleFunc("/o/p/_apis/git/repositories/r/stats/branches", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"count": 5, "value": [
+			{"name": "master", "isBaseVersion": true, "commit": {"committer": {"date": "2019-01-01T00:00:00Z"}}},
+			{"name": "old", "aheadCount": 1, "behindCount": 9, "commit": {"author": {"name": "Jane"}, "committer": {"date": "2019-06-01T00:00:00Z"}}},
+			{"name": "older", "commit": {"author": {"email": "jane@example.com", "date": "2019-03-01T00:00:00Z"}}},
+			{"name": "new", "commit": {"author": {"name": "Jane"}, "committer": {"date": %q}}},
+			{"name": "other", "commit": {"author": {"name": "Joe"}, "committer": {"date": "2019-01-01T00:00:00Z"}}}
+		]}`, recent)
+	})
+
+	opts := &azuredevops.StaleBranchOptions{
+		OlderThan: 90 * 24 * time.Hour,
+		Authors:   []string{"jane", "JANE@example.com"},
+	}
+	got, err := c.Git.StaleBranches(context.Background(), "o", "p", []string{"r"}, opts)
+	if err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("Git.StaleBranches returned %d branches, want 2", len(got))
+	}
+	if got[0].Name != "refs/heads/older" || got[1].Name != "refs/heads/old" {
+		t.Errorf("Git.StaleBranches returned %s, %s, want older, old", got[0].Name, got[1].Name)
+	}
+	if old := got[1]; old.Repository != "r" || !old.IsLocked || old.Creator.GetDisplayName() != "Jane" || old.AheadCount != 1 || old.BehindCount != 9 {
+		t.Errorf("Git.StaleBranches returned %+v", old)
+	}
+}
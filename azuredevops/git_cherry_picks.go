@@ -0,0 +1,234 @@
+package azuredevops
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// GitCherryPick describes a cherry-pick operation
+type GitCherryPick struct {
+	Links          *map[string]Link                `json:"_links,omitempty"`
+	CherryPickID   *int                            `json:"cherryPickId,omitempty"`
+	DetailedStatus *GitAsyncRefOperationDetail     `json:"detailedStatus,omitempty"`
+	Parameters     *GitAsyncRefOperationParameters `json:"parameters,omitempty"`
+	// Status is a GitAsyncOperationStatus
+	Status *string `json:"status,omitempty"`
+	URL    *string `json:"url,omitempty"`
+}
+
+// GitRevert describes a revert operation
+type GitRevert struct {
+	Links          *map[string]Link                `json:"_links,omitempty"`
+	DetailedStatus *GitAsyncRefOperationDetail     `json:"detailedStatus,omitempty"`
+	Parameters     *GitAsyncRefOperationParameters `json:"parameters,omitempty"`
+	RevertID       *int                            `json:"revertId,omitempty"`
+	// Status is a GitAsyncOperationStatus
+	Status *string `json:"status,omitempty"`
+	URL    *string `json:"url,omitempty"`
+}
+
+// GitAsyncRefOperationParameters describes a cherry-pick or revert: the
+// commits of Source are applied onto the branch OntoRefName, and the result
+// is written to the new branch GeneratedRefName.
+type GitAsyncRefOperationParameters struct {
+	GeneratedRefName *string                     `json:"generatedRefName,omitempty"`
+	OntoRefName      *string                     `json:"ontoRefName,omitempty"`
+	Repository       *GitRepository              `json:"repository,omitempty"`
+	Source           *GitAsyncRefOperationSource `json:"source,omitempty"`
+}
+
+// GitAsyncRefOperationSource describes the commits of a cherry-pick or
+// revert: either a list of commits or the commits of a pull request
+type GitAsyncRefOperationSource struct {
+	CommitList    []*GitCommitRef `json:"commitList,omitempty"`
+	PullRequestID *int            `json:"pullRequestId,omitempty"`
+}
+
+// GitAsyncRefOperationDetail describes the progress of a cherry-pick or
+// revert
+type GitAsyncRefOperationDetail struct {
+	Conflict        *bool    `json:"conflict,omitempty"`
+	CurrentCommitID *string  `json:"currentCommitId,omitempty"`
+	FailureMessage  *string  `json:"failureMessage,omitempty"`
+	Progress        *float64 `json:"progress,omitempty"`
+	Status          *string  `json:"status,omitempty"`
+	Timedout        *bool    `json:"timedout,omitempty"`
+}
+
+// CommitsSource returns the source of a cherry-pick or revert of commits,
+// which are applied in order.
+func CommitsSource(commitIDs ...string) *GitAsyncRefOperationSource {
+	source := &GitAsyncRefOperationSource{}
+	for _, id := range commitIDs {
+		source.CommitList = append(source.CommitList, &GitCommitRef{CommitID: String(id)})
+	}
+	return source
+}
+
+// PullRequestSource returns the source of a cherry-pick or revert of the
+// commits of a pull request.
+func PullRequestSource(pullRequestID int) *GitAsyncRefOperationSource {
+	return &GitAsyncRefOperationSource{PullRequestID: Int(pullRequestID)}
+}
+
+// RefOperationError is returned by WaitForCherryPick and WaitForRevert when
+// the operation fails or is abandoned. It matches ErrConflict if the
+// commits did not apply cleanly.
+type RefOperationError struct {
+	Operation string // cherry-pick or revert
+	ID        int
+	Status    string
+	Detail    *GitAsyncRefOperationDetail
+}
+
+func (e *RefOperationError) Error() string {
+	msg := fmt.Sprintf("%s %d %s", e.Operation, e.ID, e.Status)
+	if e.Detail.GetConflict() {
+		msg += " with conflicts"
+		if id := e.Detail.GetCurrentCommitID(); id != "" {
+			msg += " at commit " + id
+		}
+	}
+	if m := e.Detail.GetFailureMessage(); m != "" {
+		msg += ": " + m
+	}
+	return msg
+}
+
+// Is reports whether the operation failed with conflicts, for
+// errors.Is(err, ErrConflict).
+func (e *RefOperationError) Is(target error) bool {
+	return target == ErrConflict && e.Detail.GetConflict()
+}
+
+// CreateCherryPick starts cherry-picking commits onto a branch
+// https://docs.microsoft.com/en-us/rest/api/azure/devops/git/cherry%20picks/create?view=azure-devops-rest-5.1
+func (s *GitService) CreateCherryPick(ctx context.Context, owner, project, repo string, params *GitAsyncRefOperationParameters) (*GitCherryPick, *Response, error) {
+	URL := fmt.Sprintf(
+		"%s/%s/_apis/git/repositories/%s/cherryPicks?api-version=%s",
+		owner,
+		project,
+		repo,
+		s.client.apiVersion("git", "cherryPicks"),
+	)
+
+	req, err := s.client.NewRequest("POST", URL, params)
+	if err != nil {
+		return nil, nil, err
+	}
+	r := new(GitCherryPick)
+	resp, err := s.client.Execute(ctx, req, r)
+
+	return r, resp, err
+}
+
+// GetCherryPick returns a cherry-pick operation, including its status
+// https://docs.microsoft.com/en-us/rest/api/azure/devops/git/cherry%20picks/get%20cherry%20pick?view=azure-devops-rest-5.1
+func (s *GitService) GetCherryPick(ctx context.Context, owner, project, repo string, cherryPickID int) (*GitCherryPick, *Response, error) {
+	URL := fmt.Sprintf(
+		"%s/%s/_apis/git/repositories/%s/cherryPicks/%d?api-version=%s",
+		owner,
+		project,
+		repo,
+		cherryPickID,
+		s.client.apiVersion("git", "cherryPicks"),
+	)
+
+	req, err := s.client.NewRequest("GET", URL, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	r := new(GitCherryPick)
+	resp, err := s.client.Execute(ctx, req, r)
+
+	return r, resp, err
+}
+
+// WaitForCherryPick polls a cherry-pick every interval until it completes.
+// It returns a *RefOperationError if the cherry-pick fails or is abandoned,
+// and ctx.Err() if ctx is done first. A zero interval uses
+// DefaultPollInterval.
+func (s *GitService) WaitForCherryPick(ctx context.Context, owner, project, repo string, cherryPickID int, interval time.Duration) (*GitCherryPick, error) {
+	var r *GitCherryPick
+	err := poll(ctx, interval, func() (bool, error) {
+		var err error
+		r, _, err = s.GetCherryPick(ctx, owner, project, repo, cherryPickID)
+		if err != nil {
+			return false, err
+		}
+		return refOperationDone("cherry-pick", cherryPickID, r.GetStatus(), r.GetDetailedStatus())
+	})
+	return r, err
+}
+
+// CreateRevert starts reverting commits on a branch
+// https://docs.microsoft.com/en-us/rest/api/azure/devops/git/reverts/create?view=azure-devops-rest-5.1
+func (s *GitService) CreateRevert(ctx context.Context, owner, project, repo string, params *GitAsyncRefOperationParameters) (*GitRevert, *Response, error) {
+	URL := fmt.Sprintf(
+		"%s/%s/_apis/git/repositories/%s/reverts?api-version=%s",
+		owner,
+		project,
+		repo,
+		s.client.apiVersion("git", "reverts"),
+	)
+
+	req, err := s.client.NewRequest("POST", URL, params)
+	if err != nil {
+		return nil, nil, err
+	}
+	r := new(GitRevert)
+	resp, err := s.client.Execute(ctx, req, r)
+
+	return r, resp, err
+}
+
+// GetRevert returns a revert operation, including its status
+// https://docs.microsoft.com/en-us/rest/api/azure/devops/git/reverts/get%20revert?view=azure-devops-rest-5.1
+func (s *GitService) GetRevert(ctx context.Context, owner, project, repo string, revertID int) (*GitRevert, *Response, error) {
+	URL := fmt.Sprintf(
+		"%s/%s/_apis/git/repositories/%s/reverts/%d?api-version=%s",
+		owner,
+		project,
+		repo,
+		revertID,
+		s.client.apiVersion("git", "reverts"),
+	)
+
+	req, err := s.client.NewRequest("GET", URL, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	r := new(GitRevert)
+	resp, err := s.client.Execute(ctx, req, r)
+
+	return r, resp, err
+}
+
+// WaitForRevert polls a revert every interval until it completes. It
+// returns a *RefOperationError if the revert fails or is abandoned, and
+// ctx.Err() if ctx is done first. A zero interval uses DefaultPollInterval.
+func (s *GitService) WaitForRevert(ctx context.Context, owner, project, repo string, revertID int, interval time.Duration) (*GitRevert, error) {
+	var r *GitRevert
+	err := poll(ctx, interval, func() (bool, error) {
+		var err error
+		r, _, err = s.GetRevert(ctx, owner, project, repo, revertID)
+		if err != nil {
+			return false, err
+		}
+		return refOperationDone("revert", revertID, r.GetStatus(), r.GetDetailedStatus())
+	})
+	return r, err
+}
+
+// refOperationDone reports whether a cherry-pick or revert has completed,
+// or returns a *RefOperationError if it has failed.
+func refOperationDone(operation string, id int, status string, detail *GitAsyncRefOperationDetail) (bool, error) {
+	switch status {
+	case AsyncOperationCompleted.String():
+		return true, nil
+	case AsyncOperationFailed.String(), AsyncOperationAbandoned.String():
+		return false, &RefOperationError{Operation: operation, ID: id, Status: status, Detail: detail}
+	}
+	return false, nil
+}
@@ -0,0 +1,117 @@
+package azuredevops_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/mcdafydd/go-azuredevops/azuredevops"
+)
+
+func TestGitService_CreateCherryPick(t *testing.T) {
+	c, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/o/p/_apis/git/repositories/r/cherryPicks", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testBody(t, r, `{"generatedRefName":"refs/heads/hotfix-42","ontoRefName":"refs/heads/release","source":{"pullRequestId":42}}`+"\n")
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"cherryPickId": 3, "status": "queued"}`)
+	})
+
+	params := &azuredevops.GitAsyncRefOperationParameters{
+		GeneratedRefName: azuredevops.String("refs/heads/hotfix-42"),
+		OntoRefName:      azuredevops.String("refs/heads/release"),
+		Source:           azuredevops.PullRequestSource(42),
+	}
+	got, _, err := c.Git.CreateCherryPick(context.Background(), "o", "p", "r", params)
+	if err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+	if got.GetCherryPickID() != 3 || got.GetStatus() != "queued" {
+		t.Errorf("Git.CreateCherryPick returned %+v", got)
+	}
+}
+
+func TestGitService_CreateRevert(t *testing.T) {
+	c, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/o/p/_apis/git/repositories/r/reverts", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testBody(t, r, `{"generatedRefName":"refs/heads/revert-abc","ontoRefName":"refs/heads/master","source":{"commitList":[{"commitId":"abc"},{"commitId":"def"}]}}`+"\n")
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"revertId": 4, "status": "queued"}`)
+	})
+
+	params := &azuredevops.GitAsyncRefOperationParameters{
+		GeneratedRefName: azuredevops.String("refs/heads/revert-abc"),
+		OntoRefName:      azuredevops.String("refs/heads/master"),
+		Source:           azuredevops.CommitsSource("abc", "def"),
+	}
+	got, _, err := c.Git.CreateRevert(context.Background(), "o", "p", "r", params)
+	if err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+	if got.GetRevertID() != 4 {
+		t.Errorf("Git.CreateRevert returned %+v", got)
+	}
+}
+
+func TestGitService_WaitForCherryPick(t *testing.T) {
+	c, mux, _, teardown := setup()
+	defer teardown()
+
+	statuses := []string{
+		`{"cherryPickId": 3, "status": "inProgress", "detailedStatus": {"progress": 0.5}}`,
+		`{"cherryPickId": 3, "status": "completed", "detailedStatus": {"progress": 1}}`,
+	}
+	polls := 0
+	mux.HandleFunc("/o/p/_apis/git/repositories/r/cherryPicks/3", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, statuses[polls])
+		polls++
+	})
+
+	got, err := c.Git.WaitForCherryPick(context.Background(), "o", "p", "r", 3, time.Millisecond)
+	if err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+	if got.GetStatus() != "completed" || polls != 2 {
+		t.Errorf("Git.WaitForCherryPick returned %+v after %d polls", got, polls)
+	}
+}
+
+func TestGitService_WaitForRevert_conflict(t *testing.T) {
+	c, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/o/p/_apis/git/repositories/r/reverts/4", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"revertId": 4, "status": "failed", "detailedStatus": {
+			"conflict": true,
+			"currentCommitId": "def",
+			"failureMessage": "The revert could not be completed due to conflicts.",
+			"status": "conflicts"
+		}}`)
+	})
+
+	_, err := c.Git.WaitForRevert(context.Background(), "o", "p", "r", 4, time.Millisecond)
+	var opErr *azuredevops.RefOperationError
+	if !errors.As(err, &opErr) {
+		t.Fatalf("returned error %v, want *RefOperationError", err)
+	}
+	if !errors.Is(err, azuredevops.ErrConflict) {
+		t.Errorf("errors.Is(%v, ErrConflict) = false, want true", err)
+	}
+	if opErr.Detail.GetCurrentCommitID() != "def" {
+		t.Errorf("RefOperationError.Detail = %+v", opErr.Detail)
+	}
+	want := "revert 4 failed with conflicts at commit def: The revert could not be completed due to conflicts."
+	if err.Error() != want {
+		t.Errorf("Error() = %q, want %q", err.Error(), want)
+	}
+}
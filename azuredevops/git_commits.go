@@ -0,0 +1,199 @@
+package azuredevops
+
+import (
+	"context"
+	"fmt"
+)
+
+// GitCommitsListResponse describes the git commits list response
+type GitCommitsListResponse struct {
+	Count   int             `json:"count"`
+	Commits []*GitCommitRef `json:"value"`
+}
+
+// GitHistoryMode enum declaration. It is encoded by name, as the API
+// expects.
+type GitHistoryMode int
+
+// GitHistoryMode enum values
+const (
+	HistorySimplifiedHistory GitHistoryMode = iota
+	HistoryFirstParent
+	HistoryFullHistory
+	HistoryFullHistorySimplifyMerges
+)
+
+var gitHistoryModes = [...]string{"simplifiedHistory", "firstParent", "fullHistory", "fullHistorySimplifyMerges"}
+
+func (d GitHistoryMode) String() string {
+	if d < 0 || int(d) >= len(gitHistoryModes) {
+		return fmt.Sprintf("GitHistoryMode(%d)", int(d))
+	}
+	return gitHistoryModes[d]
+}
+
+// MarshalText encodes the history mode by name.
+func (d GitHistoryMode) MarshalText() ([]byte, error) {
+	if d < 0 || int(d) >= len(gitHistoryModes) {
+		return nil, fmt.Errorf("invalid GitHistoryMode %d", int(d))
+	}
+	return []byte(d.String()), nil
+}
+
+// GitQueryCommitsCriteria describes the search criteria of the git commits
+// list and batch APIs. ListCommits sends it as searchCriteria query
+// parameters and GetCommitsBatch as the request body.
+//
+// Commits between two commits are selected with FromCommitID and
+// ToCommitID, and on a branch, tag or commit with ItemVersion. Dates use the
+// format of the API, for example 2019-10-01T00:00:00Z.
+type GitQueryCommitsCriteria struct {
+	Author                 string                `json:"author,omitempty" url:"searchCriteria.author,omitempty"`
+	CompareVersion         *GitVersionDescriptor `json:"compareVersion,omitempty" url:"searchCriteria.compareVersion,omitempty"`
+	ExcludeDeletes         bool                  `json:"excludeDeletes,omitempty" url:"searchCriteria.excludeDeletes,omitempty"`
+	FromCommitID           string                `json:"fromCommitId,omitempty" url:"searchCriteria.fromCommitId,omitempty"`
+	FromDate               string                `json:"fromDate,omitempty" url:"searchCriteria.fromDate,omitempty"`
+	HistoryMode            GitHistoryMode        `json:"historyMode,omitempty" url:"searchCriteria.historyMode,omitempty"`
+	IDs                    []string              `json:"ids,omitempty" url:"-"`
+	IncludeLinks           bool                  `json:"includeLinks,omitempty" url:"searchCriteria.includeLinks,omitempty"`
+	IncludePushData        bool                  `json:"includePushData,omitempty" url:"searchCriteria.includePushData,omitempty"`
+	IncludeUserImageURL    bool                  `json:"includeUserImageUrl,omitempty" url:"searchCriteria.includeUserImageUrl,omitempty"`
+	IncludeWorkItems       bool                  `json:"includeWorkItems,omitempty" url:"searchCriteria.includeWorkItems,omitempty"`
+	ItemPath               string                `json:"itemPath,omitempty" url:"searchCriteria.itemPath,omitempty"`
+	ItemVersion            *GitVersionDescriptor `json:"itemVersion,omitempty" url:"searchCriteria.itemVersion,omitempty"`
+	ShowOldestCommitsFirst bool                  `json:"showOldestCommitsFirst,omitempty" url:"searchCriteria.showOldestCommitsFirst,omitempty"`
+	ToCommitID             string                `json:"toCommitId,omitempty" url:"searchCriteria.toCommitId,omitempty"`
+	ToDate                 string                `json:"toDate,omitempty" url:"searchCriteria.toDate,omitempty"`
+	User                   string                `json:"user,omitempty" url:"searchCriteria.user,omitempty"`
+	Skip                   *int                  `json:"$skip,omitempty" url:"searchCriteria.$skip,omitempty"`
+	Top                    *int                  `json:"$top,omitempty" url:"searchCriteria.$top,omitempty"`
+}
+
+// GitCommitGetOptions describes the parameters of the git commit get API
+type GitCommitGetOptions struct {
+	// ChangeCount is the number of changes to include in the result.
+	ChangeCount *int `url:"changeCount,omitempty"`
+}
+
+// ListCommits returns the commits of a repository matching the search
+// criteria
+// https://docs.microsoft.com/en-us/rest/api/azure/devops/git/commits/get%20commits?view=azure-devops-rest-5.1
+func (s *GitService) ListCommits(ctx context.Context, owner, project, repo string, opts *GitQueryCommitsCriteria) ([]*GitCommitRef, *Response, error) {
+	URL := fmt.Sprintf(
+		"%s/%s/_apis/git/repositories/%s/commits?api-version=%s",
+		owner,
+		project,
+		repo,
+		s.client.apiVersion("git", "commits"),
+	)
+	URL, err := addOptions(URL, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := s.client.NewRequest("GET", URL, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	r := new(GitCommitsListResponse)
+	resp, err := s.client.Execute(ctx, req, r)
+
+	return r.Commits, resp, err
+}
+
+// ListCommitsPages walks every page of commits matching opts, calling fn
+// with each page. Pages are requested with searchCriteria.$skip and
+// searchCriteria.$top.
+func (s *GitService) ListCommitsPages(ctx context.Context, owner, project, repo string, opts *GitQueryCommitsCriteria, fn func([]*GitCommitRef, *Response) error) error {
+	o := GitQueryCommitsCriteria{}
+	if opts != nil {
+		o = *opts
+	}
+	return walkSkip(o.GetSkip(), o.GetTop(), func(skip, top int) (int, error) {
+		o.Skip, o.Top = Int(skip), Int(top)
+		commits, resp, err := s.ListCommits(ctx, owner, project, repo, &o)
+		if err != nil {
+			return 0, err
+		}
+		return len(commits), fn(commits, resp)
+	})
+}
+
+// ListAllCommits returns every commit matching opts, walking all pages of
+// results.
+func (s *GitService) ListAllCommits(ctx context.Context, owner, project, repo string, opts *GitQueryCommitsCriteria) ([]*GitCommitRef, error) {
+	var all []*GitCommitRef
+	err := s.ListCommitsPages(ctx, owner, project, repo, opts, func(commits []*GitCommitRef, _ *Response) error {
+		all = append(all, commits...)
+		return nil
+	})
+	return all, err
+}
+
+// GetCommit returns a single commit, including its parents and the number
+// of changes of each type
+// https://docs.microsoft.com/en-us/rest/api/azure/devops/git/commits/get?view=azure-devops-rest-5.1
+func (s *GitService) GetCommit(ctx context.Context, owner, project, repo, commitID string, opts *GitCommitGetOptions) (*GitCommitRef, *Response, error) {
+	URL := fmt.Sprintf(
+		"%s/%s/_apis/git/repositories/%s/commits/%s?api-version=%s",
+		owner,
+		project,
+		repo,
+		commitID,
+		s.client.apiVersion("git", "commits"),
+	)
+	URL, err := addOptions(URL, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := s.client.NewRequest("GET", URL, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	r := new(GitCommitRef)
+	resp, err := s.client.Execute(ctx, req, r)
+
+	return r, resp, err
+}
+
+// GetCommitsBatch returns the commits matching the search criteria, which
+// may include a list of commit IDs. Criteria too long for the query string
+// of ListCommits can be sent this way.
+// https://docs.microsoft.com/en-us/rest/api/azure/devops/git/commits/get%20commits%20batch?view=azure-devops-rest-5.1
+func (s *GitService) GetCommitsBatch(ctx context.Context, owner, project, repo string, criteria *GitQueryCommitsCriteria) ([]*GitCommitRef, *Response, error) {
+	URL := fmt.Sprintf(
+		"%s/%s/_apis/git/repositories/%s/commitsbatch?api-version=%s",
+		owner,
+		project,
+		repo,
+		s.client.apiVersion("git", "commits"),
+	)
+
+	req, err := s.client.NewRequest("POST", URL, criteria)
+	if err != nil {
+		return nil, nil, err
+	}
+	r := new(GitCommitsListResponse)
+	resp, err := s.client.Execute(ctx, req, r)
+
+	return r.Commits, resp, err
+}
+
+// GetCommitsBatchPages walks every page of commits matching criteria,
+// calling fn with each page. Pages are requested with $skip and $top in the
+// request body.
+func (s *GitService) GetCommitsBatchPages(ctx context.Context, owner, project, repo string, criteria *GitQueryCommitsCriteria, fn func([]*GitCommitRef, *Response) error) error {
+	c := GitQueryCommitsCriteria{}
+	if criteria != nil {
+		c = *criteria
+	}
+	return walkSkip(c.GetSkip(), c.GetTop(), func(skip, top int) (int, error) {
+		c.Skip, c.Top = Int(skip), Int(top)
+		commits, resp, err := s.GetCommitsBatch(ctx, owner, project, repo, &c)
+		if err != nil {
+			return 0, err
+		}
+		return len(commits), fn(commits, resp)
+	})
+}
@@ -0,0 +1,147 @@
+package azuredevops_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/mcdafydd/go-azuredevops/azuredevops"
+)
+
+func TestGitService_ListCommits(t *testing.T) {
+	c, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/o/p/_apis/git/repositories/r/commits", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{
+			"searchCriteria.author":                  "Jane",
+			"searchCriteria.itemPath":                "/src",
+			"searchCriteria.fromDate":                "2019-10-01T00:00:00Z",
+			"searchCriteria.fromCommitId":            "aaa",
+			"searchCriteria.toCommitId":              "bbb",
+			"searchCriteria.itemVersion.version":     "master",
+			"searchCriteria.itemVersion.versionType": "branch",
+			"searchCriteria.$top":                    "10",
+			"searchCriteria.historyMode":             "firstParent",
+		})
+		fmt.Fprint(w, `{"count": 1, "value": [{"commitId": "bbb", "comment": "Fix", "author": {"name": "Jane"}}]}`)
+	})
+
+	opts := &azuredevops.GitQueryCommitsCriteria{
+		Author:       "Jane",
+		ItemPath:     "/src",
+		FromDate:     "2019-10-01T00:00:00Z",
+		FromCommitID: "aaa",
+		ToCommitID:   "bbb",
+		ItemVersion:  azuredevops.BranchVersion("master"),
+		Top:          azuredevops.Int(10),
+		HistoryMode:  azuredevops.HistoryFirstParent,
+	}
+	got, _, err := c.Git.ListCommits(context.Background(), "o", "p", "r", opts)
+	if err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+	want := []*azuredevops.GitCommitRef{{
+		CommitID: azuredevops.String("bbb"),
+		Comment:  azuredevops.String("Fix"),
+		Author:   &azuredevops.GitUserDate{Name: azuredevops.String("Jane")},
+	}}
+	if !cmp.Equal(got, want) {
+		t.Errorf("Git.ListCommits returned %+v, want %+v", got, want)
+	}
+}
+
+func TestGitService_ListAllCommits(t *testing.T) {
+	c, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/o/p/_apis/git/repositories/r/commits", func(w http.ResponseWriter, r *http.Request) {
+		skip, _ := strconv.Atoi(r.FormValue("searchCriteria.$skip"))
+		if top := r.FormValue("searchCriteria.$top"); top != "2" {
+			t.Errorf("searchCriteria.$top = %q, want 2", top)
+		}
+		switch skip {
+		case 0:
+			fmt.Fprint(w, `{"count": 2, "value": [{"commitId": "c"}, {"commitId": "b"}]}`)
+		case 2:
+			fmt.Fprint(w, `{"count": 1, "value": [{"commitId": "a"}]}`)
+		default:
+			t.Errorf("Unexpected searchCriteria.$skip %d", skip)
+		}
+	})
+
+	got, err := c.Git.ListAllCommits(context.Background(), "o", "p", "r", &azuredevops.GitQueryCommitsCriteria{Top: azuredevops.Int(2)})
+	if err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+	var ids []string
+	for _, commit := range got {
+		ids = append(ids, commit.GetCommitID())
+	}
+	if want := []string{"c", "b", "a"}; !cmp.Equal(ids, want) {
+		t.Errorf("Git.ListAllCommits returned %v, want %v", ids, want)
+	}
+}
+
+func TestGitService_GetCommit(t *testing.T) {
+	c, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/o/p/_apis/git/repositories/r/commits/bbb", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{"changeCount": "5"})
+		fmt.Fprint(w, `{"commitId": "bbb", "parents": ["aaa"], "changeCounts": {"Add": 1, "Edit": 2}}`)
+	})
+
+	opts := &azuredevops.GitCommitGetOptions{ChangeCount: azuredevops.Int(5)}
+	got, _, err := c.Git.GetCommit(context.Background(), "o", "p", "r", "bbb", opts)
+	if err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+	want := &azuredevops.GitCommitRef{
+		CommitID:     azuredevops.String("bbb"),
+		Parents:      []*string{azuredevops.String("aaa")},
+		ChangeCounts: &map[string]int{"Add": 1, "Edit": 2},
+	}
+	if !cmp.Equal(got, want) {
+		t.Errorf("Git.GetCommit returned %+v, want %+v", got, want)
+	}
+}
+
+func TestGitService_GetCommitsBatch(t *testing.T) {
+	c, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/o/p/_apis/git/repositories/r/commitsbatch", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		var got map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&got)
+		want := map[string]interface{}{
+			"ids":         []interface{}{"aaa", "bbb"},
+			"itemVersion": map[string]interface{}{"version": "v1.0", "versionType": "tag"},
+			"historyMode": "fullHistory",
+		}
+		if !cmp.Equal(got, want) {
+			t.Errorf("Request body differs: %s", cmp.Diff(got, want))
+		}
+		fmt.Fprint(w, `{"count": 2, "value": [{"commitId": "aaa"}, {"commitId": "bbb"}]}`)
+	})
+
+	criteria := &azuredevops.GitQueryCommitsCriteria{
+		IDs:         []string{"aaa", "bbb"},
+		ItemVersion: azuredevops.TagVersion("v1.0"),
+		HistoryMode: azuredevops.HistoryFullHistory,
+	}
+	got, _, err := c.Git.GetCommitsBatch(context.Background(), "o", "p", "r", criteria)
+	if err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+	if len(got) != 2 || got[1].GetCommitID() != "bbb" {
+		t.Errorf("Git.GetCommitsBatch returned %+v", got)
+	}
+}
@@ -0,0 +1,242 @@
+package azuredevops
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// GitCommitDiffs describes the changes between two versions of a repository
+type GitCommitDiffs struct {
+	AheadCount         *int            `json:"aheadCount,omitempty"`
+	AllChangesIncluded *bool           `json:"allChangesIncluded,omitempty"`
+	BaseCommit         *string         `json:"baseCommit,omitempty"`
+	BehindCount        *int            `json:"behindCount,omitempty"`
+	ChangeCounts       *map[string]int `json:"changeCounts,omitempty"`
+	Changes            []*GitChange    `json:"changes,omitempty"`
+	CommonCommit       *string         `json:"commonCommit,omitempty"`
+	TargetCommit       *string         `json:"targetCommit,omitempty"`
+}
+
+// GitCommitDiffsOptions describes the parameters of the git diffs API
+type GitCommitDiffsOptions struct {
+	// DiffCommonCommit compares the target with the common commit of the
+	// base and target, rather than with the base. Defaults to true.
+	DiffCommonCommit *bool `url:"diffCommonCommit,omitempty"`
+	Skip             *int  `url:"$skip,omitempty"`
+	Top              *int  `url:"$top,omitempty"`
+}
+
+// setVersionParams adds a version descriptor to query parameters in the
+// form used by the diffs API: prefix, prefixType and prefixOptions.
+func setVersionParams(v url.Values, prefix string, d *GitVersionDescriptor) {
+	if d == nil {
+		return
+	}
+	if d.Version != nil {
+		v.Set(prefix, *d.Version)
+	}
+	if d.VersionType != nil {
+		v.Set(prefix+"Type", *d.VersionType)
+	}
+	if d.VersionOptions != nil {
+		v.Set(prefix+"Options", *d.VersionOptions)
+	}
+}
+
+// GetCommitDiffs returns the files changed between the base and target
+// versions, with the common commit and the number of commits the target is
+// ahead of and behind the base. A nil version selects the default branch.
+// https://docs.microsoft.com/en-us/rest/api/azure/devops/git/diffs/get?view=azure-devops-rest-5.1
+func (s *GitService) GetCommitDiffs(ctx context.Context, owner, project, repo string, base, target *GitVersionDescriptor, opts *GitCommitDiffsOptions) (*GitCommitDiffs, *Response, error) {
+	URL := fmt.Sprintf(
+		"%s/%s/_apis/git/repositories/%s/diffs/commits?api-version=%s",
+		owner,
+		project,
+		repo,
+		s.client.apiVersion("git", "diffs"),
+	)
+	URL, err := addOptions(URL, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	u, err := url.Parse(URL)
+	if err != nil {
+		return nil, nil, err
+	}
+	q := u.Query()
+	setVersionParams(q, "baseVersion", base)
+	setVersionParams(q, "targetVersion", target)
+	u.RawQuery = q.Encode()
+
+	req, err := s.client.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	r := new(GitCommitDiffs)
+	resp, err := s.client.Execute(ctx, req, r)
+
+	return r, resp, err
+}
+
+// GetCommitDiffsPages walks every page of changes between the base and
+// target versions, calling fn with each page. Pages are requested with
+// $skip and $top.
+func (s *GitService) GetCommitDiffsPages(ctx context.Context, owner, project, repo string, base, target *GitVersionDescriptor, opts *GitCommitDiffsOptions, fn func(*GitCommitDiffs, *Response) error) error {
+	o := GitCommitDiffsOptions{}
+	if opts != nil {
+		o = *opts
+	}
+	return walkSkip(o.GetSkip(), o.GetTop(), func(skip, top int) (int, error) {
+		o.Skip, o.Top = Int(skip), Int(top)
+		diffs, resp, err := s.GetCommitDiffs(ctx, owner, project, repo, base, target, &o)
+		if err != nil {
+			return 0, err
+		}
+		return len(diffs.Changes), fn(diffs, resp)
+	})
+}
+
+// DefaultMaxDiffFileSize is the size above which GetUnifiedDiffs does not
+// render a patch for a version of a file, if the caller did not set one.
+const DefaultMaxDiffFileSize = 1 << 20
+
+// FileDiff is the unified diff of a file changed between two versions
+type FileDiff struct {
+	Path string
+	// OriginalPath is the path of a renamed file in the base version.
+	OriginalPath string
+	ChangeType   string
+	// Binary is set for files with a NUL byte, which have no Patch.
+	Binary bool
+	// TooLarge is set for files with a version larger than the size limit,
+	// which have no Patch.
+	TooLarge bool
+	Patch    string
+}
+
+// UnifiedDiffOptions describes the parameters of GetUnifiedDiffs
+type UnifiedDiffOptions struct {
+	// MaxFileSize is the largest version of a file, in bytes, which is
+	// downloaded and diffed. Defaults to DefaultMaxDiffFileSize.
+	MaxFileSize int64
+}
+
+// GetUnifiedDiffs compares the base and target versions like
+// GetCommitDiffs, then fetches both versions of each changed file and
+// renders a unified diff with three lines of context. Folders are skipped.
+// Downloads stop at the size limit in opts; files over it are reported as
+// TooLarge.
+func (s *GitService) GetUnifiedDiffs(ctx context.Context, owner, project, repo string, base, target *GitVersionDescriptor, opts *UnifiedDiffOptions) ([]*FileDiff, error) {
+	limit := int64(DefaultMaxDiffFileSize)
+	if opts != nil && opts.MaxFileSize > 0 {
+		limit = opts.MaxFileSize
+	}
+
+	var changes []*GitChange
+	err := s.GetCommitDiffsPages(ctx, owner, project, repo, base, target, nil, func(diffs *GitCommitDiffs, _ *Response) error {
+		changes = append(changes, diffs.Changes...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var files []*FileDiff
+	for _, change := range changes {
+		item := change.GetItem()
+		if item.GetIsFolder() || item.GetGitObjectType() == Tree.String() {
+			continue
+		}
+		changeType := change.GetChangeType()
+		f := &FileDiff{
+			Path:         item.GetPath(),
+			OriginalPath: change.GetSourceServerItem(),
+			ChangeType:   changeType,
+		}
+		oldName, newName := "a"+f.Path, "b"+f.Path
+		if f.OriginalPath != "" {
+			oldName = "a" + f.OriginalPath
+		}
+
+		oldID, newID := item.GetOriginalObjectID(), item.GetObjectID()
+		if strings.Contains(changeType, Delete.String()) {
+			if oldID == "" {
+				oldID = newID
+			}
+			newID, newName = "", "/dev/null"
+		}
+		if oldID == "" {
+			oldName = "/dev/null"
+		}
+
+		oldText, err := s.blobContent(ctx, owner, project, repo, oldID, limit)
+		if errors.Is(err, errBlobTooLarge) {
+			f.TooLarge = true
+			files = append(files, f)
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		newText, err := s.blobContent(ctx, owner, project, repo, newID, limit)
+		if errors.Is(err, errBlobTooLarge) {
+			f.TooLarge = true
+			files = append(files, f)
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if isBinary(oldText) || isBinary(newText) {
+			f.Binary = true
+		} else {
+			f.Patch = UnifiedDiff(oldName, newName, oldText, newText, 3)
+		}
+		files = append(files, f)
+	}
+	return files, nil
+}
+
+// errBlobTooLarge is returned by blobContent for a blob over the size limit.
+var errBlobTooLarge = errors.New("blob exceeds the size limit")
+
+// limitedBuffer is a buffer which fails writes beyond limit bytes, to stop
+// a download early. The buffer is not embedded, so that io.Copy cannot
+// bypass Write through bytes.Buffer.ReadFrom.
+type limitedBuffer struct {
+	buf   bytes.Buffer
+	limit int64
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if int64(b.buf.Len()+len(p)) > b.limit {
+		return 0, errBlobTooLarge
+	}
+	return b.buf.Write(p)
+}
+
+// blobContent returns the content of a blob, or nil for an empty ID. It
+// returns errBlobTooLarge if the blob is larger than limit bytes.
+func (s *GitService) blobContent(ctx context.Context, owner, project, repo, id string, limit int64) ([]byte, error) {
+	if id == "" {
+		return nil, nil
+	}
+	buf := &limitedBuffer{limit: limit}
+	if _, err := s.GetBlobContent(ctx, owner, project, repo, id, buf); err != nil {
+		return nil, err
+	}
+	return buf.buf.Bytes(), nil
+}
+
+// isBinary reports whether content looks binary, as git does: it has a NUL
+// byte in the first 8000 bytes.
+func isBinary(content []byte) bool {
+	if len(content) > 8000 {
+		content = content[:8000]
+	}
+	return bytes.IndexByte(content, 0) >= 0
+}
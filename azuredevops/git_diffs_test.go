@@ -0,0 +1,136 @@
+package azuredevops_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/mcdafydd/go-azuredevops/azuredevops"
+)
+
+func TestGitService_GetCommitDiffs(t *testing.T) {
+	c, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/o/p/_apis/git/repositories/r/diffs/commits", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{
+			"baseVersion":       "master",
+			"baseVersionType":   "branch",
+			"targetVersion":     "abc",
+			"targetVersionType": "commit",
+			"diffCommonCommit":  "false",
+		})
+		fmt.Fprint(w, `{
+			"aheadCount": 2,
+			"behindCount": 1,
+			"allChangesIncluded": true,
+			"commonCommit": "def",
+			"changeCounts": {"Edit": 1},
+			"changes": [{"changeType": "edit", "item": {"path": "/README.md", "gitObjectType": "blob"}}]
+		}`)
+	})
+
+	opts := &azuredevops.GitCommitDiffsOptions{DiffCommonCommit: azuredevops.Bool(false)}
+	got, _, err := c.Git.GetCommitDiffs(context.Background(), "o", "p", "r", azuredevops.BranchVersion("master"), azuredevops.CommitVersion("abc"), opts)
+	if err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+	want := &azuredevops.GitCommitDiffs{
+		AheadCount:         azuredevops.Int(2),
+		BehindCount:        azuredevops.Int(1),
+		AllChangesIncluded: azuredevops.Bool(true),
+		CommonCommit:       azuredevops.String("def"),
+		ChangeCounts:       &map[string]int{"Edit": 1},
+		Changes: []*azuredevops.GitChange{{
+			ChangeType: azuredevops.String("edit"),
+			Item: &azuredevops.GitItem{
+				Path:          azuredevops.String("/README.md"),
+				GitObjectType: azuredevops.String("blob"),
+			},
+		}},
+	}
+	if !cmp.Equal(got, want) {
+		t.Errorf("Git.GetCommitDiffs returned %+v, want %+v", got, want)
+	}
+}
+
+func TestGitService_GetUnifiedDiffs(t *testing.T) {
+	c, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/o/p/_apis/git/repositories/r/diffs/commits", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"changes": [
+			{"changeType": "edit", "item": {"path": "/a.txt", "gitObjectType": "blob", "objectId": "a2", "originalObjectId": "a1"}},
+			{"changeType": "add", "item": {"path": "/src", "gitObjectType": "tree", "isFolder": true}},
+			{"changeType": "add", "item": {"path": "/src/b.txt", "gitObjectType": "blob", "objectId": "b1"}},
+			{"changeType": "delete", "item": {"path": "/c.txt", "gitObjectType": "blob", "originalObjectId": "c1"}},
+			{"changeType": "edit, rename", "sourceServerItem": "/d.bin", "item": {"path": "/e.bin", "gitObjectType": "blob", "objectId": "d2", "originalObjectId": "d1"}}
+		]}`)
+	})
+	blobs := map[string]string{
+		"a1": "one\ntwo\n",
+		"a2": "one\n2\n",
+		"b1": "new\n",
+		"c1": "gone\n",
+		"d1": "\x00\x01",
+		"d2": "\x00\x02",
+	}
+	mux.HandleFunc("/o/p/_apis/git/repositories/r/blobs/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		id := r.URL.Path[len("/o/p/_apis/git/repositories/r/blobs/"):]
+		fmt.Fprint(w, blobs[id])
+	})
+
+	got, err := c.Git.GetUnifiedDiffs(context.Background(), "o", "p", "r", azuredevops.BranchVersion("master"), azuredevops.BranchVersion("topic"), nil)
+	if err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+	want := []*azuredevops.FileDiff{
+		{Path: "/a.txt", ChangeType: "edit", Patch: "--- a/a.txt\n+++ b/a.txt\n@@ -1,2 +1,2 @@\n one\n-two\n+2\n"},
+		{Path: "/src/b.txt", ChangeType: "add", Patch: "--- /dev/null\n+++ b/src/b.txt\n@@ -0,0 +1 @@\n+new\n"},
+		{Path: "/c.txt", ChangeType: "delete", Patch: "--- a/c.txt\n+++ /dev/null\n@@ -1 +0,0 @@\n-gone\n"},
+		{Path: "/e.bin", OriginalPath: "/d.bin", ChangeType: "edit, rename", Binary: true},
+	}
+	if !cmp.Equal(got, want) {
+		t.Errorf("Git.GetUnifiedDiffs differs: %s", cmp.Diff(want, got))
+	}
+}
+
+func TestGitService_GetUnifiedDiffs_tooLarge(t *testing.T) {
+	c, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/o/p/_apis/git/repositories/r/diffs/commits", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"changes": [
+			{"changeType": "edit", "item": {"path": "/big.txt", "gitObjectType": "blob", "objectId": "b2", "originalObjectId": "b1"}},
+			{"changeType": "edit", "item": {"path": "/small.txt", "gitObjectType": "blob", "objectId": "s2", "originalObjectId": "s1"}}
+		]}`)
+	})
+	blobs := map[string]string{
+		"b1": "small\n",
+		"b2": strings.Repeat("line\n", 100),
+		"s1": "one\n",
+		"s2": "two\n",
+	}
+	mux.HandleFunc("/o/p/_apis/git/repositories/r/blobs/", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, blobs[r.URL.Path[len("/o/p/_apis/git/repositories/r/blobs/"):]])
+	})
+
+	opts := &azuredevops.UnifiedDiffOptions{MaxFileSize: 64}
+	got, err := c.Git.GetUnifiedDiffs(context.Background(), "o", "p", "r", azuredevops.BranchVersion("master"), azuredevops.BranchVersion("topic"), opts)
+	if err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+	want := []*azuredevops.FileDiff{
+		{Path: "/big.txt", ChangeType: "edit", TooLarge: true},
+		{Path: "/small.txt", ChangeType: "edit", Patch: "--- a/small.txt\n+++ b/small.txt\n@@ -1 +1 @@\n-one\n+two\n"},
+	}
+	if !cmp.Equal(got, want) {
+		t.Errorf("Git.GetUnifiedDiffs differs: %s", cmp.Diff(want, got))
+	}
+}
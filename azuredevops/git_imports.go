@@ -0,0 +1,224 @@
+package azuredevops
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// GitAsyncOperationStatus enum declaration
+type GitAsyncOperationStatus int
+
+// GitAsyncOperationStatus enum values
+const (
+	AsyncOperationQueued GitAsyncOperationStatus = iota
+	AsyncOperationInProgress
+	AsyncOperationCompleted
+	AsyncOperationFailed
+	AsyncOperationAbandoned
+)
+
+func (d GitAsyncOperationStatus) String() string {
+	return [...]string{"queued", "inProgress", "completed", "failed", "abandoned"}[d]
+}
+
+// GitImportRequestsListResponse describes the git import requests list
+// response
+type GitImportRequestsListResponse struct {
+	Count          int                 `json:"count"`
+	ImportRequests []*GitImportRequest `json:"value"`
+}
+
+// GitImportRequest describes the import of an external repository into an
+// Azure DevOps repository
+type GitImportRequest struct {
+	Links           *map[string]Link            `json:"_links,omitempty"`
+	DetailedStatus  *GitImportStatusDetail      `json:"detailedStatus,omitempty"`
+	ImportRequestID *int                        `json:"importRequestId,omitempty"`
+	Parameters      *GitImportRequestParameters `json:"parameters,omitempty"`
+	Repository      *GitRepository              `json:"repository,omitempty"`
+	// Status is a GitAsyncOperationStatus
+	Status *string `json:"status,omitempty"`
+	URL    *string `json:"url,omitempty"`
+}
+
+// GitImportRequestParameters describes the source of an import. Credentials
+// for a private source are read from the service endpoint with ID
+// ServiceEndpointID.
+type GitImportRequestParameters struct {
+	DeleteServiceEndpointAfterImportIsDone *bool                `json:"deleteServiceEndpointAfterImportIsDone,omitempty"`
+	GitSource                              *GitImportGitSource  `json:"gitSource,omitempty"`
+	ServiceEndpointID                      *string              `json:"serviceEndpointId,omitempty"`
+	TfvcSource                             *GitImportTfvcSource `json:"tfvcSource,omitempty"`
+}
+
+// GitImportGitSource describes a git repository to import
+type GitImportGitSource struct {
+	// Overwrite must be set to import into a repository which is not empty.
+	Overwrite *bool   `json:"overwrite,omitempty"`
+	URL       *string `json:"url,omitempty"`
+}
+
+// GitImportTfvcSource describes a TFVC path to import
+type GitImportTfvcSource struct {
+	ImportHistory        *bool   `json:"importHistory,omitempty"`
+	ImportHistoryMaxDays *int    `json:"importHistoryMaxDays,omitempty"`
+	Path                 *string `json:"path,omitempty"`
+}
+
+// GitImportStatusDetail describes the progress of an import
+type GitImportStatusDetail struct {
+	AllSteps     []string `json:"allSteps,omitempty"`
+	CurrentStep  *int     `json:"currentStep,omitempty"`
+	ErrorMessage *string  `json:"errorMessage,omitempty"`
+}
+
+// GitImportRequestListOptions describes the parameters of the git import
+// requests list API
+type GitImportRequestListOptions struct {
+	IncludeAbandoned bool `url:"includeAbandoned,omitempty"`
+}
+
+// ImportError is returned by WaitForImport when an import fails or is
+// abandoned.
+type ImportError struct {
+	ImportRequest *GitImportRequest
+}
+
+func (e *ImportError) Error() string {
+	msg := e.ImportRequest.GetDetailedStatus().GetErrorMessage()
+	if msg == "" {
+		return fmt.Sprintf("import request %d %s", e.ImportRequest.GetImportRequestID(), e.ImportRequest.GetStatus())
+	}
+	return fmt.Sprintf("import request %d %s: %s", e.ImportRequest.GetImportRequestID(), e.ImportRequest.GetStatus(), msg)
+}
+
+func (s *GitService) importRequestsURL(owner, project, repo string) string {
+	return fmt.Sprintf(
+		"%s/%s/_apis/git/repositories/%s/importRequests",
+		owner,
+		project,
+		repo,
+	)
+}
+
+// CreateImportRequest starts importing an external repository into an
+// empty repository
+// https://docs.microsoft.com/en-us/rest/api/azure/devops/git/import%20requests/create?view=azure-devops-rest-5.1
+func (s *GitService) CreateImportRequest(ctx context.Context, owner, project, repo string, params *GitImportRequestParameters) (*GitImportRequest, *Response, error) {
+	URL := fmt.Sprintf(
+		"%s?api-version=%s",
+		s.importRequestsURL(owner, project, repo),
+		s.client.apiVersion("git", "importRequests"),
+	)
+
+	body := &GitImportRequest{Parameters: params}
+	req, err := s.client.NewRequest("POST", URL, body)
+	if err != nil {
+		return nil, nil, err
+	}
+	r := new(GitImportRequest)
+	resp, err := s.client.Execute(ctx, req, r)
+
+	return r, resp, err
+}
+
+// GetImportRequest returns an import request, including its status
+// https://docs.microsoft.com/en-us/rest/api/azure/devops/git/import%20requests/get?view=azure-devops-rest-5.1
+func (s *GitService) GetImportRequest(ctx context.Context, owner, project, repo string, importRequestID int) (*GitImportRequest, *Response, error) {
+	URL := fmt.Sprintf(
+		"%s/%d?api-version=%s",
+		s.importRequestsURL(owner, project, repo),
+		importRequestID,
+		s.client.apiVersion("git", "importRequests"),
+	)
+
+	req, err := s.client.NewRequest("GET", URL, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	r := new(GitImportRequest)
+	resp, err := s.client.Execute(ctx, req, r)
+
+	return r, resp, err
+}
+
+// ListImportRequests returns the import requests of a repository
+// https://docs.microsoft.com/en-us/rest/api/azure/devops/git/import%20requests/query?view=azure-devops-rest-5.1
+func (s *GitService) ListImportRequests(ctx context.Context, owner, project, repo string, opts *GitImportRequestListOptions) ([]*GitImportRequest, *Response, error) {
+	URL := fmt.Sprintf(
+		"%s?api-version=%s",
+		s.importRequestsURL(owner, project, repo),
+		s.client.apiVersion("git", "importRequests"),
+	)
+	URL, err := addOptions(URL, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := s.client.NewRequest("GET", URL, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	r := new(GitImportRequestsListResponse)
+	resp, err := s.client.Execute(ctx, req, r)
+
+	return r.ImportRequests, resp, err
+}
+
+// RetryImportRequest queues a failed import request again
+// https://docs.microsoft.com/en-us/rest/api/azure/devops/git/import%20requests/update?view=azure-devops-rest-5.1
+func (s *GitService) RetryImportRequest(ctx context.Context, owner, project, repo string, importRequestID int) (*GitImportRequest, *Response, error) {
+	return s.updateImportRequest(ctx, owner, project, repo, importRequestID, AsyncOperationQueued)
+}
+
+// AbandonImportRequest abandons a failed import request
+// https://docs.microsoft.com/en-us/rest/api/azure/devops/git/import%20requests/update?view=azure-devops-rest-5.1
+func (s *GitService) AbandonImportRequest(ctx context.Context, owner, project, repo string, importRequestID int) (*GitImportRequest, *Response, error) {
+	return s.updateImportRequest(ctx, owner, project, repo, importRequestID, AsyncOperationAbandoned)
+}
+
+func (s *GitService) updateImportRequest(ctx context.Context, owner, project, repo string, importRequestID int, status GitAsyncOperationStatus) (*GitImportRequest, *Response, error) {
+	URL := fmt.Sprintf(
+		"%s/%d?api-version=%s",
+		s.importRequestsURL(owner, project, repo),
+		importRequestID,
+		s.client.apiVersion("git", "importRequests"),
+	)
+
+	body := &GitImportRequest{Status: String(status.String())}
+	req, err := s.client.NewRequest("PATCH", URL, body)
+	if err != nil {
+		return nil, nil, err
+	}
+	r := new(GitImportRequest)
+	resp, err := s.client.Execute(ctx, req, r)
+
+	return r, resp, err
+}
+
+// WaitForImport polls an import request every interval until it completes,
+// calling progress, if not nil, with the request after each poll. It
+// returns an *ImportError if the import fails or is abandoned, and
+// ctx.Err() if ctx is done first. A zero interval uses DefaultPollInterval.
+func (s *GitService) WaitForImport(ctx context.Context, owner, project, repo string, importRequestID int, interval time.Duration, progress func(*GitImportRequest)) (*GitImportRequest, error) {
+	var r *GitImportRequest
+	err := poll(ctx, interval, func() (bool, error) {
+		var err error
+		r, _, err = s.GetImportRequest(ctx, owner, project, repo, importRequestID)
+		if err != nil {
+			return false, err
+		}
+		if progress != nil {
+			progress(r)
+		}
+		switch r.GetStatus() {
+		case AsyncOperationCompleted.String():
+			return true, nil
+		case AsyncOperationFailed.String(), AsyncOperationAbandoned.String():
+			return false, &ImportError{ImportRequest: r}
+		}
+		return false, nil
+	})
+	return r, err
+}
@@ -0,0 +1,140 @@
+package azuredevops_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/mcdafydd/go-azuredevops/azuredevops"
+)
+
+func TestGitService_CreateImportRequest(t *testing.T) {
+	c, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/o/p/_apis/git/repositories/r/importRequests", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testBody(t, r, `{"parameters":{"gitSource":{"url":"https://github.com/o/r.git"},"serviceEndpointId":"se1"}}`+"\n")
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"importRequestId": 7, "status": "queued"}`)
+	})
+
+	params := &azuredevops.GitImportRequestParameters{
+		GitSource:         &azuredevops.GitImportGitSource{URL: azuredevops.String("https://github.com/o/r.git")},
+		ServiceEndpointID: azuredevops.String("se1"),
+	}
+	got, _, err := c.Git.CreateImportRequest(context.Background(), "o", "p", "r", params)
+	if err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+	if got.GetImportRequestID() != 7 || got.GetStatus() != "queued" {
+		t.Errorf("Git.CreateImportRequest returned %+v", got)
+	}
+}
+
+func TestGitService_ListImportRequests(t *testing.T) {
+	c, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/o/p/_apis/git/repositories/r/importRequests", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{"includeAbandoned": "true"})
+		fmt.Fprint(w, `{"count": 2, "value": [{"importRequestId": 1, "status": "abandoned"}, {"importRequestId": 2, "status": "completed"}]}`)
+	})
+
+	opts := &azuredevops.GitImportRequestListOptions{IncludeAbandoned: true}
+	got, _, err := c.Git.ListImportRequests(context.Background(), "o", "p", "r", opts)
+	if err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+	if len(got) != 2 || got[1].GetStatus() != "completed" {
+		t.Errorf("Git.ListImportRequests returned %+v", got)
+	}
+}
+
+func TestGitService_RetryAndAbandonImportRequest(t *testing.T) {
+	c, mux, _, teardown := setup()
+	defer teardown()
+
+	var want string
+	mux.HandleFunc("/o/p/_apis/git/repositories/r/importRequests/7", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PATCH")
+		testBody(t, r, fmt.Sprintf(`{"status":%q}`+"\n", want))
+		fmt.Fprintf(w, `{"importRequestId": 7, "status": %q}`, want)
+	})
+
+	ctx := context.Background()
+	want = "queued"
+	if got, _, err := c.Git.RetryImportRequest(ctx, "o", "p", "r", 7); err != nil || got.GetStatus() != want {
+		t.Errorf("Git.RetryImportRequest returned %+v, %v", got, err)
+	}
+	want = "abandoned"
+	if got, _, err := c.Git.AbandonImportRequest(ctx, "o", "p", "r", 7); err != nil || got.GetStatus() != want {
+		t.Errorf("Git.AbandonImportRequest returned %+v, %v", got, err)
+	}
+}
+
+func TestGitService_WaitForImport(t *testing.T) {
+	c, mux, _, teardown := setup()
+	defer teardown()
+
+	statuses := []string{
+		`{"importRequestId": 7, "status": "queued"}`,
+		`{"importRequestId": 7, "status": "inProgress", "detailedStatus": {"currentStep": 2, "allSteps": ["a", "b", "c"]}}`,
+		`{"importRequestId": 7, "status": "completed"}`,
+	}
+	polls := 0
+	mux.HandleFunc("/o/p/_apis/git/repositories/r/importRequests/7", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, statuses[polls])
+		polls++
+	})
+
+	var steps []int
+	got, err := c.Git.WaitForImport(context.Background(), "o", "p", "r", 7, time.Millisecond, func(r *azuredevops.GitImportRequest) {
+		steps = append(steps, r.GetDetailedStatus().GetCurrentStep())
+	})
+	if err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+	if got.GetStatus() != "completed" || polls != 3 || len(steps) != 3 || steps[1] != 2 {
+		t.Errorf("Git.WaitForImport returned %+v after %d polls, progress %v", got, polls, steps)
+	}
+}
+
+func TestGitService_WaitForImport_failed(t *testing.T) {
+	c, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/o/p/_apis/git/repositories/r/importRequests/7", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"importRequestId": 7, "status": "failed", "detailedStatus": {"errorMessage": "authentication failed"}}`)
+	})
+
+	_, err := c.Git.WaitForImport(context.Background(), "o", "p", "r", 7, time.Millisecond, nil)
+	var importErr *azuredevops.ImportError
+	if !errors.As(err, &importErr) {
+		t.Fatalf("returned error %v, want *ImportError", err)
+	}
+	if want := "import request 7 failed: authentication failed"; err.Error() != want {
+		t.Errorf("Error() = %q, want %q", err.Error(), want)
+	}
+}
+
+func TestGitService_WaitForImport_contextDone(t *testing.T) {
+	c, mux, _, teardown := setup()
+	defer teardown()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	mux.HandleFunc("/o/p/_apis/git/repositories/r/importRequests/7", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"importRequestId": 7, "status": "inProgress"}`)
+		cancel()
+	})
+
+	_, err := c.Git.WaitForImport(ctx, "o", "p", "r", 7, time.Hour, nil)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("returned error %v, want context.Canceled", err)
+	}
+}
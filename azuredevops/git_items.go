@@ -0,0 +1,331 @@
+package azuredevops
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+)
+
+// GitVersionType enum declaration
+type GitVersionType int
+
+// GitVersionType enum values
+const (
+	VersionTypeBranch GitVersionType = iota
+	VersionTypeTag
+	VersionTypeCommit
+)
+
+func (d GitVersionType) String() string {
+	return [...]string{"branch", "tag", "commit"}[d]
+}
+
+// GitVersionOptions enum declaration
+type GitVersionOptions int
+
+// GitVersionOptions enum values
+const (
+	VersionOptionsNone GitVersionOptions = iota
+	VersionOptionsPreviousChange
+	VersionOptionsFirstParent
+)
+
+func (d GitVersionOptions) String() string {
+	return [...]string{"none", "previousChange", "firstParent"}[d]
+}
+
+// GitVersionDescriptor selects a version of a repository: a branch, tag or
+// commit. A nil descriptor selects the default branch.
+type GitVersionDescriptor struct {
+	Version        *string `json:"version,omitempty"`
+	VersionOptions *string `json:"versionOptions,omitempty"`
+	VersionType    *string `json:"versionType,omitempty"`
+}
+
+// BranchVersion returns a descriptor of the head of a branch.
+func BranchVersion(name string) *GitVersionDescriptor {
+	return &GitVersionDescriptor{Version: String(name), VersionType: String(VersionTypeBranch.String())}
+}
+
+// TagVersion returns a descriptor of a tag.
+func TagVersion(name string) *GitVersionDescriptor {
+	return &GitVersionDescriptor{Version: String(name), VersionType: String(VersionTypeTag.String())}
+}
+
+// CommitVersion returns a descriptor of a commit.
+func CommitVersion(commitID string) *GitVersionDescriptor {
+	return &GitVersionDescriptor{Version: String(commitID), VersionType: String(VersionTypeCommit.String())}
+}
+
+// EncodeValues adds the descriptor to query parameters as key.version,
+// key.versionOptions and key.versionType.
+func (d *GitVersionDescriptor) EncodeValues(key string, v *url.Values) error {
+	if d.Version != nil {
+		v.Set(key+".version", *d.Version)
+	}
+	if d.VersionOptions != nil {
+		v.Set(key+".versionOptions", *d.VersionOptions)
+	}
+	if d.VersionType != nil {
+		v.Set(key+".versionType", *d.VersionType)
+	}
+	return nil
+}
+
+// VersionControlRecursionType enum declaration
+type VersionControlRecursionType int
+
+// VersionControlRecursionType enum values
+const (
+	RecursionNone VersionControlRecursionType = iota
+	RecursionOneLevel
+	RecursionOneLevelPlusNestedEmptyFolders
+	RecursionFull
+)
+
+func (d VersionControlRecursionType) String() string {
+	return [...]string{"none", "oneLevel", "oneLevelPlusNestedEmptyFolders", "full"}[d]
+}
+
+// GitItemsListResponse describes the git items list response
+type GitItemsListResponse struct {
+	Count int        `json:"count"`
+	Items []*GitItem `json:"value"`
+}
+
+// GitItemOptions describes the parameters of the git item get API
+type GitItemOptions struct {
+	IncludeContent         bool                  `url:"includeContent,omitempty"`
+	IncludeContentMetadata bool                  `url:"includeContentMetadata,omitempty"`
+	LatestProcessedChange  bool                  `url:"latestProcessedChange,omitempty"`
+	ResolveLfs             bool                  `url:"resolveLfs,omitempty"`
+	Version                *GitVersionDescriptor `url:"versionDescriptor,omitempty"`
+}
+
+// GitItemListOptions describes the parameters of the git items list API
+type GitItemListOptions struct {
+	// ScopePath is the folder to list. Defaults to the repository root.
+	ScopePath string `url:"scopePath,omitempty"`
+	// RecursionLevel is a VersionControlRecursionType. Defaults to none,
+	// which lists only the item at ScopePath.
+	RecursionLevel         string                `url:"recursionLevel,omitempty"`
+	IncludeContentMetadata bool                  `url:"includeContentMetadata,omitempty"`
+	LatestProcessedChange  bool                  `url:"latestProcessedChange,omitempty"`
+	IncludeLinks           bool                  `url:"includeLinks,omitempty"`
+	Version                *GitVersionDescriptor `url:"versionDescriptor,omitempty"`
+}
+
+// GitTreeOptions describes the parameters of the git tree get API
+type GitTreeOptions struct {
+	Recursive bool `url:"recursive,omitempty"`
+}
+
+// GitTreeRef describes a git tree
+type GitTreeRef struct {
+	Links       *map[string]Link   `json:"_links,omitempty"`
+	ObjectID    *string            `json:"objectId,omitempty"`
+	Size        *int64             `json:"size,omitempty"`
+	TreeEntries []*GitTreeEntryRef `json:"treeEntries,omitempty"`
+	URL         *string            `json:"url,omitempty"`
+}
+
+// GitTreeEntryRef describes an entry of a git tree
+type GitTreeEntryRef struct {
+	GitObjectType *string `json:"gitObjectType,omitempty"`
+	Mode          *string `json:"mode,omitempty"`
+	ObjectID      *string `json:"objectId,omitempty"`
+	RelativePath  *string `json:"relativePath,omitempty"`
+	Size          *int64  `json:"size,omitempty"`
+	URL           *string `json:"url,omitempty"`
+}
+
+// GitBlobRef describes a git blob
+type GitBlobRef struct {
+	Links    *map[string]Link `json:"_links,omitempty"`
+	ObjectID *string          `json:"objectId,omitempty"`
+	Size     *int64           `json:"size,omitempty"`
+	URL      *string          `json:"url,omitempty"`
+}
+
+func (s *GitService) itemsURL(owner, project, repo, path, format string) string {
+	return fmt.Sprintf(
+		"%s/%s/_apis/git/repositories/%s/items?path=%s&$format=%s&api-version=%s",
+		owner,
+		project,
+		repo,
+		url.QueryEscape(path),
+		format,
+		s.client.apiVersion("git", "items"),
+	)
+}
+
+// GetItem returns the metadata of a file or folder, and the content of a
+// text file if opts.IncludeContent is set. Use GetItemContent for binary
+// or large files.
+// https://docs.microsoft.com/en-us/rest/api/azure/devops/git/items/get?view=azure-devops-rest-5.1
+func (s *GitService) GetItem(ctx context.Context, owner, project, repo, path string, opts *GitItemOptions) (*GitItem, *Response, error) {
+	URL, err := addOptions(s.itemsURL(owner, project, repo, path, "json"), opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := s.client.NewRequest("GET", URL, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	r := new(GitItem)
+	resp, err := s.client.Execute(ctx, req, r)
+
+	return r, resp, err
+}
+
+// GetItemContent writes the raw content of a file to w, without buffering
+// it in memory.
+// https://docs.microsoft.com/en-us/rest/api/azure/devops/git/items/get?view=azure-devops-rest-5.1
+func (s *GitService) GetItemContent(ctx context.Context, owner, project, repo, path string, opts *GitItemOptions, w io.Writer) (*Response, error) {
+	URL, err := addOptions(s.itemsURL(owner, project, repo, path, "octetStream"), opts)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := s.client.NewRequest("GET", URL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/octet-stream")
+	return s.client.Execute(ctx, req, w)
+}
+
+// GetItemsZip writes a zip archive of a folder at the given version to w,
+// without buffering the archive in memory. A nil version selects the
+// default branch.
+// https://docs.microsoft.com/en-us/rest/api/azure/devops/git/items/get?view=azure-devops-rest-5.1
+func (s *GitService) GetItemsZip(ctx context.Context, owner, project, repo, path string, version *GitVersionDescriptor, w io.Writer) (*Response, error) {
+	URL, err := addOptions(s.itemsURL(owner, project, repo, path, "zip")+"&download=true", &GitItemOptions{Version: version})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := s.client.NewRequest("GET", URL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/zip")
+	return s.client.Execute(ctx, req, w)
+}
+
+// ListItems returns the files and folders below opts.ScopePath, to the depth
+// given by opts.RecursionLevel.
+// https://docs.microsoft.com/en-us/rest/api/azure/devops/git/items/list?view=azure-devops-rest-5.1
+func (s *GitService) ListItems(ctx context.Context, owner, project, repo string, opts *GitItemListOptions) ([]*GitItem, *Response, error) {
+	URL := fmt.Sprintf(
+		"%s/%s/_apis/git/repositories/%s/items?api-version=%s",
+		owner,
+		project,
+		repo,
+		s.client.apiVersion("git", "items"),
+	)
+	URL, err := addOptions(URL, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := s.client.NewRequest("GET", URL, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	r := new(GitItemsListResponse)
+	resp, err := s.client.Execute(ctx, req, r)
+
+	return r.Items, resp, err
+}
+
+// GetTree returns a tree object
+// https://docs.microsoft.com/en-us/rest/api/azure/devops/git/trees/get?view=azure-devops-rest-5.1
+func (s *GitService) GetTree(ctx context.Context, owner, project, repo, sha1 string, opts *GitTreeOptions) (*GitTreeRef, *Response, error) {
+	URL := fmt.Sprintf(
+		"%s/%s/_apis/git/repositories/%s/trees/%s?$format=json&api-version=%s",
+		owner,
+		project,
+		repo,
+		sha1,
+		s.client.apiVersion("git", "trees"),
+	)
+	URL, err := addOptions(URL, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := s.client.NewRequest("GET", URL, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	r := new(GitTreeRef)
+	resp, err := s.client.Execute(ctx, req, r)
+
+	return r, resp, err
+}
+
+// GetBlob returns the metadata of a blob object
+// https://docs.microsoft.com/en-us/rest/api/azure/devops/git/blobs/get%20blob?view=azure-devops-rest-5.1
+func (s *GitService) GetBlob(ctx context.Context, owner, project, repo, sha1 string) (*GitBlobRef, *Response, error) {
+	URL := fmt.Sprintf(
+		"%s/%s/_apis/git/repositories/%s/blobs/%s?$format=json&api-version=%s",
+		owner,
+		project,
+		repo,
+		sha1,
+		s.client.apiVersion("git", "blobs"),
+	)
+
+	req, err := s.client.NewRequest("GET", URL, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	r := new(GitBlobRef)
+	resp, err := s.client.Execute(ctx, req, r)
+
+	return r, resp, err
+}
+
+// GetBlobContent writes the content of a blob object to w, without
+// buffering it in memory.
+// https://docs.microsoft.com/en-us/rest/api/azure/devops/git/blobs/get%20blob?view=azure-devops-rest-5.1
+func (s *GitService) GetBlobContent(ctx context.Context, owner, project, repo, sha1 string, w io.Writer) (*Response, error) {
+	URL := fmt.Sprintf(
+		"%s/%s/_apis/git/repositories/%s/blobs/%s?$format=octetStream&api-version=%s",
+		owner,
+		project,
+		repo,
+		sha1,
+		s.client.apiVersion("git", "blobs"),
+	)
+
+	req, err := s.client.NewRequest("GET", URL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/octet-stream")
+	return s.client.Execute(ctx, req, w)
+}
+
+// GetBlobsZip writes a zip archive of the given blob objects to w, without
+// buffering the archive in memory.
+// https://docs.microsoft.com/en-us/rest/api/azure/devops/git/blobs/get%20blobs%20zip?view=azure-devops-rest-5.1
+func (s *GitService) GetBlobsZip(ctx context.Context, owner, project, repo string, sha1s []string, w io.Writer) (*Response, error) {
+	URL := fmt.Sprintf(
+		"%s/%s/_apis/git/repositories/%s/blobs?api-version=%s",
+		owner,
+		project,
+		repo,
+		s.client.apiVersion("git", "blobs"),
+	)
+
+	req, err := s.client.NewRequest("POST", URL, sha1s)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/zip")
+	return s.client.Execute(ctx, req, w)
+}
@@ -0,0 +1,295 @@
+package azuredevops_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/mcdafydd/go-azuredevops/azuredevops"
+)
+
+func TestGitService_GetItem(t *testing.T) {
+	c, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/o/p/_apis/git/repositories/r/items", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{
+			"path":                          "/README.md",
+			"$format":                       "json",
+			"includeContent":                "true",
+			"versionDescriptor.version":     "v1.0",
+			"versionDescriptor.versionType": "tag",
+		})
+		fmt.Fprint(w, `{"objectId": "abc", "path": "/README.md", "content": "# Hello", "commitId": "def"}`)
+	})
+
+	opts := &azuredevops.GitItemOptions{
+		IncludeContent: true,
+		Version:        azuredevops.TagVersion("v1.0"),
+	}
+	got, _, err := c.Git.GetItem(context.Background(), "o", "p", "r", "/README.md", opts)
+	if err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+	want := &azuredevops.GitItem{
+		ObjectID: azuredevops.String("abc"),
+		Path:     azuredevops.String("/README.md"),
+		Content:  azuredevops.String("# Hello"),
+		CommitID: azuredevops.String("def"),
+	}
+	if !cmp.Equal(got, want) {
+		t.Errorf("Git.GetItem returned %+v, want %+v", got, want)
+	}
+}
+
+func TestGitService_GetItemContent(t *testing.T) {
+	c, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/o/p/_apis/git/repositories/r/items", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testHeader(t, r, "Accept", "application/octet-stream")
+		testFormValues(t, r, values{
+			"path":                          "/logo.png",
+			"$format":                       "octetStream",
+			"versionDescriptor.version":     "abc",
+			"versionDescriptor.versionType": "commit",
+		})
+		w.Write([]byte{0x89, 'P', 'N', 'G'})
+	})
+
+	var buf bytes.Buffer
+	opts := &azuredevops.GitItemOptions{Version: azuredevops.CommitVersion("abc")}
+	_, err := c.Git.GetItemContent(context.Background(), "o", "p", "r", "/logo.png", opts, &buf)
+	if err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+	if want := []byte{0x89, 'P', 'N', 'G'}; !bytes.Equal(buf.Bytes(), want) {
+		t.Errorf("Git.GetItemContent wrote %v, want %v", buf.Bytes(), want)
+	}
+}
+
+func TestGitService_ListItems(t *testing.T) {
+	c, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/o/p/_apis/git/repositories/r/items", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{
+			"scopePath":                     "/src",
+			"recursionLevel":                "oneLevel",
+			"versionDescriptor.version":     "develop",
+			"versionDescriptor.versionType": "branch",
+		})
+		fmt.Fprint(w, `{"count": 2, "value": [
+			{"path": "/src", "isFolder": true},
+			{"path": "/src/main.go", "gitObjectType": "blob"}
+		]}`)
+	})
+
+	opts := &azuredevops.GitItemListOptions{
+		ScopePath:      "/src",
+		RecursionLevel: azuredevops.RecursionOneLevel.String(),
+		Version:        azuredevops.BranchVersion("develop"),
+	}
+	got, _, err := c.Git.ListItems(context.Background(), "o", "p", "r", opts)
+	if err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+	if len(got) != 2 || !got[0].GetIsFolder() || got[1].GetPath() != "/src/main.go" {
+		t.Errorf("Git.ListItems returned %+v", got)
+	}
+}
+
+func TestGitService_GetTree(t *testing.T) {
+	c, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/o/p/_apis/git/repositories/r/trees/abc", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{"$format": "json", "recursive": "true"})
+		fmt.Fprint(w, `{"objectId": "abc", "treeEntries": [
+			{"gitObjectType": "blob", "mode": "100644", "objectId": "def", "relativePath": "main.go", "size": 12}
+		]}`)
+	})
+
+	got, _, err := c.Git.GetTree(context.Background(), "o", "p", "r", "abc", &azuredevops.GitTreeOptions{Recursive: true})
+	if err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+	want := &azuredevops.GitTreeRef{
+		ObjectID: azuredevops.String("abc"),
+		TreeEntries: []*azuredevops.GitTreeEntryRef{{
+			GitObjectType: azuredevops.String("blob"),
+			Mode:          azuredevops.String("100644"),
+			ObjectID:      azuredevops.String("def"),
+			RelativePath:  azuredevops.String("main.go"),
+			Size:          azuredevops.Int64(12),
+		}},
+	}
+	if !cmp.Equal(got, want) {
+		t.Errorf("Git.GetTree returned %+v, want %+v", got, want)
+	}
+}
+
+func TestGitService_GetBlob(t *testing.T) {
+	c, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/o/p/_apis/git/repositories/r/blobs/def", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		switch r.FormValue("$format") {
+		case "json":
+			fmt.Fprint(w, `{"objectId": "def", "size": 5}`)
+		case "octetStream":
+			fmt.Fprint(w, "hello")
+		default:
+			t.Errorf("Unexpected $format %q", r.FormValue("$format"))
+		}
+	})
+
+	blob, _, err := c.Git.GetBlob(context.Background(), "o", "p", "r", "def")
+	if err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+	if blob.GetObjectID() != "def" || blob.GetSize() != 5 {
+		t.Errorf("Git.GetBlob returned %+v", blob)
+	}
+
+	var buf bytes.Buffer
+	if _, err := c.Git.GetBlobContent(context.Background(), "o", "p", "r", "def", &buf); err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+	if buf.String() != "hello" {
+		t.Errorf("Git.GetBlobContent wrote %q, want %q", buf.String(), "hello")
+	}
+}
+
+func TestGitService_GetItemsZip(t *testing.T) {
+	c, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/o/p/_apis/git/repositories/r/items", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testHeader(t, r, "Accept", "application/zip")
+		testFormValues(t, r, values{"path": "/docs", "$format": "zip", "download": "true"})
+		fmt.Fprint(w, "PK\x03\x04")
+	})
+
+	var buf bytes.Buffer
+	_, err := c.Git.GetItemsZip(context.Background(), "o", "p", "r", "/docs", nil, &buf)
+	if err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+	if buf.String() != "PK\x03\x04" {
+		t.Errorf("Git.GetItemsZip wrote %q", buf.String())
+	}
+}
+
+func TestGitService_GetBlobsZip(t *testing.T) {
+	c, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/o/p/_apis/git/repositories/r/blobs", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testHeader(t, r, "Accept", "application/zip")
+		var got []string
+		json.NewDecoder(r.Body).Decode(&got)
+		if want := []string{"abc", "def"}; !cmp.Equal(got, want) {
+			t.Errorf("Request body = %v, want %v", got, want)
+		}
+		fmt.Fprint(w, "PK\x03\x04")
+	})
+
+	var buf bytes.Buffer
+	_, err := c.Git.GetBlobsZip(context.Background(), "o", "p", "r", []string{"abc", "def"}, &buf)
+	if err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+	if buf.String() != "PK\x03\x04" {
+		t.Errorf("Git.GetBlobsZip wrote %q", buf.String())
+	}
+}
+
+func TestGitService_GetItemsZip_truncated(t *testing.T) {
+	c, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/o/p/_apis/git/repositories/r/items", func(w http.ResponseWriter, r *http.Request) {
+		// Promise more than is sent, so the connection closes mid-body.
+		w.Header().Set("Content-Length", "1024")
+		fmt.Fprint(w, "PK\x03\x04")
+	})
+
+	var buf bytes.Buffer
+	_, err := c.Git.GetItemsZip(context.Background(), "o", "p", "r", "/docs", nil, &buf)
+	if err == nil {
+		t.Error("Git.GetItemsZip of a truncated archive returned no error")
+	}
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write(p []byte) (int, error) {
+	return 0, errors.New("disk full")
+}
+
+func TestGitService_GetBlobContent_writeError(t *testing.T) {
+	c, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/o/p/_apis/git/repositories/r/blobs/abc", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "content")
+	})
+
+	_, err := c.Git.GetBlobContent(context.Background(), "o", "p", "r", "abc", failingWriter{})
+	if err == nil || err.Error() != "disk full" {
+		t.Errorf("Git.GetBlobContent returned %v, want disk full", err)
+	}
+}
+
+// recordingCache is a CacheStore which records the keys stored in it.
+type recordingCache struct {
+	azuredevops.CacheStore
+	set []string
+}
+
+func (c *recordingCache) Set(key string, r *azuredevops.CachedResponse) {
+	c.set = append(c.set, key)
+	c.CacheStore.Set(key, r)
+}
+
+func TestGitService_GetItemsZip_notCached(t *testing.T) {
+	c, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/o/p/_apis/git/repositories/r/items", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") != "" {
+			t.Errorf("Request was conditional: If-None-Match = %q", r.Header.Get("If-None-Match"))
+		}
+		w.Header().Set("ETag", `"v1"`)
+		fmt.Fprint(w, "PK\x03\x04")
+	})
+
+	cache := &recordingCache{CacheStore: azuredevops.NewMemoryCache(1 << 20)}
+	c.Cache = cache
+	for i := 0; i < 2; i++ {
+		var buf bytes.Buffer
+		resp, err := c.Git.GetItemsZip(context.Background(), "o", "p", "r", "/docs", nil, &buf)
+		if err != nil {
+			t.Fatalf("returned error: %v", err)
+		}
+		if buf.String() != "PK\x03\x04" || resp.FromCache {
+			t.Errorf("Git.GetItemsZip call %d wrote %q, FromCache %v", i, buf.String(), resp.FromCache)
+		}
+	}
+	if len(cache.set) != 0 {
+		t.Errorf("Cache stored %v, want nothing", cache.set)
+	}
+}
@@ -0,0 +1,236 @@
+package azuredevops
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+)
+
+// GitPushesListResponse describes the git pushes list response
+type GitPushesListResponse struct {
+	Count  int        `json:"count"`
+	Pushes []*GitPush `json:"value"`
+}
+
+// GitPushListOptions describes the search criteria of the git pushes list
+// API. Dates use the format of the API, for example 2019-10-01T00:00:00Z.
+type GitPushListOptions struct {
+	FromDate          string `url:"searchCriteria.fromDate,omitempty"`
+	ToDate            string `url:"searchCriteria.toDate,omitempty"`
+	PusherID          string `url:"searchCriteria.pusherId,omitempty"`
+	RefName           string `url:"searchCriteria.refName,omitempty"`
+	IncludeRefUpdates bool   `url:"searchCriteria.includeRefUpdates,omitempty"`
+	IncludeLinks      bool   `url:"searchCriteria.includeLinks,omitempty"`
+	Skip              *int   `url:"$skip,omitempty"`
+	Top               *int   `url:"$top,omitempty"`
+}
+
+// GitPushGetOptions describes the parameters of the git push get API
+type GitPushGetOptions struct {
+	// IncludeCommits is the number of commits to include in the result.
+	IncludeCommits    *int `url:"includeCommits,omitempty"`
+	IncludeRefUpdates bool `url:"includeRefUpdates,omitempty"`
+}
+
+// CreatePush pushes one or more commits to a repository. Build the push
+// with NewPush for the common case of committing files to a branch.
+// https://docs.microsoft.com/en-us/rest/api/azure/devops/git/pushes/create?view=azure-devops-rest-5.1
+func (s *GitService) CreatePush(ctx context.Context, owner, project, repo string, push *GitPush) (*GitPush, *Response, error) {
+	URL := fmt.Sprintf(
+		"%s/%s/_apis/git/repositories/%s/pushes?api-version=%s",
+		owner,
+		project,
+		repo,
+		s.client.apiVersion("git", "pushes"),
+	)
+
+	req, err := s.client.NewRequest("POST", URL, push)
+	if err != nil {
+		return nil, nil, err
+	}
+	r := new(GitPush)
+	resp, err := s.client.Execute(ctx, req, r)
+
+	return r, resp, err
+}
+
+// GetPush returns a single push
+// https://docs.microsoft.com/en-us/rest/api/azure/devops/git/pushes/get?view=azure-devops-rest-5.1
+func (s *GitService) GetPush(ctx context.Context, owner, project, repo string, pushID int, opts *GitPushGetOptions) (*GitPush, *Response, error) {
+	URL := fmt.Sprintf(
+		"%s/%s/_apis/git/repositories/%s/pushes/%d?api-version=%s",
+		owner,
+		project,
+		repo,
+		pushID,
+		s.client.apiVersion("git", "pushes"),
+	)
+	URL, err := addOptions(URL, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := s.client.NewRequest("GET", URL, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	r := new(GitPush)
+	resp, err := s.client.Execute(ctx, req, r)
+
+	return r, resp, err
+}
+
+// ListPushes returns the pushes to a repository matching the search criteria
+// https://docs.microsoft.com/en-us/rest/api/azure/devops/git/pushes/list?view=azure-devops-rest-5.1
+func (s *GitService) ListPushes(ctx context.Context, owner, project, repo string, opts *GitPushListOptions) ([]*GitPush, *Response, error) {
+	URL := fmt.Sprintf(
+		"%s/%s/_apis/git/repositories/%s/pushes?api-version=%s",
+		owner,
+		project,
+		repo,
+		s.client.apiVersion("git", "pushes"),
+	)
+	URL, err := addOptions(URL, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := s.client.NewRequest("GET", URL, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	r := new(GitPushesListResponse)
+	resp, err := s.client.Execute(ctx, req, r)
+
+	return r.Pushes, resp, err
+}
+
+// ListPushesPages walks every page of pushes matching opts, calling fn with
+// each page. Pages are requested with $skip and $top.
+func (s *GitService) ListPushesPages(ctx context.Context, owner, project, repo string, opts *GitPushListOptions, fn func([]*GitPush, *Response) error) error {
+	o := GitPushListOptions{}
+	if opts != nil {
+		o = *opts
+	}
+	return walkSkip(o.GetSkip(), o.GetTop(), func(skip, top int) (int, error) {
+		o.Skip, o.Top = Int(skip), Int(top)
+		pushes, resp, err := s.ListPushes(ctx, owner, project, repo, &o)
+		if err != nil {
+			return 0, err
+		}
+		return len(pushes), fn(pushes, resp)
+	})
+}
+
+// ListAllPushes returns every push matching opts, walking all pages of
+// results.
+func (s *GitService) ListAllPushes(ctx context.Context, owner, project, repo string, opts *GitPushListOptions) ([]*GitPush, error) {
+	var all []*GitPush
+	err := s.ListPushesPages(ctx, owner, project, repo, opts, func(pushes []*GitPush, _ *Response) error {
+		all = append(all, pushes...)
+		return nil
+	})
+	return all, err
+}
+
+// PushBuilder builds a push of one or more commits to a single branch, for
+// use with CreatePush.
+//
+//	push := azuredevops.NewPush("refs/heads/master", oldObjectID)
+//	push.Commit("Bump version").
+//		Edit("/VERSION", "1.2.0\n").
+//		Add("/docs/1.2.0.md", notes)
+//	git.CreatePush(ctx, owner, project, repo, push.Push())
+type PushBuilder struct {
+	push *GitPush
+}
+
+// NewPush starts a push to the ref name, which must currently point at
+// oldObjectID. Use ZeroObjectID to create a new branch.
+func NewPush(name, oldObjectID string) *PushBuilder {
+	formatRef(&name)
+	return &PushBuilder{push: &GitPush{
+		RefUpdates: []*GitRefUpdate{{
+			Name:        String(name),
+			OldObjectID: String(oldObjectID),
+		}},
+	}}
+}
+
+// Commit adds a commit with the given message to the push, and returns a
+// builder for its changes. Commits are applied in the order they are added.
+func (b *PushBuilder) Commit(message string) *CommitBuilder {
+	commit := &GitCommitRef{Comment: String(message)}
+	b.push.Commits = append(b.push.Commits, commit)
+	return &CommitBuilder{commit: commit}
+}
+
+// Push returns the push to pass to CreatePush.
+func (b *PushBuilder) Push() *GitPush {
+	return b.push
+}
+
+// CommitBuilder adds changes to a commit started with PushBuilder.Commit.
+// Paths are absolute within the repository, such as /src/main.go.
+type CommitBuilder struct {
+	commit *GitCommitRef
+}
+
+// Author sets the author of the commit. By default it is the authenticated
+// user.
+func (b *CommitBuilder) Author(name, email string) *CommitBuilder {
+	b.commit.Author = &GitUserDate{Name: String(name), Email: String(email)}
+	return b
+}
+
+func (b *CommitBuilder) change(changeType VersionControlChangeType, path string, content *ItemContent) *CommitBuilder {
+	b.commit.Changes = append(b.commit.Changes, &GitChange{
+		ChangeType: String(changeType.String()),
+		Item:       &GitItem{Path: String(path)},
+		NewContent: content,
+	})
+	return b
+}
+
+func textContent(content string) *ItemContent {
+	return &ItemContent{Content: String(content), ContentType: String(RawText.String())}
+}
+
+func binaryContent(content []byte) *ItemContent {
+	return &ItemContent{
+		Content:     String(base64.StdEncoding.EncodeToString(content)),
+		ContentType: String(Base64Encoded.String()),
+	}
+}
+
+// Add adds a new text file.
+func (b *CommitBuilder) Add(path, content string) *CommitBuilder {
+	return b.change(Add, path, textContent(content))
+}
+
+// AddBinary adds a new file, sent base64 encoded.
+func (b *CommitBuilder) AddBinary(path string, content []byte) *CommitBuilder {
+	return b.change(Add, path, binaryContent(content))
+}
+
+// Edit replaces the content of an existing text file.
+func (b *CommitBuilder) Edit(path, content string) *CommitBuilder {
+	return b.change(Edit, path, textContent(content))
+}
+
+// EditBinary replaces the content of an existing file, sent base64 encoded.
+func (b *CommitBuilder) EditBinary(path string, content []byte) *CommitBuilder {
+	return b.change(Edit, path, binaryContent(content))
+}
+
+// Delete deletes a file.
+func (b *CommitBuilder) Delete(path string) *CommitBuilder {
+	return b.change(Delete, path, nil)
+}
+
+// Rename moves a file from one path to another.
+func (b *CommitBuilder) Rename(from, to string) *CommitBuilder {
+	b.change(Rename, to, nil)
+	b.commit.Changes[len(b.commit.Changes)-1].SourceServerItem = String(from)
+	return b
+}
@@ -0,0 +1,126 @@
+package azuredevops_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/mcdafydd/go-azuredevops/azuredevops"
+)
+
+func TestGitService_CreatePush(t *testing.T) {
+	c, mux, _, teardown := setup()
+	defer teardown()
+
+	push := azuredevops.NewPush("master", "abc")
+	push.Commit("Bump version").
+		Author("Bot", "bot@example.com").
+		Edit("/VERSION", "1.2.0\n").
+		AddBinary("/logo.png", []byte{0x89, 'P', 'N', 'G'})
+	push.Commit("Tidy up").
+		Delete("/old.txt").
+		Rename("/a.txt", "/b.txt")
+
+	mux.HandleFunc("/o/p/_apis/git/repositories/r/pushes", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+
+		var got map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&got)
+		var want map[string]interface{}
+		json.Unmarshal([]byte(`{
+			"refUpdates": [{"name": "refs/heads/master", "oldObjectId": "abc"}],
+			"commits": [
+				{
+					"comment": "Bump version",
+					"author": {"name": "Bot", "email": "bot@example.com"},
+					"changes": [
+						{"changeType": "edit", "item": {"path": "/VERSION"}, "newContent": {"content": "1.2.0\n", "contentType": "rawText"}},
+						{"changeType": "add", "item": {"path": "/logo.png"}, "newContent": {"content": "iVBORw==", "contentType": "base64Encoded"}}
+					]
+				},
+				{
+					"comment": "Tidy up",
+					"changes": [
+						{"changeType": "delete", "item": {"path": "/old.txt"}},
+						{"changeType": "rename", "item": {"path": "/b.txt"}, "sourceServerItem": "/a.txt"}
+					]
+				}
+			]
+		}`), &want)
+		if !cmp.Equal(got, want) {
+			t.Errorf("Request body differs: %s", cmp.Diff(got, want))
+		}
+
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{
+			"pushId": 42,
+			"refUpdates": [{"name": "refs/heads/master", "oldObjectId": "abc", "newObjectId": "def"}],
+			"commits": [{"commitId": "def", "comment": "Tidy up"}]
+		}`)
+	})
+
+	got, _, err := c.Git.CreatePush(context.Background(), "o", "p", "r", push.Push())
+	if err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+	if got.GetPushID() != 42 || got.RefUpdates[0].GetNewObjectID() != "def" {
+		t.Errorf("Git.CreatePush returned %+v", got)
+	}
+}
+
+func TestGitService_GetPush(t *testing.T) {
+	c, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/o/p/_apis/git/repositories/r/pushes/42", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{"includeCommits": "10", "includeRefUpdates": "true"})
+		fmt.Fprint(w, `{"pushId": 42, "pushedBy": {"displayName": "Jamal Hartnett"}}`)
+	})
+
+	opts := &azuredevops.GitPushGetOptions{IncludeCommits: azuredevops.Int(10), IncludeRefUpdates: true}
+	push, _, err := c.Git.GetPush(context.Background(), "o", "p", "r", 42, opts)
+	if err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+	if push.GetPushID() != 42 || push.GetPushedBy().GetDisplayName() != "Jamal Hartnett" {
+		t.Errorf("Git.GetPush returned %+v", push)
+	}
+}
+
+func TestGitService_ListAllPushes(t *testing.T) {
+	c, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/o/p/_apis/git/repositories/r/pushes", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		skip := r.URL.Query().Get("$skip")
+		testFormValues(t, r, values{
+			"searchCriteria.refName":  "refs/heads/master",
+			"searchCriteria.fromDate": "2019-10-01T00:00:00Z",
+			"$top":                    "2",
+			"$skip":                   skip,
+		})
+		switch skip {
+		case "0":
+			fmt.Fprint(w, `{"count": 2, "value": [{"pushId": 3}, {"pushId": 2}]}`)
+		default:
+			fmt.Fprint(w, `{"count": 1, "value": [{"pushId": 1}]}`)
+		}
+	})
+
+	pushes, err := c.Git.ListAllPushes(context.Background(), "o", "p", "r", &azuredevops.GitPushListOptions{
+		RefName:  "refs/heads/master",
+		FromDate: "2019-10-01T00:00:00Z",
+		Top:      azuredevops.Int(2),
+	})
+	if err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+	if len(pushes) != 3 || pushes[2].GetPushID() != 1 {
+		t.Errorf("Git.ListAllPushes returned %d pushes", len(pushes))
+	}
+}
@@ -0,0 +1,263 @@
+package azuredevops
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// GitRepositoriesListResponse describes the git repositories list response
+type GitRepositoriesListResponse struct {
+	Count        int              `json:"count"`
+	Repositories []*GitRepository `json:"value"`
+}
+
+// GitDeletedRepositoriesListResponse describes the git recycle bin list
+// response
+type GitDeletedRepositoriesListResponse struct {
+	Count        int                     `json:"count"`
+	Repositories []*GitDeletedRepository `json:"value"`
+}
+
+// GitForksListResponse describes the git forks list response
+type GitForksListResponse struct {
+	Count int                 `json:"count"`
+	Forks []*GitRepositoryRef `json:"value"`
+}
+
+// GitDeletedRepository describes a repository in the recycle bin
+type GitDeletedRepository struct {
+	CreatedDate *Time                 `json:"createdDate,omitempty"`
+	DeletedBy   *IdentityRef          `json:"deletedBy,omitempty"`
+	DeletedDate *Time                 `json:"deletedDate,omitempty"`
+	ID          *string               `json:"id,omitempty"`
+	Name        *string               `json:"name,omitempty"`
+	Project     *TeamProjectReference `json:"project,omitempty"`
+}
+
+// GitRepositoryListOptions describes the parameters of the git repositories
+// list API
+type GitRepositoryListOptions struct {
+	IncludeLinks   bool `url:"includeLinks,omitempty"`
+	IncludeAllURLs bool `url:"includeAllUrls,omitempty"`
+	// IncludeHidden includes repositories hidden from the web UI, such as
+	// those of disabled projects.
+	IncludeHidden bool `url:"includeHidden,omitempty"`
+}
+
+// GitRepositoryCreateOptions describes a repository to create. Set
+// ParentRepository to create a fork.
+type GitRepositoryCreateOptions struct {
+	Name             *string               `json:"name,omitempty"`
+	ParentRepository *GitRepositoryRef     `json:"parentRepository,omitempty"`
+	Project          *TeamProjectReference `json:"project,omitempty"`
+}
+
+// gitRepositoryCreateQuery describes the query parameters of the git
+// repository create API
+type gitRepositoryCreateQuery struct {
+	SourceRef string `url:"sourceRef,omitempty"`
+}
+
+// ListRepositories returns the repositories of a project
+// https://docs.microsoft.com/en-us/rest/api/azure/devops/git/repositories/list?view=azure-devops-rest-5.1
+func (s *GitService) ListRepositories(ctx context.Context, owner, project string, opts *GitRepositoryListOptions) ([]*GitRepository, *Response, error) {
+	URL := fmt.Sprintf(
+		"%s/%s/_apis/git/repositories?api-version=%s",
+		owner,
+		project,
+		s.client.apiVersion("git", "repositories"),
+	)
+	URL, err := addOptions(URL, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := s.client.NewRequest("GET", URL, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	r := new(GitRepositoriesListResponse)
+	resp, err := s.client.Execute(ctx, req, r)
+
+	return r.Repositories, resp, err
+}
+
+// CreateRepository creates a repository in a project
+// https://docs.microsoft.com/en-us/rest/api/azure/devops/git/repositories/create?view=azure-devops-rest-5.1
+func (s *GitService) CreateRepository(ctx context.Context, owner, project string, repo *GitRepositoryCreateOptions) (*GitRepository, *Response, error) {
+	return s.createRepository(ctx, owner, project, repo, "")
+}
+
+// CreateFork creates a repository named name as a fork of parent. Only the
+// sourceRef branch, such as refs/heads/master, is copied to the fork; an
+// empty sourceRef copies every ref.
+// https://docs.microsoft.com/en-us/rest/api/azure/devops/git/repositories/create?view=azure-devops-rest-5.1
+func (s *GitService) CreateFork(ctx context.Context, owner, project, name string, parent *GitRepositoryRef, sourceRef string) (*GitRepository, *Response, error) {
+	repo := &GitRepositoryCreateOptions{
+		Name:             String(name),
+		ParentRepository: parent,
+	}
+	return s.createRepository(ctx, owner, project, repo, sourceRef)
+}
+
+func (s *GitService) createRepository(ctx context.Context, owner, project string, repo *GitRepositoryCreateOptions, sourceRef string) (*GitRepository, *Response, error) {
+	URL := fmt.Sprintf(
+		"%s/%s/_apis/git/repositories?api-version=%s",
+		owner,
+		project,
+		s.client.apiVersion("git", "repositories"),
+	)
+	URL, err := addOptions(URL, &gitRepositoryCreateQuery{SourceRef: sourceRef})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := s.client.NewRequest("POST", URL, repo)
+	if err != nil {
+		return nil, nil, err
+	}
+	r := new(GitRepository)
+	resp, err := s.client.Execute(ctx, req, r)
+
+	return r, resp, err
+}
+
+// UpdateRepository renames a repository or changes its default branch. Only
+// the Name and DefaultBranch fields of update are used, and at least one of
+// them must be set.
+// https://docs.microsoft.com/en-us/rest/api/azure/devops/git/repositories/update?view=azure-devops-rest-5.1
+func (s *GitService) UpdateRepository(ctx context.Context, owner, project, repoID string, update *GitRepository) (*GitRepository, *Response, error) {
+	URL := fmt.Sprintf(
+		"%s/%s/_apis/git/repositories/%s?api-version=%s",
+		owner,
+		project,
+		repoID,
+		s.client.apiVersion("git", "repositories"),
+	)
+
+	if update.GetName() == "" && update.GetDefaultBranch() == "" {
+		return nil, nil, errors.New("Git.UpdateRepository: Name or DefaultBranch is required")
+	}
+	body := &GitRepository{}
+	if update.GetName() != "" {
+		body.Name = update.Name
+	}
+	if update.GetDefaultBranch() != "" {
+		branch := update.GetDefaultBranch()
+		if err := formatRef(&branch); err != nil {
+			return nil, nil, err
+		}
+		body.DefaultBranch = &branch
+	}
+	req, err := s.client.NewRequest("PATCH", URL, body)
+	if err != nil {
+		return nil, nil, err
+	}
+	r := new(GitRepository)
+	resp, err := s.client.Execute(ctx, req, r)
+
+	return r, resp, err
+}
+
+// DeleteRepository moves a repository to the recycle bin of its project.
+// The repository must be given by ID, not name.
+// https://docs.microsoft.com/en-us/rest/api/azure/devops/git/repositories/delete?view=azure-devops-rest-5.1
+func (s *GitService) DeleteRepository(ctx context.Context, owner, project, repoID string) (*Response, error) {
+	URL := fmt.Sprintf(
+		"%s/%s/_apis/git/repositories/%s?api-version=%s",
+		owner,
+		project,
+		repoID,
+		s.client.apiVersion("git", "repositories"),
+	)
+
+	req, err := s.client.NewRequest("DELETE", URL, nil)
+	if err != nil {
+		return nil, err
+	}
+	return s.client.Execute(ctx, req, nil)
+}
+
+// ListRecycleBinRepositories returns the deleted repositories of a project
+// which can still be restored
+// https://docs.microsoft.com/en-us/rest/api/azure/devops/git/repositories/get%20recycle%20bin%20repositories?view=azure-devops-rest-5.1
+func (s *GitService) ListRecycleBinRepositories(ctx context.Context, owner, project string) ([]*GitDeletedRepository, *Response, error) {
+	URL := fmt.Sprintf(
+		"%s/%s/_apis/git/recycleBin/repositories?api-version=%s",
+		owner,
+		project,
+		s.client.apiVersion("git", "repositories"),
+	)
+
+	req, err := s.client.NewRequest("GET", URL, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	r := new(GitDeletedRepositoriesListResponse)
+	resp, err := s.client.Execute(ctx, req, r)
+
+	return r.Repositories, resp, err
+}
+
+// RestoreRepository restores a deleted repository from the recycle bin
+// https://docs.microsoft.com/en-us/rest/api/azure/devops/git/repositories/restore%20repository%20from%20recycle%20bin?view=azure-devops-rest-5.1
+func (s *GitService) RestoreRepository(ctx context.Context, owner, project, repoID string) (*GitRepository, *Response, error) {
+	URL := fmt.Sprintf(
+		"%s/%s/_apis/git/recycleBin/repositories/%s?api-version=%s",
+		owner,
+		project,
+		repoID,
+		s.client.apiVersion("git", "repositories"),
+	)
+
+	body := map[string]bool{"deleted": false}
+	req, err := s.client.NewRequest("PATCH", URL, body)
+	if err != nil {
+		return nil, nil, err
+	}
+	r := new(GitRepository)
+	resp, err := s.client.Execute(ctx, req, r)
+
+	return r, resp, err
+}
+
+// PurgeRepository permanently deletes a repository from the recycle bin
+// https://docs.microsoft.com/en-us/rest/api/azure/devops/git/repositories/delete%20repository%20from%20recycle%20bin?view=azure-devops-rest-5.1
+func (s *GitService) PurgeRepository(ctx context.Context, owner, project, repoID string) (*Response, error) {
+	URL := fmt.Sprintf(
+		"%s/%s/_apis/git/recycleBin/repositories/%s?api-version=%s",
+		owner,
+		project,
+		repoID,
+		s.client.apiVersion("git", "repositories"),
+	)
+
+	req, err := s.client.NewRequest("DELETE", URL, nil)
+	if err != nil {
+		return nil, err
+	}
+	return s.client.Execute(ctx, req, nil)
+}
+
+// ListForks returns the forks of a repository in a project collection
+// https://docs.microsoft.com/en-us/rest/api/azure/devops/git/forks/list?view=azure-devops-rest-5.1
+func (s *GitService) ListForks(ctx context.Context, owner, project, repo, collectionID string) ([]*GitRepositoryRef, *Response, error) {
+	URL := fmt.Sprintf(
+		"%s/%s/_apis/git/repositories/%s/forks/%s?api-version=%s",
+		owner,
+		project,
+		repo,
+		collectionID,
+		s.client.apiVersion("git", "forks"),
+	)
+
+	req, err := s.client.NewRequest("GET", URL, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	r := new(GitForksListResponse)
+	resp, err := s.client.Execute(ctx, req, r)
+
+	return r.Forks, resp, err
+}
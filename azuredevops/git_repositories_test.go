@@ -0,0 +1,198 @@
+package azuredevops_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/mcdafydd/go-azuredevops/azuredevops"
+)
+
+func TestGitService_ListRepositories(t *testing.T) {
+	c, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/o/p/_apis/git/repositories", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{"includeHidden": "true"})
+		fmt.Fprint(w, `{"count": 2, "value": [{"id": "1", "name": "a"}, {"id": "2", "name": "b"}]}`)
+	})
+
+	opts := &azuredevops.GitRepositoryListOptions{IncludeHidden: true}
+	got, resp, err := c.Git.ListRepositories(context.Background(), "o", "p", opts)
+	if err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+	if len(got) != 2 || got[1].GetName() != "b" || resp.Count != 2 {
+		t.Errorf("Git.ListRepositories returned %+v", got)
+	}
+}
+
+func TestGitService_CreateRepository(t *testing.T) {
+	c, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/o/p/_apis/git/repositories", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testFormValues(t, r, values{})
+		testBody(t, r, `{"name":"svc"}`+"\n")
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"id": "1", "name": "svc"}`)
+	})
+
+	repo := &azuredevops.GitRepositoryCreateOptions{Name: azuredevops.String("svc")}
+	got, _, err := c.Git.CreateRepository(context.Background(), "o", "p", repo)
+	if err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+	if got.GetID() != "1" {
+		t.Errorf("Git.CreateRepository returned %+v", got)
+	}
+}
+
+func TestGitService_CreateFork(t *testing.T) {
+	c, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/o/p/_apis/git/repositories", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testFormValues(t, r, values{"sourceRef": "refs/heads/master"})
+		testBody(t, r, `{"name":"svc-fork","parentRepository":{"id":"1"}}`+"\n")
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"id": "2", "name": "svc-fork", "isFork": true, "parentRepository": {"id": "1"}}`)
+	})
+
+	parent := &azuredevops.GitRepositoryRef{ID: azuredevops.String("1")}
+	got, _, err := c.Git.CreateFork(context.Background(), "o", "p", "svc-fork", parent, "refs/heads/master")
+	if err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+	if !got.GetIsFork() || got.GetParentRepository().GetID() != "1" {
+		t.Errorf("Git.CreateFork returned %+v", got)
+	}
+}
+
+func TestGitService_UpdateRepository(t *testing.T) {
+	c, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/o/p/_apis/git/repositories/1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PATCH")
+		testBody(t, r, `{"defaultBranch":"refs/heads/main","name":"renamed"}`+"\n")
+		fmt.Fprint(w, `{"id": "1", "name": "renamed", "defaultBranch": "refs/heads/main"}`)
+	})
+
+	update := &azuredevops.GitRepository{
+		Name:          azuredevops.String("renamed"),
+		DefaultBranch: azuredevops.String("main"),
+		ID:            azuredevops.String("ignored"),
+	}
+	got, _, err := c.Git.UpdateRepository(context.Background(), "o", "p", "1", update)
+	if err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+	want := &azuredevops.GitRepository{
+		ID:            azuredevops.String("1"),
+		Name:          azuredevops.String("renamed"),
+		DefaultBranch: azuredevops.String("refs/heads/main"),
+	}
+	if !cmp.Equal(got, want) {
+		t.Errorf("Git.UpdateRepository returned %+v, want %+v", got, want)
+	}
+}
+
+func TestGitService_UpdateRepository_empty(t *testing.T) {
+	c, _, _, teardown := setup()
+	defer teardown()
+
+	for _, update := range []*azuredevops.GitRepository{nil, {}, {Name: azuredevops.String("")}} {
+		if _, _, err := c.Git.UpdateRepository(context.Background(), "o", "p", "1", update); err == nil {
+			t.Errorf("Git.UpdateRepository(%+v) returned no error", update)
+		}
+	}
+}
+
+func TestGitService_DeleteRepository(t *testing.T) {
+	c, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/o/p/_apis/git/repositories/1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "DELETE")
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	resp, err := c.Git.DeleteRepository(context.Background(), "o", "p", "1")
+	if err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+	if resp.StatusCode != http.StatusNoContent {
+		t.Errorf("Git.DeleteRepository returned status %d", resp.StatusCode)
+	}
+}
+
+func TestGitService_RecycleBin(t *testing.T) {
+	c, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/o/p/_apis/git/recycleBin/repositories", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"count": 1, "value": [{"id": "1", "name": "svc", "deletedBy": {"displayName": "Jane"}}]}`)
+	})
+	mux.HandleFunc("/o/p/_apis/git/recycleBin/repositories/1", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "PATCH":
+			testBody(t, r, `{"deleted":false}`+"\n")
+			fmt.Fprint(w, `{"id": "1", "name": "svc"}`)
+		case "DELETE":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("Unexpected method %s", r.Method)
+		}
+	})
+
+	ctx := context.Background()
+	deleted, _, err := c.Git.ListRecycleBinRepositories(ctx, "o", "p")
+	if err != nil {
+		t.Fatalf("ListRecycleBinRepositories returned error: %v", err)
+	}
+	if len(deleted) != 1 || deleted[0].GetDeletedBy().GetDisplayName() != "Jane" {
+		t.Errorf("Git.ListRecycleBinRepositories returned %+v", deleted)
+	}
+
+	restored, _, err := c.Git.RestoreRepository(ctx, "o", "p", "1")
+	if err != nil {
+		t.Fatalf("RestoreRepository returned error: %v", err)
+	}
+	if restored.GetName() != "svc" {
+		t.Errorf("Git.RestoreRepository returned %+v", restored)
+	}
+
+	if _, err := c.Git.PurgeRepository(ctx, "o", "p", "1"); err != nil {
+		t.Errorf("PurgeRepository returned error: %v", err)
+	}
+}
+
+func TestGitService_ListForks(t *testing.T) {
+	c, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/o/p/_apis/git/repositories/svc/forks/c1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"count": 1, "value": [{"id": "2", "name": "svc-fork", "isFork": true}]}`)
+	})
+
+	got, _, err := c.Git.ListForks(context.Background(), "o", "p", "svc", "c1")
+	if err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+	want := []*azuredevops.GitRepositoryRef{{
+		ID:     azuredevops.String("2"),
+		Name:   azuredevops.String("svc-fork"),
+		IsFork: azuredevops.Bool(true),
+	}}
+	if !cmp.Equal(got, want) {
+		t.Errorf("Git.ListForks returned %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,158 @@
+package azuredevops
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+	"time"
+)
+
+// GitStatusesListResponse describes the git statuses list response
+type GitStatusesListResponse struct {
+	Count    int          `json:"count"`
+	Statuses []*GitStatus `json:"value"`
+}
+
+// GitStatusListOptions describes the parameters of the git statuses list
+// API
+type GitStatusListOptions struct {
+	// LatestOnly returns only the latest status of each context.
+	LatestOnly bool `url:"latestOnly,omitempty"`
+	Skip       *int `url:"skip,omitempty"`
+	Top        *int `url:"top,omitempty"`
+}
+
+// ListStatuses returns the statuses of a commit, newest first
+// https://docs.microsoft.com/en-us/rest/api/azure/devops/git/statuses/list?view=azure-devops-rest-5.1
+func (s *GitService) ListStatuses(ctx context.Context, owner, project, repo, commitID string, opts *GitStatusListOptions) ([]*GitStatus, *Response, error) {
+	URL := fmt.Sprintf(
+		"%s/%s/_apis/git/repositories/%s/commits/%s/statuses?api-version=%s",
+		owner,
+		project,
+		repo,
+		url.QueryEscape(commitID),
+		s.client.apiVersion("git", "statuses"),
+	)
+	URL, err := addOptions(URL, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := s.client.NewRequest("GET", URL, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	r := new(GitStatusesListResponse)
+	resp, err := s.client.Execute(ctx, req, r)
+
+	return r.Statuses, resp, err
+}
+
+// ListStatusesPages walks every page of statuses of a commit, calling fn
+// with each page. Pages are requested with skip and top.
+func (s *GitService) ListStatusesPages(ctx context.Context, owner, project, repo, commitID string, opts *GitStatusListOptions, fn func([]*GitStatus, *Response) error) error {
+	o := GitStatusListOptions{}
+	if opts != nil {
+		o = *opts
+	}
+	return walkSkip(o.GetSkip(), o.GetTop(), func(skip, top int) (int, error) {
+		o.Skip, o.Top = Int(skip), Int(top)
+		statuses, resp, err := s.ListStatuses(ctx, owner, project, repo, commitID, &o)
+		if err != nil {
+			return 0, err
+		}
+		return len(statuses), fn(statuses, resp)
+	})
+}
+
+// ListAllStatuses returns every status of a commit, walking all pages of
+// results.
+func (s *GitService) ListAllStatuses(ctx context.Context, owner, project, repo, commitID string, opts *GitStatusListOptions) ([]*GitStatus, error) {
+	var all []*GitStatus
+	err := s.ListStatusesPages(ctx, owner, project, repo, commitID, opts, func(statuses []*GitStatus, _ *Response) error {
+		all = append(all, statuses...)
+		return nil
+	})
+	return all, err
+}
+
+// String returns the context in the form genre/name, or name if it has no
+// genre.
+func (c *GitStatusContext) String() string {
+	if c.GetGenre() == "" {
+		return c.GetName()
+	}
+	return c.GetGenre() + "/" + c.GetName()
+}
+
+// CollapseStatuses returns the state of the latest status of each context,
+// keyed by context in the form genre/name. Statuses are append-only, so
+// only the latest status of a context is meaningful.
+func CollapseStatuses(statuses []*GitStatus) map[string]GitStatusState {
+	latest := make(map[string]*GitStatus)
+	for _, st := range statuses {
+		key := st.GetContext().String()
+		if prev, ok := latest[key]; !ok || newerStatus(st, prev) {
+			latest[key] = st
+		}
+	}
+	states := make(map[string]GitStatusState, len(latest))
+	for key, st := range latest {
+		states[key] = statusState(st.GetState())
+	}
+	return states
+}
+
+// CollapsePullRequestStatuses is CollapseStatuses for the statuses of a
+// pull request.
+func CollapsePullRequestStatuses(statuses []*GitPullRequestStatus) map[string]GitStatusState {
+	s := make([]*GitStatus, len(statuses))
+	for i, st := range statuses {
+		s[i] = &st.GitStatus
+	}
+	return CollapseStatuses(s)
+}
+
+// RequiredStatusesPassed reports whether the latest status of every
+// required context, in the form genre/name, succeeded. It also returns the
+// required contexts which are missing or did not succeed, sorted.
+func RequiredStatusesPassed(states map[string]GitStatusState, required ...string) (bool, []string) {
+	var failing []string
+	for _, key := range required {
+		if states[key] != GitSucceeded {
+			failing = append(failing, key)
+		}
+	}
+	sort.Strings(failing)
+	return len(failing) == 0, failing
+}
+
+// newerStatus reports whether status a was posted after status b, by
+// update date, creation date and then ID.
+func newerStatus(a, b *GitStatus) bool {
+	if ad, bd := statusDate(a), statusDate(b); !ad.Equal(bd) {
+		return ad.After(bd)
+	}
+	return a.GetID() > b.GetID()
+}
+
+func statusDate(st *GitStatus) time.Time {
+	if d := st.GetUpdatedDate(); d != nil {
+		return d.Time
+	}
+	if d := st.GetCreationDate(); d != nil {
+		return d.Time
+	}
+	return time.Time{}
+}
+
+// statusState returns the GitStatusState named state, or GitNotSet.
+func statusState(state string) GitStatusState {
+	for s := GitNotSet; s <= GitNotApplicable; s++ {
+		if s.String() == state {
+			return s
+		}
+	}
+	return GitNotSet
+}
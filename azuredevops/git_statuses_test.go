@@ -0,0 +1,99 @@
+package azuredevops_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/mcdafydd/go-azuredevops/azuredevops"
+)
+
+func TestGitService_ListStatuses(t *testing.T) {
+	c, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/o/p/_apis/git/repositories/r/commits/abc/statuses", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{"latestOnly": "true"})
+		fmt.Fprint(w, `{"count": 1, "value": [{"id": 2, "state": "succeeded", "context": {"genre": "ci", "name": "build"}}]}`)
+	})
+
+	opts := &azuredevops.GitStatusListOptions{LatestOnly: true}
+	got, _, err := c.Git.ListStatuses(context.Background(), "o", "p", "r", "abc", opts)
+	if err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+	if len(got) != 1 || got[0].GetContext().String() != "ci/build" || got[0].GetState() != "succeeded" {
+		t.Errorf("Git.ListStatuses returned %+v", got)
+	}
+}
+
+func TestGitService_ListAllStatuses(t *testing.T) {
+	c, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/o/p/_apis/git/repositories/r/commits/abc/statuses", func(w http.ResponseWriter, r *http.Request) {
+		switch r.FormValue("skip") {
+		case "0":
+			fmt.Fprint(w, `{"count": 2, "value": [{"id": 3}, {"id": 2}]}`)
+		case "2":
+			fmt.Fprint(w, `{"count": 1, "value": [{"id": 1}]}`)
+		default:
+			t.Errorf("Unexpected skip %q", r.FormValue("skip"))
+		}
+	})
+
+	got, err := c.Git.ListAllStatuses(context.Background(), "o", "p", "r", "abc", &azuredevops.GitStatusListOptions{Top: azuredevops.Int(2)})
+	if err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+	if len(got) != 3 || got[2].GetID() != 1 {
+		t.Errorf("Git.ListAllStatuses returned %+v", got)
+	}
+}
+
+func TestCollapseStatuses(t *testing.T) {
+	at := func(minute int) *azuredevops.Time {
+		return &azuredevops.Time{Time: time.Date(2019, 10, 1, 12, minute, 0, 0, time.UTC)}
+	}
+	status := func(id int, genre, name, state string, date *azuredevops.Time) *azuredevops.GitStatus {
+		st := &azuredevops.GitStatus{
+			ID:           azuredevops.Int(id),
+			Context:      &azuredevops.GitStatusContext{Name: azuredevops.String(name)},
+			State:        azuredevops.String(state),
+			CreationDate: date,
+		}
+		if genre != "" {
+			st.Context.Genre = azuredevops.String(genre)
+		}
+		return st
+	}
+	statuses := []*azuredevops.GitStatus{
+		status(1, "ci", "build", "failed", at(0)),
+		status(3, "ci", "build", "succeeded", at(5)),
+		status(2, "ci", "test", "pending", at(1)),
+		status(4, "ci", "test", "error", at(1)),
+		status(5, "", "lint", "succeeded", at(2)),
+	}
+
+	got := azuredevops.CollapseStatuses(statuses)
+	want := map[string]azuredevops.GitStatusState{
+		"ci/build": azuredevops.GitSucceeded,
+		"ci/test":  azuredevops.GitError,
+		"lint":     azuredevops.GitSucceeded,
+	}
+	if !cmp.Equal(got, want) {
+		t.Errorf("CollapseStatuses returned %v, want %v", got, want)
+	}
+
+	if ok, failing := azuredevops.RequiredStatusesPassed(got, "ci/build", "lint"); !ok || len(failing) != 0 {
+		t.Errorf("RequiredStatusesPassed(build, lint) = %v, %v, want true", ok, failing)
+	}
+	ok, failing := azuredevops.RequiredStatusesPassed(got, "security/scan", "ci/test", "ci/build")
+	if want := []string{"ci/test", "security/scan"}; ok || !cmp.Equal(failing, want) {
+		t.Errorf("RequiredStatusesPassed returned %v, %v, want false, %v", ok, failing, want)
+	}
+}
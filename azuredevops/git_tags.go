@@ -0,0 +1,93 @@
+package azuredevops
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// GitAnnotatedTag describes an annotated tag object
+type GitAnnotatedTag struct {
+	Message *string `json:"message,omitempty"`
+	// Name is the name of the tag, without the refs/tags/ prefix.
+	Name         *string      `json:"name,omitempty"`
+	ObjectID     *string      `json:"objectId,omitempty"`
+	TaggedBy     *GitUserDate `json:"taggedBy,omitempty"`
+	TaggedObject *GitObject   `json:"taggedObject,omitempty"`
+	URL          *string      `json:"url,omitempty"`
+}
+
+// GitObject describes a git object
+type GitObject struct {
+	ObjectID *string `json:"objectId,omitempty"`
+	// ObjectType is a GitObjectType, such as commit.
+	ObjectType *string `json:"objectType,omitempty"`
+}
+
+// NewAnnotatedTag returns a tag of the object objectID, of type objectType,
+// to pass to CreateAnnotatedTag. Tags are usually of a Commit.
+func NewAnnotatedTag(name, message, objectID string, objectType GitObjectType) *GitAnnotatedTag {
+	return &GitAnnotatedTag{
+		Name:    String(strings.TrimPrefix(name, "refs/tags/")),
+		Message: String(message),
+		TaggedObject: &GitObject{
+			ObjectID:   String(objectID),
+			ObjectType: String(objectType.String()),
+		},
+	}
+}
+
+// CreateAnnotatedTag creates an annotated tag object and the ref
+// refs/tags/<name> pointing at it
+// https://docs.microsoft.com/en-us/rest/api/azure/devops/git/annotated%20tags/create?view=azure-devops-rest-5.1
+func (s *GitService) CreateAnnotatedTag(ctx context.Context, owner, project, repo string, tag *GitAnnotatedTag) (*GitAnnotatedTag, *Response, error) {
+	URL := fmt.Sprintf(
+		"%s/%s/_apis/git/repositories/%s/annotatedtags?api-version=%s",
+		owner,
+		project,
+		repo,
+		s.client.apiVersion("git", "annotatedTags"),
+	)
+
+	req, err := s.client.NewRequest("POST", URL, tag)
+	if err != nil {
+		return nil, nil, err
+	}
+	r := new(GitAnnotatedTag)
+	resp, err := s.client.Execute(ctx, req, r)
+
+	return r, resp, err
+}
+
+// GetAnnotatedTag returns an annotated tag object. The object ID of a tag
+// is the ObjectID of its ref, as returned by ListRefs.
+// https://docs.microsoft.com/en-us/rest/api/azure/devops/git/annotated%20tags/get?view=azure-devops-rest-5.1
+func (s *GitService) GetAnnotatedTag(ctx context.Context, owner, project, repo, objectID string) (*GitAnnotatedTag, *Response, error) {
+	URL := fmt.Sprintf(
+		"%s/%s/_apis/git/repositories/%s/annotatedtags/%s?api-version=%s",
+		owner,
+		project,
+		repo,
+		objectID,
+		s.client.apiVersion("git", "annotatedTags"),
+	)
+
+	req, err := s.client.NewRequest("GET", URL, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	r := new(GitAnnotatedTag)
+	resp, err := s.client.Execute(ctx, req, r)
+
+	return r, resp, err
+}
+
+// DeleteTag deletes the ref of a tag, lightweight or annotated, given by
+// name with or without the refs/tags/ prefix. The tag object of an
+// annotated tag is left for garbage collection. An error matching
+// ErrNotFound is returned if the tag does not exist, and a *RefUpdateError
+// if the service rejects the deletion.
+func (s *GitService) DeleteTag(ctx context.Context, owner, project, repo, name string) (*GitRefUpdateResult, *Response, error) {
+	name = "refs/tags/" + strings.TrimPrefix(name, "refs/tags/")
+	return s.forceUpdateRef(ctx, owner, project, repo, name, ZeroObjectID, true)
+}
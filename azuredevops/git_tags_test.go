@@ -0,0 +1,108 @@
+package azuredevops_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/mcdafydd/go-azuredevops/azuredevops"
+)
+
+func TestGitService_CreateAnnotatedTag(t *testing.T) {
+	c, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/o/p/_apis/git/repositories/r/annotatedtags", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testBody(t, r, `{"message":"Release 1.0","name":"v1.0","taggedObject":{"objectId":"abc","objectType":"commit"}}`+"\n")
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{
+			"name": "v1.0",
+			"objectId": "def",
+			"message": "Release 1.0",
+			"taggedObject": {"objectId": "abc", "objectType": "commit"},
+			"taggedBy": {"name": "Jane"}
+		}`)
+	})
+
+	tag := azuredevops.NewAnnotatedTag("refs/tags/v1.0", "Release 1.0", "abc", azuredevops.Commit)
+	got, _, err := c.Git.CreateAnnotatedTag(context.Background(), "o", "p", "r", tag)
+	if err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+	want := &azuredevops.GitAnnotatedTag{
+		Name:     azuredevops.String("v1.0"),
+		ObjectID: azuredevops.String("def"),
+		Message:  azuredevops.String("Release 1.0"),
+		TaggedObject: &azuredevops.GitObject{
+			ObjectID:   azuredevops.String("abc"),
+			ObjectType: azuredevops.String("commit"),
+		},
+		TaggedBy: &azuredevops.GitUserDate{Name: azuredevops.String("Jane")},
+	}
+	if !cmp.Equal(got, want) {
+		t.Errorf("Git.CreateAnnotatedTag returned %+v, want %+v", got, want)
+	}
+}
+
+func TestGitService_GetAnnotatedTag(t *testing.T) {
+	c, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/o/p/_apis/git/repositories/r/annotatedtags/def", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"name": "v1.0", "objectId": "def", "taggedObject": {"objectId": "abc", "objectType": "commit"}}`)
+	})
+
+	got, _, err := c.Git.GetAnnotatedTag(context.Background(), "o", "p", "r", "def")
+	if err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+	if got.GetName() != "v1.0" || got.GetTaggedObject().GetObjectType() != azuredevops.Commit.String() {
+		t.Errorf("Git.GetAnnotatedTag returned %+v", got)
+	}
+}
+
+func TestGitService_DeleteTag(t *testing.T) {
+	c, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/o/p/_apis/git/repositories/r/refs/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{"filter": "tags/v1.0"})
+		fmt.Fprint(w, `{"count": 1, "value": [{"name": "refs/tags/v1.0", "objectId": "def"}]}`)
+	})
+	mux.HandleFunc("/o/p/_apis/git/repositories/r/refs", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testBody(t, r, `[{"name":"refs/tags/v1.0","newObjectId":"0000000000000000000000000000000000000000","oldObjectId":"def"}]`+"\n")
+		fmt.Fprint(w, `{"count": 1, "value": [{"name": "refs/tags/v1.0", "success": true, "updateStatus": "succeeded"}]}`)
+	})
+
+	got, _, err := c.Git.DeleteTag(context.Background(), "o", "p", "r", "v1.0")
+	if err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+	if !got.GetSuccess() {
+		t.Errorf("Git.DeleteTag returned %+v", got)
+	}
+}
+
+func TestGitService_DeleteTag_notFound(t *testing.T) {
+	c, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/o/p/_apis/git/repositories/r/refs/", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"count": 1, "value": [{"name": "refs/tags/v1.0.1", "objectId": "abc"}]}`)
+	})
+	mux.HandleFunc("/o/p/_apis/git/repositories/r/refs", func(w http.ResponseWriter, r *http.Request) {
+		t.Error("Deleting a missing tag sent an update")
+	})
+
+	_, _, err := c.Git.DeleteTag(context.Background(), "o", "p", "r", "v1.0")
+	if !errors.Is(err, azuredevops.ErrNotFound) {
+		t.Errorf("Git.DeleteTag returned %v, want ErrNotFound", err)
+	}
+}
@@ -2,6 +2,7 @@ package azuredevops_test
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"testing"
@@ -260,3 +261,143 @@ func TestGitService_GetChanges(t *testing.T) {
 		t.Errorf("Git.GetChanges returned %+v, want %+v", got, want)
 	}
 }
+
+func TestGitService_UpdateRefs(t *testing.T) {
+	c, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/o/p/_apis/git/repositories/r/refs", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testBody(t, r, `[{"name":"refs/heads/new","newObjectId":"abc","oldObjectId":"0000000000000000000000000000000000000000"},{"name":"refs/heads/old","newObjectId":"0000000000000000000000000000000000000000","oldObjectId":"def"}]`+"\n")
+		fmt.Fprint(w, `{
+			"count": 2,
+			"value": [
+				{"name": "refs/heads/new", "oldObjectId": "0000000000000000000000000000000000000000", "newObjectId": "abc", "success": true, "updateStatus": "succeeded"},
+				{"name": "refs/heads/old", "oldObjectId": "def", "newObjectId": "0000000000000000000000000000000000000000", "success": false, "updateStatus": "staleOldObjectId", "customMessage": "ref has moved"}
+			]
+		}`)
+	})
+
+	results, _, err := c.Git.UpdateRefs(context.Background(), "o", "p", "r", []*azuredevops.GitRefUpdate{
+		{Name: azuredevops.String("refs/heads/new"), OldObjectID: azuredevops.String(azuredevops.ZeroObjectID), NewObjectID: azuredevops.String("abc")},
+		{Name: azuredevops.String("refs/heads/old"), OldObjectID: azuredevops.String("def"), NewObjectID: azuredevops.String(azuredevops.ZeroObjectID)},
+	})
+	if err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+
+	want := []*azuredevops.GitRefUpdateResult{
+		{
+			Name:         azuredevops.String("refs/heads/new"),
+			OldObjectID:  azuredevops.String(azuredevops.ZeroObjectID),
+			NewObjectID:  azuredevops.String("abc"),
+			Success:      azuredevops.Bool(true),
+			UpdateStatus: azuredevops.String(azuredevops.RefUpdateSucceeded.String()),
+		},
+		{
+			Name:          azuredevops.String("refs/heads/old"),
+			OldObjectID:   azuredevops.String("def"),
+			NewObjectID:   azuredevops.String(azuredevops.ZeroObjectID),
+			Success:       azuredevops.Bool(false),
+			UpdateStatus:  azuredevops.String(azuredevops.RefUpdateStaleOldObjectID.String()),
+			CustomMessage: azuredevops.String("ref has moved"),
+		},
+	}
+	if !cmp.Equal(results, want) {
+		t.Errorf("Git.UpdateRefs error: %s", cmp.Diff(results, want))
+	}
+}
+
+func TestGitService_ForceUpdateRef(t *testing.T) {
+	c, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/o/p/_apis/git/repositories/r/refs/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{"filter": "heads/master"})
+		fmt.Fprint(w, `{"count": 1, "value": [{"name": "refs/heads/master", "objectId": "current"}]}`)
+	})
+	mux.HandleFunc("/o/p/_apis/git/repositories/r/refs", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testBody(t, r, `[{"name":"refs/heads/master","newObjectId":"rewritten","oldObjectId":"current"}]`+"\n")
+		fmt.Fprint(w, `{"count": 1, "value": [{"name": "refs/heads/master", "success": true, "updateStatus": "succeeded"}]}`)
+	})
+
+	result, _, err := c.Git.ForceUpdateRef(context.Background(), "o", "p", "r", "master", "rewritten")
+	if err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+	if !result.GetSuccess() {
+		t.Errorf("Git.ForceUpdateRef returned %+v", result)
+	}
+}
+
+func TestGitService_ForceUpdateRef_rejected(t *testing.T) {
+	tests := []struct {
+		name     string
+		response string
+		want     error
+	}{
+		{"empty", `{"count": 0, "value": []}`, nil},
+		{"stale", `{"count": 1, "value": [{"name": "refs/heads/master", "success": false, "updateStatus": "staleOldObjectId"}]}`, azuredevops.ErrConflict},
+		{"force", `{"count": 1, "value": [{"name": "refs/heads/master", "success": false, "updateStatus": "forcePushRequired"}]}`, azuredevops.ErrUnauthorized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, mux, _, teardown := setup()
+			defer teardown()
+
+			mux.HandleFunc("/o/p/_apis/git/repositories/r/refs/", func(w http.ResponseWriter, r *http.Request) {
+				fmt.Fprint(w, `{"count": 1, "value": [{"name": "refs/heads/master", "objectId": "current"}]}`)
+			})
+			mux.HandleFunc("/o/p/_apis/git/repositories/r/refs", func(w http.ResponseWriter, r *http.Request) {
+				fmt.Fprint(w, tt.response)
+			})
+
+			_, _, err := c.Git.ForceUpdateRef(context.Background(), "o", "p", "r", "master", "rewritten")
+			if err == nil {
+				t.Fatal("Expected error to be returned.")
+			}
+			if tt.want != nil && !errors.Is(err, tt.want) {
+				t.Errorf("Git.ForceUpdateRef returned %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestGitService_LockBranch(t *testing.T) {
+	tt := []struct {
+		name   string
+		lock   bool
+		branch string
+	}{
+		{name: "lock short branch name", lock: true, branch: "release/1.0"},
+		{name: "unlock full ref name", lock: false, branch: "refs/heads/release/1.0"},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			c, mux, _, teardown := setup()
+			defer teardown()
+
+			mux.HandleFunc("/o/p/_apis/git/repositories/r/refs", func(w http.ResponseWriter, r *http.Request) {
+				testMethod(t, r, "PATCH")
+				testFormValues(t, r, values{"filter": "heads/release/1.0"})
+				testBody(t, r, fmt.Sprintf(`{"isLocked":%v}`+"\n", tc.lock))
+				fmt.Fprintf(w, `{"name": "refs/heads/release/1.0", "isLocked": %v}`, tc.lock)
+			})
+
+			lockFn := c.Git.UnlockBranch
+			if tc.lock {
+				lockFn = c.Git.LockBranch
+			}
+			ref, _, err := lockFn(context.Background(), "o", "p", "r", tc.branch)
+			if err != nil {
+				t.Fatalf("returned error: %v", err)
+			}
+			if ref.GetIsLocked() != tc.lock {
+				t.Errorf("IsLocked = %v, want %v", ref.GetIsLocked(), tc.lock)
+			}
+		})
+	}
+}
@@ -3,7 +3,6 @@ package azuredevops
 import (
 	"context"
 	"fmt"
-	"net/http"
 	"net/url"
 )
 
@@ -32,12 +31,13 @@ type Iteration struct {
 
 // List returns list of the iterations available to the user
 // utilising https://docs.microsoft.com/en-gb/rest/api/vsts/work/iterations/list
-func (s *IterationsService) List(ctx context.Context, owner, project, team string) ([]*Iteration, *http.Response, error) {
+func (s *IterationsService) List(ctx context.Context, owner, project, team string) ([]*Iteration, *Response, error) {
 	URL := fmt.Sprintf(
-		"%s/%s/%s/_apis/work/teamsettings/iterations?api-version=5.1-preview.1",
+		"%s/%s/%s/_apis/work/teamsettings/iterations?api-version=%s",
 		owner,
 		project,
 		url.PathEscape(team),
+		s.client.apiVersion("work", "iterations"),
 	)
 
 	request, err := s.client.NewRequest("GET", URL, nil)
@@ -52,7 +52,7 @@ func (s *IterationsService) List(ctx context.Context, owner, project, team strin
 
 // GetByName will search the iterations for the account and project
 // and return a single iteration if the names match
-func (s *IterationsService) GetByName(ctx context.Context, owner, project, team string, name string) (*Iteration, *http.Response, error) {
+func (s *IterationsService) GetByName(ctx context.Context, owner, project, team string, name string) (*Iteration, *Response, error) {
 	iterations, resp, err := s.List(ctx, owner, project, team)
 	if err != nil {
 		return nil, nil, err
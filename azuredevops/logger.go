@@ -0,0 +1,174 @@
+package azuredevops
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+// maxLogBodySize is the number of bytes of a body included in a LogEntry.
+const maxLogBodySize = 64 * 1024
+
+// LogLevel is the severity of a LogEntry, and the most verbose level a Client
+// logs.
+type LogLevel int
+
+// LogLevel enum values
+const (
+	LogNone  LogLevel = iota // nothing is logged
+	LogError                 // requests which failed with a network error or 5xx status
+	LogWarn                  // requests which returned any other error status
+	LogInfo                  // every request, with its status and latency
+	LogDebug                 // as LogInfo, with headers and optionally bodies
+)
+
+func (l LogLevel) String() string {
+	switch l {
+	case LogNone:
+		return "none"
+	case LogError:
+		return "error"
+	case LogWarn:
+		return "warn"
+	case LogInfo:
+		return "info"
+	case LogDebug:
+		return "debug"
+	}
+	return fmt.Sprintf("LogLevel(%d)", int(l))
+}
+
+// LogEntry describes a single attempt at sending a request. Credentials are
+// redacted from URL, RequestHeader and ResponseHeader.
+type LogEntry struct {
+	Level      LogLevel
+	Method     string
+	URL        string
+	Attempt    int // 1 for the first attempt, incremented by each retry
+	StatusCode int // zero if no response was received
+	Latency    time.Duration
+	Err        error // network error, if any
+
+	// Headers are only set at LogDebug, and bodies only if the client's
+	// LogBodies is set. Bodies are truncated to 64KiB.
+	RequestHeader  http.Header
+	ResponseHeader http.Header
+	RequestBody    []byte
+	ResponseBody   []byte
+}
+
+// Logger receives log entries from a Client.
+type Logger interface {
+	Log(entry *LogEntry)
+}
+
+// LoggerFunc adapts a function to the Logger interface.
+type LoggerFunc func(entry *LogEntry)
+
+// Log calls f(entry).
+func (f LoggerFunc) Log(entry *LogEntry) {
+	f(entry)
+}
+
+// NewStdLogger returns a Logger which writes entries as text to l, or to the
+// standard logger if l is nil.
+func NewStdLogger(l *log.Logger) Logger {
+	return LoggerFunc(func(e *LogEntry) {
+		var b strings.Builder
+		fmt.Fprintf(&b, "azuredevops: %s: %s %s attempt %d: ", e.Level, e.Method, e.URL, e.Attempt)
+		if e.Err != nil {
+			fmt.Fprintf(&b, "%v", e.Err)
+		} else {
+			fmt.Fprintf(&b, "%d %s", e.StatusCode, http.StatusText(e.StatusCode))
+		}
+		fmt.Fprintf(&b, " (%v)", e.Latency)
+		writeLogHeader(&b, "> ", e.RequestHeader)
+		writeLogBody(&b, "> ", e.RequestBody)
+		writeLogHeader(&b, "< ", e.ResponseHeader)
+		writeLogBody(&b, "< ", e.ResponseBody)
+		if l == nil {
+			log.Print(b.String())
+			return
+		}
+		l.Print(b.String())
+	})
+}
+
+func writeLogHeader(b *strings.Builder, prefix string, h http.Header) {
+	keys := make([]string, 0, len(h))
+	for k := range h {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		fmt.Fprintf(b, "\n%s%s: %s", prefix, k, strings.Join(h[k], ", "))
+	}
+}
+
+func writeLogBody(b *strings.Builder, prefix string, body []byte) {
+	if len(body) > 0 {
+		fmt.Fprintf(b, "\n%s%s", prefix, body)
+	}
+}
+
+// logAttempt logs an attempt at sending req. The response body is read for
+// logging only up to maxLogBodySize, and remains readable by the caller.
+func (c *Client) logAttempt(req *http.Request, resp *http.Response, err error, attempt int, latency time.Duration) {
+	if c.Logger == nil {
+		return
+	}
+	level := LogInfo
+	switch {
+	case err != nil || resp.StatusCode >= 500:
+		level = LogError
+	case resp.StatusCode >= 400:
+		level = LogWarn
+	}
+	if level > c.LogLevel {
+		return
+	}
+
+	u := *req.URL
+	entry := &LogEntry{
+		Level:   level,
+		Method:  req.Method,
+		URL:     sanitizeURL(&u).String(),
+		Attempt: attempt,
+		Latency: latency,
+		Err:     sanitizeError(err),
+	}
+	if resp != nil {
+		entry.StatusCode = resp.StatusCode
+	}
+	if c.LogLevel >= LogDebug {
+		entry.RequestHeader = RedactHeader(req.Header)
+		if resp != nil {
+			entry.ResponseHeader = RedactHeader(resp.Header)
+		}
+		if c.LogBodies {
+			if req.GetBody != nil {
+				if body, err := req.GetBody(); err == nil {
+					entry.RequestBody, _ = ioutil.ReadAll(io.LimitReader(body, maxLogBodySize))
+					body.Close()
+				}
+			}
+			if resp != nil {
+				entry.ResponseBody, _ = ioutil.ReadAll(io.LimitReader(resp.Body, maxLogBodySize))
+				resp.Body = &readCloser{io.MultiReader(bytes.NewReader(entry.ResponseBody), resp.Body), resp.Body}
+			}
+		}
+	}
+	c.Logger.Log(entry)
+}
+
+// readCloser combines a Reader with the Closer of the body it wraps.
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
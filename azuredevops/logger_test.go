@@ -0,0 +1,135 @@
+package azuredevops_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/mcdafydd/go-azuredevops/azuredevops"
+)
+
+func TestClient_Logger(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/o/p/_apis/build/builds", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Set-Cookie", "session=secret")
+		fmt.Fprint(w, `{"count":1,"value":[{"id":1}]}`)
+	})
+
+	var entries []*azuredevops.LogEntry
+	client.Logger = azuredevops.LoggerFunc(func(e *azuredevops.LogEntry) {
+		entries = append(entries, e)
+	})
+	client.LogLevel = azuredevops.LogDebug
+	client.LogBodies = true
+
+	u, _ := url.Parse(strings.Replace(client.BaseURL.String(), "://", "://:mypat@", 1))
+	client.BaseURL = *u
+
+	req, _ := client.NewRequest("GET", "o/p/_apis/build/builds?client_secret=s3cret", nil)
+	req.Header.Set("Authorization", "Basic bXlwYXQ=")
+	builds := new(azuredevops.BuildsListResponse)
+	if _, err := client.Execute(context.Background(), req, builds); err != nil {
+		t.Fatalf("Execute returned error: %v", err)
+	}
+	if len(builds.Builds) != 1 {
+		t.Errorf("Decoded %d builds after logging the body, want 1", len(builds.Builds))
+	}
+
+	if len(entries) != 1 {
+		t.Fatalf("Logged %d entries, want 1", len(entries))
+	}
+	e := entries[0]
+	if e.Level != azuredevops.LogInfo || e.StatusCode != http.StatusOK || e.Attempt != 1 {
+		t.Errorf("Entry = %+v, want info level 200 on attempt 1", e)
+	}
+	if strings.Contains(e.URL, "mypat") || strings.Contains(e.URL, "s3cret") {
+		t.Errorf("URL %q was not redacted", e.URL)
+	}
+	if got := e.RequestHeader.Get("Authorization"); got != "REDACTED" {
+		t.Errorf("Authorization header logged as %q", got)
+	}
+	if got := e.ResponseHeader.Get("Set-Cookie"); got != "REDACTED" {
+		t.Errorf("Set-Cookie header logged as %q", got)
+	}
+	if !strings.Contains(string(e.ResponseBody), `"count":1`) {
+		t.Errorf("ResponseBody = %q", e.ResponseBody)
+	}
+}
+
+func TestClient_LoggerRedactsTransportError(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/o/p/_apis/build/builds", func(w http.ResponseWriter, r *http.Request) {
+		conn, _, err := w.(http.Hijacker).Hijack()
+		if err != nil {
+			t.Fatalf("Hijack returned error: %v", err)
+		}
+		conn.Close()
+	})
+
+	var buf bytes.Buffer
+	client.Logger = azuredevops.NewStdLogger(log.New(&buf, "", 0))
+	client.LogLevel = azuredevops.LogError
+
+	req, _ := client.NewRequest("GET", "o/p/_apis/build/builds?access_token=s3cret", nil)
+	if _, err := client.Execute(context.Background(), req, nil); err == nil {
+		t.Fatal("Expected error to be returned.")
+	}
+	if buf.Len() == 0 {
+		t.Fatal("Logged nothing for the failed request")
+	}
+	if strings.Contains(buf.String(), "s3cret") {
+		t.Errorf("Logged %q, want the access token redacted from the error", buf.String())
+	}
+}
+
+func TestClient_LoggerLevel(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/o/p/_apis/build/builds", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	var buf bytes.Buffer
+	client.Logger = azuredevops.NewStdLogger(log.New(&buf, "", 0))
+	client.LogLevel = azuredevops.LogError
+	client.Builds.List(context.Background(), "o", "p", nil)
+	if buf.Len() != 0 {
+		t.Errorf("Logged %q at LogError, want nothing", buf.String())
+	}
+
+	client.LogLevel = azuredevops.LogWarn
+	client.Builds.List(context.Background(), "o", "p", nil)
+	if !strings.HasPrefix(buf.String(), "azuredevops: warn: GET ") || !strings.Contains(buf.String(), "404 Not Found") {
+		t.Errorf("Logged %q, want a warning for the 404", buf.String())
+	}
+	if strings.Contains(buf.String(), "\n> ") {
+		t.Errorf("Logged headers below LogDebug: %q", buf.String())
+	}
+}
+
+func TestLogLevel_String(t *testing.T) {
+	tests := []struct {
+		level azuredevops.LogLevel
+		want  string
+	}{
+		{azuredevops.LogNone, "none"},
+		{azuredevops.LogDebug, "debug"},
+		{azuredevops.LogLevel(-1), "LogLevel(-1)"},
+		{azuredevops.LogDebug + 1, "LogLevel(5)"},
+	}
+	for _, tt := range tests {
+		if got := tt.level.String(); got != tt.want {
+			t.Errorf("LogLevel(%d).String() = %q, want %q", int(tt.level), got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package azuredevops
+
+import (
+	"context"
+	"net/http"
+)
+
+// Middleware hooks into every request executed by a Client. Either hook may
+// be nil.
+type Middleware struct {
+	// BeforeRequest is called before req is sent, and may modify it, for
+	// example to add headers. If it returns an error the request is not sent,
+	// and Execute returns the error.
+	BeforeRequest func(ctx context.Context, req *http.Request) error
+
+	// AfterResponse is called once the response has been decoded, with the
+	// error Execute would return, such as an *ErrorResponse. resp is nil if
+	// no response was received. The returned error replaces err, so returning
+	// err unchanged leaves the result as it was.
+	AfterResponse func(ctx context.Context, req *http.Request, resp *Response, err error) error
+}
+
+// Use appends middleware to the client's chain. BeforeRequest hooks run in the
+// order middleware was added, and AfterResponse hooks in the reverse order,
+// so the first middleware added sees the final result. Retries happen inside
+// the chain, so each hook runs once per call to Execute. Use is not safe to
+// call concurrently with requests.
+func (c *Client) Use(middleware ...Middleware) {
+	c.middleware = append(c.middleware, middleware...)
+}
+
+// afterResponse runs the AfterResponse hooks of the first n middleware in
+// reverse order, returning the resulting error.
+func (c *Client) afterResponse(ctx context.Context, req *http.Request, resp *Response, err error, n int) error {
+	for i := n - 1; i >= 0; i-- {
+		if m := c.middleware[i]; m.AfterResponse != nil {
+			err = m.AfterResponse(ctx, req, resp, err)
+		}
+	}
+	return err
+}
+
+// SetHeader returns Middleware which sets the header key to value on every
+// request, for example X-TFS-FedAuthRedirect: Suppress.
+func SetHeader(key, value string) Middleware {
+	return Middleware{
+		BeforeRequest: func(ctx context.Context, req *http.Request) error {
+			req.Header.Set(key, value)
+			return nil
+		},
+	}
+}
@@ -0,0 +1,109 @@
+package azuredevops_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/mcdafydd/go-azuredevops/azuredevops"
+)
+
+func TestClient_Use(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/o/p/_apis/build/builds", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("X-Correlation-Id"); got != "abc" {
+			t.Errorf("X-Correlation-Id = %q, want abc", got)
+		}
+		if got := r.Header.Get("X-TFS-FedAuthRedirect"); got != "Suppress" {
+			t.Errorf("X-TFS-FedAuthRedirect = %q, want Suppress", got)
+		}
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"typeKey":"ProjectDoesNotExistWithNameException","message":"not found"}`)
+	})
+
+	var order []string
+	hook := func(name string) azuredevops.Middleware {
+		return azuredevops.Middleware{
+			BeforeRequest: func(ctx context.Context, req *http.Request) error {
+				order = append(order, "before "+name)
+				return nil
+			},
+			AfterResponse: func(ctx context.Context, req *http.Request, resp *azuredevops.Response, err error) error {
+				order = append(order, "after "+name)
+				if resp == nil || resp.StatusCode != http.StatusNotFound {
+					t.Errorf("%s: AfterResponse got response %v, want 404", name, resp)
+				}
+				if !errors.Is(err, azuredevops.ErrNotFound) {
+					t.Errorf("%s: AfterResponse got error %v, want ErrNotFound", name, err)
+				}
+				return err
+			},
+		}
+	}
+	client.Use(
+		azuredevops.SetHeader("X-TFS-FedAuthRedirect", "Suppress"),
+		hook("first"),
+		azuredevops.Middleware{
+			BeforeRequest: func(ctx context.Context, req *http.Request) error {
+				req.Header.Set("X-Correlation-Id", "abc")
+				return nil
+			},
+		},
+		hook("second"),
+	)
+
+	_, _, err := client.Builds.List(context.Background(), "o", "p", nil)
+	if !errors.Is(err, azuredevops.ErrNotFound) {
+		t.Errorf("Builds.List returned error %v, want ErrNotFound", err)
+	}
+
+	want := []string{"before first", "before second", "after second", "after first"}
+	if !reflect.DeepEqual(order, want) {
+		t.Errorf("Hooks ran in order %v, want %v", order, want)
+	}
+}
+
+func TestClient_Use_beforeRequestError(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/o/p/_apis/build/builds", func(w http.ResponseWriter, r *http.Request) {
+		t.Error("Request should not have been sent")
+	})
+
+	abort := errors.New("abort")
+	var afterCalls int
+	client.Use(
+		azuredevops.Middleware{
+			AfterResponse: func(ctx context.Context, req *http.Request, resp *azuredevops.Response, err error) error {
+				afterCalls++
+				if err != abort {
+					t.Errorf("AfterResponse got error %v, want abort", err)
+				}
+				return err
+			},
+		},
+		azuredevops.Middleware{
+			BeforeRequest: func(ctx context.Context, req *http.Request) error {
+				return abort
+			},
+			AfterResponse: func(ctx context.Context, req *http.Request, resp *azuredevops.Response, err error) error {
+				t.Error("AfterResponse of the failing middleware should not run")
+				return err
+			},
+		},
+	)
+
+	_, _, err := client.Builds.List(context.Background(), "o", "p", nil)
+	if err != abort {
+		t.Errorf("Builds.List returned error %v, want abort", err)
+	}
+	if afterCalls != 1 {
+		t.Errorf("AfterResponse called %d times, want 1", afterCalls)
+	}
+}
@@ -0,0 +1,68 @@
+package azuredevops
+
+import (
+	"reflect"
+)
+
+// DefaultPageSize is the $top value used when walking the pages of an
+// endpoint paged with $top and $skip, if the caller did not set one.
+const DefaultPageSize = 100
+
+// continuationTokenHeader is the response header carrying the token which
+// requests the next page of results.
+const continuationTokenHeader = "X-Ms-Continuationtoken"
+
+// walkContinuation fetches pages with page, starting at token, until the
+// service stops returning a continuation token.
+func walkContinuation(token string, page func(token string) (*Response, error)) error {
+	for {
+		resp, err := page(token)
+		if err != nil {
+			return err
+		}
+		if resp == nil || resp.ContinuationToken == "" || resp.ContinuationToken == token {
+			return nil
+		}
+		token = resp.ContinuationToken
+	}
+}
+
+// walkSkip fetches pages of up to top items with page, starting at skip,
+// until a page returns fewer than top items. page returns the number of items
+// it received.
+func walkSkip(skip, top int, page func(skip, top int) (int, error)) error {
+	if top <= 0 {
+		top = DefaultPageSize
+	}
+	for {
+		n, err := page(skip, top)
+		if err != nil {
+			return err
+		}
+		if n == 0 || n < top {
+			return nil
+		}
+		skip += n
+	}
+}
+
+// listCount returns the number of items reported by a list response body.
+// By convention list responses decode the "count" property into an int field
+// named Count.
+func listCount(v interface{}) int {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return 0
+	}
+	f := rv.FieldByName("Count")
+	if f.Kind() == reflect.Ptr && !f.IsNil() {
+		f = f.Elem()
+	}
+	if f.Kind() == reflect.Int {
+		return int(f.Int())
+	}
+	return 0
+}
@@ -3,7 +3,6 @@ package azuredevops
 import (
 	"context"
 	"fmt"
-	"net/http"
 )
 
 // PolicyEvaluationsService handles communication with the evaluations methods on the API
@@ -13,7 +12,10 @@ type PolicyEvaluationsService struct {
 }
 
 // PolicyEvaluationsListOptions describes what the request to the API should look like
-type PolicyEvaluationsListOptions struct{}
+type PolicyEvaluationsListOptions struct {
+	Top  *int `url:"$top,omitempty"`
+	Skip *int `url:"$skip,omitempty"`
+}
 
 // PolicyEvaluationsListResponse describes a pull requests list response
 type PolicyEvaluationsListResponse struct {
@@ -83,11 +85,12 @@ func (s *PolicyEvaluationsService) GetPullRequestArtifactID(projectID string, pu
 
 // List retrieves a list of all the policy evaluation statuses for a specific pull request.
 // https://docs.microsoft.com/en-us/rest/api/azure/devops/policy/evaluations/list?view=azure-devops-rest-5.1
-func (s *PolicyEvaluationsService) List(ctx context.Context, owner, project, artifactID string, opts *PolicyEvaluationsListOptions) ([]*PolicyEvaluationRecord, *http.Response, error) {
-	URL := fmt.Sprintf("%s/%s/_apis/policy/evaluations?artifactId=%s&api-version=5.1-preview",
+func (s *PolicyEvaluationsService) List(ctx context.Context, owner, project, artifactID string, opts *PolicyEvaluationsListOptions) ([]*PolicyEvaluationRecord, *Response, error) {
+	URL := fmt.Sprintf("%s/%s/_apis/policy/evaluations?artifactId=%s&api-version=%s",
 		owner,
 		project,
 		artifactID,
+		s.client.apiVersion("policy", "evaluations"),
 	)
 	URL, err := addOptions(URL, opts)
 
@@ -104,3 +107,32 @@ func (s *PolicyEvaluationsService) List(ctx context.Context, owner, project, art
 
 	return r.PolicyEvaluations, resp, err
 }
+
+// ListPages walks every page of policy evaluations for a pull request, calling
+// fn with each page. Pages are requested with $top and $skip, starting at
+// opts.Skip.
+func (s *PolicyEvaluationsService) ListPages(ctx context.Context, owner, project, artifactID string, opts *PolicyEvaluationsListOptions, fn func([]*PolicyEvaluationRecord, *Response) error) error {
+	o := PolicyEvaluationsListOptions{}
+	if opts != nil {
+		o = *opts
+	}
+	return walkSkip(o.GetSkip(), o.GetTop(), func(skip, top int) (int, error) {
+		o.Skip, o.Top = Int(skip), Int(top)
+		evaluations, resp, err := s.List(ctx, owner, project, artifactID, &o)
+		if err != nil {
+			return 0, err
+		}
+		return len(evaluations), fn(evaluations, resp)
+	})
+}
+
+// ListAll returns every policy evaluation for a pull request, walking all
+// pages of results.
+func (s *PolicyEvaluationsService) ListAll(ctx context.Context, owner, project, artifactID string, opts *PolicyEvaluationsListOptions) ([]*PolicyEvaluationRecord, error) {
+	var all []*PolicyEvaluationRecord
+	err := s.ListPages(ctx, owner, project, artifactID, opts, func(evaluations []*PolicyEvaluationRecord, _ *Response) error {
+		all = append(all, evaluations...)
+		return nil
+	})
+	return all, err
+}
@@ -0,0 +1,32 @@
+package azuredevops
+
+import (
+	"context"
+	"time"
+)
+
+// DefaultPollInterval is the interval between status requests of the Wait
+// helpers for long-running operations when none is given.
+const DefaultPollInterval = 5 * time.Second
+
+// poll calls check every interval until it reports done or returns an
+// error, or ctx is done.
+func poll(ctx context.Context, interval time.Duration, check func() (bool, error)) error {
+	if interval <= 0 {
+		interval = DefaultPollInterval
+	}
+	for {
+		done, err := check()
+		if done || err != nil {
+			return err
+		}
+
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
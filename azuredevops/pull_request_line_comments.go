@@ -0,0 +1,258 @@
+package azuredevops
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// DiffSide enum declaration
+type DiffSide int
+
+// DiffSide enum values. The right side of a pull request diff is the source
+// branch, the left side is the target branch.
+const (
+	RightSide DiffSide = iota
+	LeftSide
+)
+
+func (d DiffSide) String() string {
+	return [...]string{"right", "left"}[d]
+}
+
+// LineCommentKeyProperty is the thread property in which CreateLineComments
+// stores the key of a line comment, to recognise threads it created.
+const LineCommentKeyProperty = "LineCommentKey"
+
+// LineComment describes a review comment anchored to a range of lines of a
+// file in a pull request.
+type LineComment struct {
+	// Path is the path of the file in the repository, such as "/src/main.go".
+	Path string
+	// StartLine and EndLine are the 1-based range of lines commented on.
+	// EndLine defaults to StartLine.
+	StartLine int
+	EndLine   int
+	// StartOffset and EndOffset are the 1-based character offsets in the
+	// first and last lines. Both default to 1, which anchors the comment to
+	// the whole lines.
+	StartOffset int
+	EndOffset   int
+	Side        DiffSide
+	// Iteration is the pull request iteration the lines refer to. Zero
+	// means the latest iteration.
+	Iteration int
+	// BaseIteration is the iteration Iteration is compared with. Zero means
+	// the pull request target branch.
+	BaseIteration int
+	Content       string
+	// Status is the status of the new thread. The zero value, StatusUnknown,
+	// creates an active thread.
+	Status CommentThreadStatus
+	// Key identifies the comment, so that it is not posted twice. When
+	// empty a key is derived from the path, side, lines and content.
+	Key string
+}
+
+// key returns the deduplication key of the comment.
+func (l *LineComment) key() string {
+	if l.Key != "" {
+		return l.Key
+	}
+	sum := sha1.Sum([]byte(fmt.Sprintf("%s\x00%s\x00%d\x00%d\x00%s",
+		l.normalizedPath(), l.Side, l.StartLine, l.endLine(), l.Content)))
+	return hex.EncodeToString(sum[:])
+}
+
+func (l *LineComment) normalizedPath() string {
+	if strings.HasPrefix(l.Path, "/") {
+		return l.Path
+	}
+	return "/" + l.Path
+}
+
+func (l *LineComment) endLine() int {
+	if l.EndLine < l.StartLine {
+		return l.StartLine
+	}
+	return l.EndLine
+}
+
+func offsetOrDefault(offset int) int {
+	if offset < 1 {
+		return 1
+	}
+	return offset
+}
+
+// Thread returns a new comment thread for the comment, on the given
+// iteration of the pull request. changeTrackingID is the change tracking ID
+// of the file in that iteration, or zero if it is not known.
+func (l *LineComment) Thread(iteration, changeTrackingID int) *GitPullRequestCommentThread {
+	start := &CommentPosition{Line: Int(l.StartLine), Offset: Int(offsetOrDefault(l.StartOffset))}
+	end := &CommentPosition{Line: Int(l.endLine()), Offset: Int(offsetOrDefault(l.EndOffset))}
+	tc := &GitPullRequestCommentThreadContext{FilePath: String(l.normalizedPath())}
+	if l.Side == LeftSide {
+		tc.LeftFileStart, tc.LeftFileEnd = start, end
+	} else {
+		tc.RightFileStart, tc.RightFileEnd = start, end
+	}
+
+	first := l.BaseIteration
+	if first < 1 {
+		first = 1
+	}
+	prc := &GitPullRequestThreadContext{
+		IterationContext: &CommentIterationContext{
+			FirstComparingIteration:  Int(first),
+			SecondComparingIteration: Int(iteration),
+		},
+	}
+	if changeTrackingID != 0 {
+		prc.ChangeTrackingID = Int(changeTrackingID)
+	}
+
+	status := l.Status
+	if status == StatusUnknown {
+		status = StatusActive
+	}
+	return &GitPullRequestCommentThread{
+		Comments: []*Comment{{
+			CommentType: String("text"),
+			Content:     String(l.Content),
+		}},
+		Properties:         map[string]interface{}{LineCommentKeyProperty: l.key()},
+		Status:             String(status.String()),
+		ThreadContext:      tc,
+		PullRequestContext: prc,
+	}
+}
+
+// threadProperty returns the string value of a thread property. Properties
+// read back from the service are wrapped in a {"$type", "$value"} object.
+func threadProperty(t *GitPullRequestCommentThread, name string) string {
+	switch v := t.Properties[name].(type) {
+	case string:
+		return v
+	case map[string]interface{}:
+		if s, ok := v["$value"].(string); ok {
+			return s
+		}
+	}
+	return ""
+}
+
+// CreateLineComments creates a comment thread anchored to the lines of
+// each comment, skipping comments for which a thread with the same key
+// already exists. The created threads are returned.
+//
+// Threads are anchored to the requested iteration, with the change tracking
+// ID of the file, so that the service moves them with the lines as new
+// changes are pushed.
+func (s *PullRequestsService) CreateLineComments(ctx context.Context, owner, project, repo string, pullNum int, comments []*LineComment) ([]*GitPullRequestCommentThread, *Response, error) {
+	for _, l := range comments {
+		if l.Path == "" || l.StartLine < 1 {
+			return nil, nil, errors.New("PullRequests.CreateLineComments: Path and StartLine are required")
+		}
+		if l.Content == "" {
+			return nil, nil, errors.New("PullRequests.CreateLineComments: Empty string in Content field")
+		}
+	}
+
+	existing, resp, err := s.ListThreads(ctx, owner, project, repo, pullNum, nil)
+	if err != nil {
+		return nil, resp, err
+	}
+	seen := make(map[string]bool)
+	for _, t := range existing {
+		if key := threadProperty(t, LineCommentKeyProperty); key != "" && !t.GetIsDeleted() {
+			seen[key] = true
+		}
+	}
+
+	latest := 0
+	trackingIDs := make(map[[2]int]map[string]int)
+	var created []*GitPullRequestCommentThread
+	for _, l := range comments {
+		key := l.key()
+		if seen[key] {
+			continue
+		}
+
+		iteration := l.Iteration
+		if iteration == 0 {
+			if latest == 0 {
+				if latest, resp, err = s.latestIteration(ctx, owner, project, repo, pullNum); err != nil {
+					return created, resp, err
+				}
+			}
+			iteration = latest
+		}
+		pair := [2]int{iteration, l.BaseIteration}
+		ids, ok := trackingIDs[pair]
+		if !ok {
+			if ids, resp, err = s.changeTrackingIDs(ctx, owner, project, repo, pullNum, iteration, l.BaseIteration); err != nil {
+				return created, resp, err
+			}
+			trackingIDs[pair] = ids
+		}
+
+		thread := l.Thread(iteration, ids[l.normalizedPath()])
+		t, r, err := s.CreateComments(ctx, owner, project, repo, pullNum, thread)
+		if r != nil {
+			resp = r
+		}
+		if err != nil {
+			return created, resp, err
+		}
+		seen[key] = true
+		created = append(created, t)
+	}
+	return created, resp, nil
+}
+
+// latestIteration returns the ID of the newest iteration of a pull request.
+func (s *PullRequestsService) latestIteration(ctx context.Context, owner, project, repo string, pullNum int) (int, *Response, error) {
+	iterations, resp, err := s.ListIterations(ctx, owner, project, repo, pullNum, nil)
+	if err != nil {
+		return 0, resp, err
+	}
+	latest := 0
+	for _, it := range iterations {
+		if it.GetID() > latest {
+			latest = it.GetID()
+		}
+	}
+	if latest == 0 {
+		return 0, resp, fmt.Errorf("PullRequests: pull request %d has no iterations", pullNum)
+	}
+	return latest, resp, nil
+}
+
+// changeTrackingIDs maps the paths changed in an iteration, compared with
+// base, to their change tracking IDs.
+func (s *PullRequestsService) changeTrackingIDs(ctx context.Context, owner, project, repo string, pullNum, iteration, base int) (map[string]int, *Response, error) {
+	ids := make(map[string]int)
+	opts := &PullRequestIterationChangesOptions{}
+	if base > 0 {
+		opts.CompareTo = Int(base)
+	}
+	for {
+		changes, resp, err := s.GetIterationChanges(ctx, owner, project, repo, pullNum, iteration, opts)
+		if err != nil {
+			return nil, resp, err
+		}
+		for _, c := range changes.ChangeEntries {
+			if path := c.GetItem().GetPath(); path != "" {
+				ids[path] = c.GetChangeTrackingID()
+			}
+		}
+		if changes.GetNextSkip() == 0 {
+			return ids, resp, nil
+		}
+		opts.Skip, opts.Top = changes.NextSkip, changes.NextTop
+	}
+}
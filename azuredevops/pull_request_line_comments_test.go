@@ -0,0 +1,100 @@
+package azuredevops_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/mcdafydd/go-azuredevops/azuredevops"
+)
+
+func TestLineComment_Thread(t *testing.T) {
+	l := &azuredevops.LineComment{
+		Path:      "src/main.go",
+		StartLine: 10,
+		EndLine:   12,
+		Side:      azuredevops.LeftSide,
+		Content:   "Unused variable",
+		Key:       "lint/unused/main.go:10",
+	}
+	got := l.Thread(3, 7)
+
+	want := &azuredevops.GitPullRequestCommentThread{
+		Comments: []*azuredevops.Comment{{
+			CommentType: String("text"),
+			Content:     String("Unused variable"),
+		}},
+		Properties: map[string]interface{}{azuredevops.LineCommentKeyProperty: "lint/unused/main.go:10"},
+		Status:     String("active"),
+		ThreadContext: &azuredevops.GitPullRequestCommentThreadContext{
+			FilePath:      String("/src/main.go"),
+			LeftFileStart: &azuredevops.CommentPosition{Line: Int(10), Offset: Int(1)},
+			LeftFileEnd:   &azuredevops.CommentPosition{Line: Int(12), Offset: Int(1)},
+		},
+		PullRequestContext: &azuredevops.GitPullRequestThreadContext{
+			ChangeTrackingID: Int(7),
+			IterationContext: &azuredevops.CommentIterationContext{
+				FirstComparingIteration:  Int(1),
+				SecondComparingIteration: Int(3),
+			},
+		},
+	}
+	if !cmp.Equal(got, want) {
+		t.Errorf("LineComment.Thread diff: %s", cmp.Diff(want, got))
+	}
+}
+
+func TestPullRequestsService_CreateLineComments(t *testing.T) {
+	c, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/o/p/_apis/git/repositories/r/pullrequests/1/threads", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			fmt.Fprint(w, `{"count": 2, "value": [
+				{"id": 1, "properties": {"LineCommentKey": {"$type": "System.String", "$value": "dup"}}},
+				{"id": 2, "isDeleted": true, "properties": {"LineCommentKey": {"$type": "System.String", "$value": "deleted"}}}
+			]}`)
+		case "POST":
+			testBody(t, r, `{"comments":[{"commentType":"text","content":"Line too long"}],`+
+				`"properties":{"LineCommentKey":"deleted"},"status":"active",`+
+				`"threadContext":{"filePath":"/README.md","rightFileEnd":{"line":4,"offset":1},"rightFileStart":{"line":4,"offset":1}},`+
+				`"pullRequestThreadContext":{"changeTrackingId":5,"iterationContext":{"firstComparingIteration":1,"secondComparingIteration":2}}}`+"\n")
+			fmt.Fprint(w, `{"id": 3}`)
+		default:
+			t.Errorf("Request method: %v", r.Method)
+		}
+	})
+	mux.HandleFunc("/o/p/_apis/git/repositories/r/pullrequests/1/iterations", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"count": 2, "value": [{"id": 1}, {"id": 2}]}`)
+	})
+	mux.HandleFunc("/o/p/_apis/git/repositories/r/pullrequests/1/iterations/2/changes", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"changeEntries": [{"changeTrackingId": 5, "item": {"path": "/README.md"}}]}`)
+	})
+
+	comments := []*azuredevops.LineComment{
+		{Path: "/main.go", StartLine: 1, Content: "Already posted", Key: "dup"},
+		{Path: "README.md", StartLine: 4, Content: "Line too long", Key: "deleted"},
+	}
+	got, _, err := c.PullRequests.CreateLineComments(context.Background(), "o", "p", "r", 1, comments)
+	if err != nil {
+		t.Fatalf("PullRequests.CreateLineComments returned error: %v", err)
+	}
+	if len(got) != 1 || got[0].GetID() != 3 {
+		t.Errorf("PullRequests.CreateLineComments returned %+v", got)
+	}
+}
+
+func TestPullRequestsService_CreateLineComments_invalid(t *testing.T) {
+	c, _, _, teardown := setup()
+	defer teardown()
+
+	comments := []*azuredevops.LineComment{{Path: "/main.go", Content: "No line"}}
+	if _, _, err := c.PullRequests.CreateLineComments(context.Background(), "o", "p", "r", 1, comments); err == nil {
+		t.Error("PullRequests.CreateLineComments without StartLine returned no error")
+	}
+}
@@ -0,0 +1,167 @@
+package azuredevops
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// PullRequestVote enum declaration
+type PullRequestVote int
+
+// PullRequestVote enum values
+const (
+	VoteReject                 PullRequestVote = -10
+	VoteWaitForAuthor          PullRequestVote = -5
+	VoteReset                  PullRequestVote = 0
+	VoteApproveWithSuggestions PullRequestVote = 5
+	VoteApprove                PullRequestVote = 10
+)
+
+func (v PullRequestVote) String() string {
+	switch v {
+	case VoteReject:
+		return "rejected"
+	case VoteWaitForAuthor:
+		return "waiting for author"
+	case VoteReset:
+		return "no vote"
+	case VoteApproveWithSuggestions:
+		return "approved with suggestions"
+	case VoteApprove:
+		return "approved"
+	}
+	return fmt.Sprintf("PullRequestVote(%d)", int(v))
+}
+
+// PullRequestVote returns the vote of the reviewer as a PullRequestVote
+func (i *IdentityRefWithVote) PullRequestVote() PullRequestVote {
+	return PullRequestVote(i.GetVote())
+}
+
+// IdentityRefWithVoteListResponse describes the pull request reviewers list
+// response
+type IdentityRefWithVoteListResponse struct {
+	Count     int                    `json:"count"`
+	Reviewers []*IdentityRefWithVote `json:"value"`
+}
+
+// RequiredReviewer returns a reviewer, identified by its identity ID, whose
+// approval is required to complete a pull request
+func RequiredReviewer(id string) *IdentityRefWithVote {
+	return &IdentityRefWithVote{IdentityRef: IdentityRef{ID: String(id)}, IsRequired: Bool(true)}
+}
+
+// OptionalReviewer returns a reviewer, identified by its identity ID, whose
+// approval is not required to complete a pull request
+func OptionalReviewer(id string) *IdentityRefWithVote {
+	return &IdentityRefWithVote{IdentityRef: IdentityRef{ID: String(id)}, IsRequired: Bool(false)}
+}
+
+func (s *PullRequestsService) reviewersURL(owner, project, repo string, pullNum int, reviewerID string) string {
+	URL := fmt.Sprintf("%s/%s/_apis/git/repositories/%s/pullrequests/%d/reviewers",
+		owner,
+		project,
+		repo,
+		pullNum,
+	)
+	if reviewerID != "" {
+		URL += "/" + reviewerID
+	}
+	return URL + "?api-version=" + s.client.apiVersion("git", "pullRequestReviewers")
+}
+
+// ListReviewers returns the reviewers of a pull request and their votes
+// Azure Devops API docs: https://docs.microsoft.com/en-us/rest/api/azure/devops/git/pull%20request%20reviewers/list?view=azure-devops-rest-5.1
+func (s *PullRequestsService) ListReviewers(ctx context.Context, owner, project, repo string, pullNum int) ([]*IdentityRefWithVote, *Response, error) {
+	req, err := s.client.NewRequest("GET", s.reviewersURL(owner, project, repo, pullNum, ""), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	r := new(IdentityRefWithVoteListResponse)
+	resp, err := s.client.Execute(ctx, req, r)
+
+	return r.Reviewers, resp, err
+}
+
+// GetReviewer returns a single reviewer of a pull request
+// Azure Devops API docs: https://docs.microsoft.com/en-us/rest/api/azure/devops/git/pull%20request%20reviewers/get?view=azure-devops-rest-5.1
+func (s *PullRequestsService) GetReviewer(ctx context.Context, owner, project, repo string, pullNum int, reviewerID string) (*IdentityRefWithVote, *Response, error) {
+	req, err := s.client.NewRequest("GET", s.reviewersURL(owner, project, repo, pullNum, reviewerID), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	r := new(IdentityRefWithVote)
+	resp, err := s.client.Execute(ctx, req, r)
+
+	return r, resp, err
+}
+
+// AddReviewer adds a reviewer, identified by its identity ID, to a pull
+// request. If the reviewer is already present, whether it is required is
+// updated.
+// Azure Devops API docs: https://docs.microsoft.com/en-us/rest/api/azure/devops/git/pull%20request%20reviewers/create%20pull%20request%20reviewer?view=azure-devops-rest-5.1
+func (s *PullRequestsService) AddReviewer(ctx context.Context, owner, project, repo string, pullNum int, reviewerID string, required bool) (*IdentityRefWithVote, *Response, error) {
+	if reviewerID == "" {
+		return nil, nil, errors.New("PullRequests.AddReviewer: Empty reviewer ID")
+	}
+	body := &IdentityRefWithVote{IsRequired: Bool(required)}
+	return s.putReviewer(ctx, owner, project, repo, pullNum, reviewerID, body)
+}
+
+// AddReviewers adds reviewers to a pull request. Each reviewer needs an ID,
+// and is optional unless IsRequired is set; see RequiredReviewer and
+// OptionalReviewer.
+// Azure Devops API docs: https://docs.microsoft.com/en-us/rest/api/azure/devops/git/pull%20request%20reviewers/create%20pull%20request%20reviewers?view=azure-devops-rest-5.1
+func (s *PullRequestsService) AddReviewers(ctx context.Context, owner, project, repo string, pullNum int, reviewers []*IdentityRefWithVote) ([]*IdentityRefWithVote, *Response, error) {
+	if len(reviewers) == 0 {
+		return nil, nil, errors.New("PullRequests.AddReviewers: Must supply at least one reviewer")
+	}
+	for _, reviewer := range reviewers {
+		if reviewer.GetID() == "" {
+			return nil, nil, errors.New("PullRequests.AddReviewers: Nil pointer or empty string in reviewer ID field")
+		}
+	}
+
+	req, err := s.client.NewRequest("POST", s.reviewersURL(owner, project, repo, pullNum, ""), reviewers)
+	if err != nil {
+		return nil, nil, err
+	}
+	r := new(IdentityRefWithVoteListResponse)
+	resp, err := s.client.Execute(ctx, req, r)
+
+	return r.Reviewers, resp, err
+}
+
+// RemoveReviewer removes a reviewer from a pull request
+// Azure Devops API docs: https://docs.microsoft.com/en-us/rest/api/azure/devops/git/pull%20request%20reviewers/delete?view=azure-devops-rest-5.1
+func (s *PullRequestsService) RemoveReviewer(ctx context.Context, owner, project, repo string, pullNum int, reviewerID string) (*Response, error) {
+	req, err := s.client.NewRequest("DELETE", s.reviewersURL(owner, project, repo, pullNum, reviewerID), nil)
+	if err != nil {
+		return nil, err
+	}
+	return s.client.Execute(ctx, req, nil)
+}
+
+// Vote casts the vote of a reviewer on a pull request, adding the reviewer
+// if needed. The service only accepts a vote from the authenticated user,
+// so reviewerID must be their identity ID. VoteReset clears the vote.
+// Azure Devops API docs: https://docs.microsoft.com/en-us/rest/api/azure/devops/git/pull%20request%20reviewers/create%20pull%20request%20reviewer?view=azure-devops-rest-5.1
+func (s *PullRequestsService) Vote(ctx context.Context, owner, project, repo string, pullNum int, reviewerID string, vote PullRequestVote) (*IdentityRefWithVote, *Response, error) {
+	if reviewerID == "" {
+		return nil, nil, errors.New("PullRequests.Vote: Empty reviewer ID")
+	}
+	body := &IdentityRefWithVote{Vote: Int(int(vote))}
+	return s.putReviewer(ctx, owner, project, repo, pullNum, reviewerID, body)
+}
+
+func (s *PullRequestsService) putReviewer(ctx context.Context, owner, project, repo string, pullNum int, reviewerID string, body *IdentityRefWithVote) (*IdentityRefWithVote, *Response, error) {
+	req, err := s.client.NewRequest("PUT", s.reviewersURL(owner, project, repo, pullNum, reviewerID), body)
+	if err != nil {
+		return nil, nil, err
+	}
+	r := new(IdentityRefWithVote)
+	resp, err := s.client.Execute(ctx, req, r)
+
+	return r, resp, err
+}
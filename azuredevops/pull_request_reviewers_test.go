@@ -0,0 +1,144 @@
+package azuredevops_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/mcdafydd/go-azuredevops/azuredevops"
+)
+
+func TestPullRequestsService_ListReviewers(t *testing.T) {
+	c, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/o/p/_apis/git/repositories/r/pullrequests/1/reviewers", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"count": 2, "value": [
+			{"id": "u1", "displayName": "One", "vote": 10, "isRequired": true},
+			{"id": "u2", "displayName": "Two", "vote": -5}
+		]}`)
+	})
+
+	got, _, err := c.PullRequests.ListReviewers(context.Background(), "o", "p", "r", 1)
+	if err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+	if len(got) != 2 || got[0].PullRequestVote() != azuredevops.VoteApprove || !got[0].GetIsRequired() {
+		t.Errorf("PullRequests.ListReviewers returned %+v", got)
+	}
+	if v := got[1].PullRequestVote(); v != azuredevops.VoteWaitForAuthor || v.String() != "waiting for author" {
+		t.Errorf("PullRequestVote = %v, want waiting for author", v)
+	}
+}
+
+func TestPullRequestsService_GetReviewer(t *testing.T) {
+	c, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/o/p/_apis/git/repositories/r/pullrequests/1/reviewers/u1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"id": "u1", "vote": 5}`)
+	})
+
+	got, _, err := c.PullRequests.GetReviewer(context.Background(), "o", "p", "r", 1, "u1")
+	if err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+	if got.PullRequestVote() != azuredevops.VoteApproveWithSuggestions {
+		t.Errorf("PullRequests.GetReviewer returned %+v", got)
+	}
+}
+
+func TestPullRequestsService_AddReviewer(t *testing.T) {
+	c, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/o/p/_apis/git/repositories/r/pullrequests/1/reviewers/u1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PUT")
+		testBody(t, r, `{"isRequired":true}`+"\n")
+		fmt.Fprint(w, `{"id": "u1", "isRequired": true, "vote": 0}`)
+	})
+
+	got, _, err := c.PullRequests.AddReviewer(context.Background(), "o", "p", "r", 1, "u1", true)
+	if err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+	if got.GetID() != "u1" || !got.GetIsRequired() {
+		t.Errorf("PullRequests.AddReviewer returned %+v", got)
+	}
+}
+
+func TestPullRequestsService_AddReviewers(t *testing.T) {
+	c, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/o/p/_apis/git/repositories/r/pullrequests/1/reviewers", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testBody(t, r, `[{"id":"u1","isRequired":true},{"id":"u2","isRequired":false}]`+"\n")
+		fmt.Fprint(w, `{"count": 2, "value": [{"id": "u1", "isRequired": true}, {"id": "u2"}]}`)
+	})
+
+	reviewers := []*azuredevops.IdentityRefWithVote{
+		azuredevops.RequiredReviewer("u1"),
+		azuredevops.OptionalReviewer("u2"),
+	}
+	got, _, err := c.PullRequests.AddReviewers(context.Background(), "o", "p", "r", 1, reviewers)
+	if err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+	if len(got) != 2 {
+		t.Errorf("PullRequests.AddReviewers returned %+v", got)
+	}
+
+	if _, _, err := c.PullRequests.AddReviewers(context.Background(), "o", "p", "r", 1, nil); err == nil {
+		t.Error("PullRequests.AddReviewers without reviewers returned no error")
+	}
+}
+
+func TestPullRequestsService_RemoveReviewer(t *testing.T) {
+	c, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/o/p/_apis/git/repositories/r/pullrequests/1/reviewers/u1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "DELETE")
+	})
+
+	if _, err := c.PullRequests.RemoveReviewer(context.Background(), "o", "p", "r", 1, "u1"); err != nil {
+		t.Errorf("PullRequests.RemoveReviewer returned error: %v", err)
+	}
+}
+
+func TestPullRequestsService_Vote(t *testing.T) {
+	tests := []struct {
+		vote azuredevops.PullRequestVote
+		body string
+	}{
+		{azuredevops.VoteApprove, `{"vote":10}`},
+		{azuredevops.VoteApproveWithSuggestions, `{"vote":5}`},
+		{azuredevops.VoteWaitForAuthor, `{"vote":-5}`},
+		{azuredevops.VoteReject, `{"vote":-10}`},
+		{azuredevops.VoteReset, `{"vote":0}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.vote.String(), func(t *testing.T) {
+			c, mux, _, teardown := setup()
+			defer teardown()
+
+			mux.HandleFunc("/o/p/_apis/git/repositories/r/pullrequests/1/reviewers/me", func(w http.ResponseWriter, r *http.Request) {
+				testMethod(t, r, "PUT")
+				testBody(t, r, tt.body+"\n")
+				fmt.Fprintf(w, `{"id": "me", "vote": %d}`, int(tt.vote))
+			})
+
+			got, _, err := c.PullRequests.Vote(context.Background(), "o", "p", "r", 1, "me", tt.vote)
+			if err != nil {
+				t.Fatalf("returned error: %v", err)
+			}
+			if got.PullRequestVote() != tt.vote {
+				t.Errorf("PullRequests.Vote returned %+v", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,157 @@
+package azuredevops
+
+import (
+	"context"
+	"fmt"
+)
+
+// GitPullRequestStatusesListResponse describes the pull request statuses
+// list response
+type GitPullRequestStatusesListResponse struct {
+	Count    int                     `json:"count"`
+	Statuses []*GitPullRequestStatus `json:"value"`
+}
+
+// JSONPatchOperation describes a JSON Patch (RFC 6902) operation
+type JSONPatchOperation struct {
+	From  *string     `json:"from,omitempty"`
+	Op    *string     `json:"op,omitempty"`
+	Path  *string     `json:"path,omitempty"`
+	Value interface{} `json:"value,omitempty"`
+}
+
+// ListStatuses returns the statuses of a pull request and its iterations.
+// It returns a single page of statuses; use ListStatusesPages or
+// ListAllStatuses to walk every page.
+// Azure Devops API docs: https://docs.microsoft.com/en-us/rest/api/azure/devops/git/pull%20request%20statuses/list?view=azure-devops-rest-5.1
+func (s *PullRequestsService) ListStatuses(ctx context.Context, owner, project, repo string, pullNum int) ([]*GitPullRequestStatus, *Response, error) {
+	return s.listStatuses(ctx, owner, project, repo, pullNum, &pullRequestStatusListOptions{})
+}
+
+// pullRequestStatusListOptions describes the paging parameters of the pull
+// request statuses list API
+type pullRequestStatusListOptions struct {
+	ContinuationToken string `url:"continuationToken,omitempty"`
+}
+
+func (s *PullRequestsService) listStatuses(ctx context.Context, owner, project, repo string, pullNum int, opts *pullRequestStatusListOptions) ([]*GitPullRequestStatus, *Response, error) {
+	URL := fmt.Sprintf("%s/%s/_apis/git/repositories/%s/pullrequests/%d/statuses?api-version=%s",
+		owner,
+		project,
+		repo,
+		pullNum,
+		s.client.apiVersion("git", "pullRequestStatuses"),
+	)
+	URL, err := addOptions(URL, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := s.client.NewRequest("GET", URL, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	r := new(GitPullRequestStatusesListResponse)
+	resp, err := s.client.Execute(ctx, req, r)
+
+	return r.Statuses, resp, err
+}
+
+// ListStatusesPages walks every page of statuses of a pull request, calling
+// fn with each page. The API returns every status in one page unless it
+// sends a continuation token, which is then followed.
+func (s *PullRequestsService) ListStatusesPages(ctx context.Context, owner, project, repo string, pullNum int, fn func([]*GitPullRequestStatus, *Response) error) error {
+	return walkContinuation("", func(token string) (*Response, error) {
+		statuses, resp, err := s.listStatuses(ctx, owner, project, repo, pullNum, &pullRequestStatusListOptions{ContinuationToken: token})
+		if err != nil {
+			return nil, err
+		}
+		return resp, fn(statuses, resp)
+	})
+}
+
+// ListAllStatuses returns every status of a pull request, walking all pages
+// of results.
+func (s *PullRequestsService) ListAllStatuses(ctx context.Context, owner, project, repo string, pullNum int) ([]*GitPullRequestStatus, error) {
+	var all []*GitPullRequestStatus
+	err := s.ListStatusesPages(ctx, owner, project, repo, pullNum, func(statuses []*GitPullRequestStatus, _ *Response) error {
+		all = append(all, statuses...)
+		return nil
+	})
+	return all, err
+}
+
+// GetStatus returns a single pull request status
+// Azure Devops API docs: https://docs.microsoft.com/en-us/rest/api/azure/devops/git/pull%20request%20statuses/get?view=azure-devops-rest-5.1
+func (s *PullRequestsService) GetStatus(ctx context.Context, owner, project, repo string, pullNum, statusID int) (*GitPullRequestStatus, *Response, error) {
+	URL := fmt.Sprintf("%s/%s/_apis/git/repositories/%s/pullrequests/%d/statuses/%d?api-version=%s",
+		owner,
+		project,
+		repo,
+		pullNum,
+		statusID,
+		s.client.apiVersion("git", "pullRequestStatuses"),
+	)
+
+	req, err := s.client.NewRequest("GET", URL, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	r := new(GitPullRequestStatus)
+	resp, err := s.client.Execute(ctx, req, r)
+
+	return r, resp, err
+}
+
+// UpdateStatuses applies JSON Patch operations to the statuses of a pull
+// request. The only operation supported by Azure DevOps is remove, with a
+// path of /<statusID>; see DeleteStatuses. To change the state of a
+// context, post a new status with CreateStatus.
+// Azure Devops API docs: https://docs.microsoft.com/en-us/rest/api/azure/devops/git/pull%20request%20statuses/update?view=azure-devops-rest-5.1
+func (s *PullRequestsService) UpdateStatuses(ctx context.Context, owner, project, repo string, pullNum int, ops []*JSONPatchOperation) (*Response, error) {
+	URL := fmt.Sprintf("%s/%s/_apis/git/repositories/%s/pullrequests/%d/statuses?api-version=%s",
+		owner,
+		project,
+		repo,
+		pullNum,
+		s.client.apiVersion("git", "pullRequestStatuses"),
+	)
+
+	req, err := s.client.NewRequest("PATCH", URL, ops)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json-patch+json")
+	return s.client.Execute(ctx, req, nil)
+}
+
+// DeleteStatuses deletes several statuses of a pull request in one request
+func (s *PullRequestsService) DeleteStatuses(ctx context.Context, owner, project, repo string, pullNum int, statusIDs ...int) (*Response, error) {
+	ops := make([]*JSONPatchOperation, len(statusIDs))
+	for i, id := range statusIDs {
+		ops[i] = &JSONPatchOperation{
+			Op:   String("remove"),
+			Path: String(fmt.Sprintf("/%d", id)),
+		}
+	}
+	return s.UpdateStatuses(ctx, owner, project, repo, pullNum, ops)
+}
+
+// DeleteStatus deletes a single pull request status
+// Azure Devops API docs: https://docs.microsoft.com/en-us/rest/api/azure/devops/git/pull%20request%20statuses/delete?view=azure-devops-rest-5.1
+func (s *PullRequestsService) DeleteStatus(ctx context.Context, owner, project, repo string, pullNum, statusID int) (*Response, error) {
+	URL := fmt.Sprintf("%s/%s/_apis/git/repositories/%s/pullrequests/%d/statuses/%d?api-version=%s",
+		owner,
+		project,
+		repo,
+		pullNum,
+		statusID,
+		s.client.apiVersion("git", "pullRequestStatuses"),
+	)
+
+	req, err := s.client.NewRequest("DELETE", URL, nil)
+	if err != nil {
+		return nil, err
+	}
+	return s.client.Execute(ctx, req, nil)
+}
@@ -0,0 +1,109 @@
+package azuredevops_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/mcdafydd/go-azuredevops/azuredevops"
+)
+
+func TestPullRequestsService_ListStatuses(t *testing.T) {
+	c, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/o/p/_apis/git/repositories/r/pullrequests/1/statuses", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"count": 2, "value": [
+			{"id": 1, "iterationId": 1, "state": "failed", "context": {"genre": "ci", "name": "build"}},
+			{"id": 2, "iterationId": 2, "state": "succeeded", "context": {"genre": "ci", "name": "build"}}
+		]}`)
+	})
+
+	got, _, err := c.PullRequests.ListStatuses(context.Background(), "o", "p", "r", 1)
+	if err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+	if len(got) != 2 || got[1].GetIterationID() != 2 {
+		t.Errorf("PullRequests.ListStatuses returned %+v", got)
+	}
+	states := azuredevops.CollapsePullRequestStatuses(got)
+	if states["ci/build"] != azuredevops.GitSucceeded {
+		t.Errorf("CollapsePullRequestStatuses returned %v", states)
+	}
+}
+
+func TestPullRequestsService_ListAllStatuses(t *testing.T) {
+	c, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/o/p/_apis/git/repositories/r/pullrequests/1/statuses", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		switch token := r.FormValue("continuationToken"); token {
+		case "":
+			w.Header().Set("X-Ms-Continuationtoken", "next")
+			fmt.Fprint(w, `{"count": 1, "value": [{"id": 1}]}`)
+		case "next":
+			fmt.Fprint(w, `{"count": 1, "value": [{"id": 2}]}`)
+		default:
+			t.Errorf("Unexpected continuationToken %q", token)
+		}
+	})
+
+	got, err := c.PullRequests.ListAllStatuses(context.Background(), "o", "p", "r", 1)
+	if err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+	if len(got) != 2 || got[0].GetID() != 1 || got[1].GetID() != 2 {
+		t.Errorf("PullRequests.ListAllStatuses returned %+v", got)
+	}
+}
+
+func TestPullRequestsService_GetStatus(t *testing.T) {
+	c, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/o/p/_apis/git/repositories/r/pullrequests/1/statuses/2", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"id": 2, "state": "succeeded"}`)
+	})
+
+	got, _, err := c.PullRequests.GetStatus(context.Background(), "o", "p", "r", 1, 2)
+	if err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+	if got.GetID() != 2 {
+		t.Errorf("PullRequests.GetStatus returned %+v", got)
+	}
+}
+
+func TestPullRequestsService_DeleteStatuses(t *testing.T) {
+	c, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/o/p/_apis/git/repositories/r/pullrequests/1/statuses", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PATCH")
+		testHeader(t, r, "Content-Type", "application/json-patch+json")
+		testBody(t, r, `[{"op":"remove","path":"/1"},{"op":"remove","path":"/2"}]`+"\n")
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	if _, err := c.PullRequests.DeleteStatuses(context.Background(), "o", "p", "r", 1, 1, 2); err != nil {
+		t.Errorf("PullRequests.DeleteStatuses returned error: %v", err)
+	}
+}
+
+func TestPullRequestsService_DeleteStatus(t *testing.T) {
+	c, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/o/p/_apis/git/repositories/r/pullrequests/1/statuses/2", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "DELETE")
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	if _, err := c.PullRequests.DeleteStatus(context.Background(), "o", "p", "r", 1, 2); err != nil {
+		t.Errorf("PullRequests.DeleteStatus returned error: %v", err)
+	}
+}
@@ -0,0 +1,194 @@
+package azuredevops
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// GitPullRequestCommentThreadsListResponse describes the pull request
+// threads list response
+type GitPullRequestCommentThreadsListResponse struct {
+	Count   int                            `json:"count"`
+	Threads []*GitPullRequestCommentThread `json:"value"`
+}
+
+// IdentityRefsListResponse describes a list of identities, such as the
+// users who liked a comment
+type IdentityRefsListResponse struct {
+	Count      int            `json:"count"`
+	Identities []*IdentityRef `json:"value"`
+}
+
+// PullRequestThreadOptions describes the parameters of the pull request
+// threads list and get APIs. With Iteration, and optionally BaseIteration,
+// the positions of threads are tracked to that iteration of the pull
+// request.
+type PullRequestThreadOptions struct {
+	Iteration     *int `url:"$iteration,omitempty"`
+	BaseIteration *int `url:"$baseIteration,omitempty"`
+}
+
+func (s *PullRequestsService) threadsURL(owner, project, repo string, pullNum int) string {
+	return fmt.Sprintf("%s/%s/_apis/git/repositories/%s/pullrequests/%d/threads",
+		owner,
+		project,
+		repo,
+		pullNum,
+	)
+}
+
+// ListThreads returns the comment threads of a pull request
+// Azure Devops API docs: https://docs.microsoft.com/en-us/rest/api/azure/devops/git/pull%20request%20threads/list?view=azure-devops-rest-5.1
+func (s *PullRequestsService) ListThreads(ctx context.Context, owner, project, repo string, pullNum int, opts *PullRequestThreadOptions) ([]*GitPullRequestCommentThread, *Response, error) {
+	URL := fmt.Sprintf("%s?api-version=%s",
+		s.threadsURL(owner, project, repo, pullNum),
+		s.client.apiVersion("git", "pullRequestThreads"),
+	)
+	URL, err := addOptions(URL, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := s.client.NewRequest("GET", URL, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	r := new(GitPullRequestCommentThreadsListResponse)
+	resp, err := s.client.Execute(ctx, req, r)
+
+	return r.Threads, resp, err
+}
+
+// GetThread returns a single comment thread of a pull request
+// Azure Devops API docs: https://docs.microsoft.com/en-us/rest/api/azure/devops/git/pull%20request%20threads/get?view=azure-devops-rest-5.1
+func (s *PullRequestsService) GetThread(ctx context.Context, owner, project, repo string, pullNum, threadID int, opts *PullRequestThreadOptions) (*GitPullRequestCommentThread, *Response, error) {
+	URL := fmt.Sprintf("%s/%d?api-version=%s",
+		s.threadsURL(owner, project, repo, pullNum),
+		threadID,
+		s.client.apiVersion("git", "pullRequestThreads"),
+	)
+	URL, err := addOptions(URL, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := s.client.NewRequest("GET", URL, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	r := new(GitPullRequestCommentThread)
+	resp, err := s.client.Execute(ctx, req, r)
+
+	return r, resp, err
+}
+
+// UpdateThread updates the status or properties of a comment thread. Use
+// UpdateComment to change its comments.
+// Azure Devops API docs: https://docs.microsoft.com/en-us/rest/api/azure/devops/git/pull%20request%20threads/update?view=azure-devops-rest-5.1
+func (s *PullRequestsService) UpdateThread(ctx context.Context, owner, project, repo string, pullNum, threadID int, thread *GitPullRequestCommentThread) (*GitPullRequestCommentThread, *Response, error) {
+	URL := fmt.Sprintf("%s/%d?api-version=%s",
+		s.threadsURL(owner, project, repo, pullNum),
+		threadID,
+		s.client.apiVersion("git", "pullRequestThreads"),
+	)
+
+	req, err := s.client.NewRequest("PATCH", URL, thread)
+	if err != nil {
+		return nil, nil, err
+	}
+	r := new(GitPullRequestCommentThread)
+	resp, err := s.client.Execute(ctx, req, r)
+
+	return r, resp, err
+}
+
+// SetThreadStatus sets the status of a comment thread, such as Fixed,
+// WontFix or Closed to resolve it, or StatusActive to reactivate it.
+func (s *PullRequestsService) SetThreadStatus(ctx context.Context, owner, project, repo string, pullNum, threadID int, status CommentThreadStatus) (*GitPullRequestCommentThread, *Response, error) {
+	thread := &GitPullRequestCommentThread{Status: String(status.String())}
+	return s.UpdateThread(ctx, owner, project, repo, pullNum, threadID, thread)
+}
+
+func (s *PullRequestsService) commentURL(owner, project, repo string, pullNum, threadID, commentID int) string {
+	return fmt.Sprintf("%s/%d/comments/%d?api-version=%s",
+		s.threadsURL(owner, project, repo, pullNum),
+		threadID,
+		commentID,
+		s.client.apiVersion("git", "pullRequestThreadComments"),
+	)
+}
+
+// UpdateComment changes the content of a comment
+// Azure Devops API docs: https://docs.microsoft.com/en-us/rest/api/azure/devops/git/pull%20request%20thread%20comments/update?view=azure-devops-rest-5.1
+func (s *PullRequestsService) UpdateComment(ctx context.Context, owner, project, repo string, pullNum, threadID, commentID int, comment *Comment) (*Comment, *Response, error) {
+	URL := s.commentURL(owner, project, repo, pullNum, threadID, commentID)
+
+	if comment.GetContent() == "" {
+		return nil, nil, errors.New("PullRequests.UpdateComment: Nil pointer or empty string in comment.Content field")
+	}
+
+	req, err := s.client.NewRequest("PATCH", URL, comment)
+	if err != nil {
+		return nil, nil, err
+	}
+	r := new(Comment)
+	resp, err := s.client.Execute(ctx, req, r)
+
+	return r, resp, err
+}
+
+// DeleteComment deletes a comment. The comment remains in its thread with
+// IsDeleted set.
+// Azure Devops API docs: https://docs.microsoft.com/en-us/rest/api/azure/devops/git/pull%20request%20thread%20comments/delete?view=azure-devops-rest-5.1
+func (s *PullRequestsService) DeleteComment(ctx context.Context, owner, project, repo string, pullNum, threadID, commentID int) (*Response, error) {
+	URL := s.commentURL(owner, project, repo, pullNum, threadID, commentID)
+
+	req, err := s.client.NewRequest("DELETE", URL, nil)
+	if err != nil {
+		return nil, err
+	}
+	return s.client.Execute(ctx, req, nil)
+}
+
+func (s *PullRequestsService) likesURL(owner, project, repo string, pullNum, threadID, commentID int) string {
+	return fmt.Sprintf("%s/%d/comments/%d/likes?api-version=%s",
+		s.threadsURL(owner, project, repo, pullNum),
+		threadID,
+		commentID,
+		s.client.apiVersion("git", "pullRequestCommentLikes"),
+	)
+}
+
+// LikeComment adds a like to a comment by the authenticated user
+// Azure Devops API docs: https://docs.microsoft.com/en-us/rest/api/azure/devops/git/pull%20request%20comment%20likes/create?view=azure-devops-rest-5.1
+func (s *PullRequestsService) LikeComment(ctx context.Context, owner, project, repo string, pullNum, threadID, commentID int) (*Response, error) {
+	req, err := s.client.NewRequest("POST", s.likesURL(owner, project, repo, pullNum, threadID, commentID), nil)
+	if err != nil {
+		return nil, err
+	}
+	return s.client.Execute(ctx, req, nil)
+}
+
+// UnlikeComment removes the like of the authenticated user from a comment
+// Azure Devops API docs: https://docs.microsoft.com/en-us/rest/api/azure/devops/git/pull%20request%20comment%20likes/delete?view=azure-devops-rest-5.1
+func (s *PullRequestsService) UnlikeComment(ctx context.Context, owner, project, repo string, pullNum, threadID, commentID int) (*Response, error) {
+	req, err := s.client.NewRequest("DELETE", s.likesURL(owner, project, repo, pullNum, threadID, commentID), nil)
+	if err != nil {
+		return nil, err
+	}
+	return s.client.Execute(ctx, req, nil)
+}
+
+// ListCommentLikes returns the users who liked a comment
+// Azure Devops API docs: https://docs.microsoft.com/en-us/rest/api/azure/devops/git/pull%20request%20comment%20likes/list?view=azure-devops-rest-5.1
+func (s *PullRequestsService) ListCommentLikes(ctx context.Context, owner, project, repo string, pullNum, threadID, commentID int) ([]*IdentityRef, *Response, error) {
+	req, err := s.client.NewRequest("GET", s.likesURL(owner, project, repo, pullNum, threadID, commentID), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	r := new(IdentityRefsListResponse)
+	resp, err := s.client.Execute(ctx, req, r)
+
+	return r.Identities, resp, err
+}
@@ -0,0 +1,151 @@
+package azuredevops_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/mcdafydd/go-azuredevops/azuredevops"
+)
+
+func TestPullRequestsService_ListThreads(t *testing.T) {
+	c, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/o/p/_apis/git/repositories/r/pullrequests/1/threads", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{
+			"$iteration":     "3",
+			"$baseIteration": "1",
+		})
+		fmt.Fprint(w, `{"count": 2, "value": [
+			{"id": 1, "status": "active", "comments": [{"id": 1, "content": "Fix this"}]},
+			{"id": 2, "status": "fixed", "properties": {"bot": {"$type": "System.String", "$value": "lint"}}}
+		]}`)
+	})
+
+	opts := &azuredevops.PullRequestThreadOptions{
+		Iteration:     azuredevops.Int(3),
+		BaseIteration: azuredevops.Int(1),
+	}
+	got, _, err := c.PullRequests.ListThreads(context.Background(), "o", "p", "r", 1, opts)
+	if err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+	if len(got) != 2 || got[0].Comments[0].GetContent() != "Fix this" || got[1].GetStatus() != "fixed" {
+		t.Errorf("PullRequests.ListThreads returned %+v", got)
+	}
+	if _, ok := got[1].Properties["bot"]; !ok {
+		t.Errorf("PullRequests.ListThreads properties = %v", got[1].Properties)
+	}
+}
+
+func TestPullRequestsService_GetThread(t *testing.T) {
+	c, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/o/p/_apis/git/repositories/r/pullrequests/1/threads/2", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"id": 2, "status": "closed"}`)
+	})
+
+	got, _, err := c.PullRequests.GetThread(context.Background(), "o", "p", "r", 1, 2, nil)
+	if err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+	if got.GetID() != 2 || got.GetStatus() != "closed" {
+		t.Errorf("PullRequests.GetThread returned %+v", got)
+	}
+}
+
+func TestPullRequestsService_SetThreadStatus(t *testing.T) {
+	c, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/o/p/_apis/git/repositories/r/pullrequests/1/threads/2", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PATCH")
+		testBody(t, r, `{"status":"wontFix"}`+"\n")
+		fmt.Fprint(w, `{"id": 2, "status": "wontFix"}`)
+	})
+
+	got, _, err := c.PullRequests.SetThreadStatus(context.Background(), "o", "p", "r", 1, 2, azuredevops.WontFix)
+	if err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+	if got.GetStatus() != "wontFix" {
+		t.Errorf("PullRequests.SetThreadStatus returned %+v", got)
+	}
+}
+
+func TestPullRequestsService_UpdateComment(t *testing.T) {
+	c, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/o/p/_apis/git/repositories/r/pullrequests/1/threads/2/comments/3", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PATCH")
+		testBody(t, r, `{"content":"Edited"}`+"\n")
+		fmt.Fprint(w, `{"id": 3, "content": "Edited"}`)
+	})
+
+	got, _, err := c.PullRequests.UpdateComment(context.Background(), "o", "p", "r", 1, 2, 3, &azuredevops.Comment{
+		Content: azuredevops.String("Edited"),
+	})
+	if err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+	if got.GetContent() != "Edited" {
+		t.Errorf("PullRequests.UpdateComment returned %+v", got)
+	}
+
+	if _, _, err := c.PullRequests.UpdateComment(context.Background(), "o", "p", "r", 1, 2, 3, &azuredevops.Comment{}); err == nil {
+		t.Error("PullRequests.UpdateComment with empty content returned no error")
+	}
+}
+
+func TestPullRequestsService_DeleteComment(t *testing.T) {
+	c, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/o/p/_apis/git/repositories/r/pullrequests/1/threads/2/comments/3", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "DELETE")
+	})
+
+	if _, err := c.PullRequests.DeleteComment(context.Background(), "o", "p", "r", 1, 2, 3); err != nil {
+		t.Errorf("PullRequests.DeleteComment returned error: %v", err)
+	}
+}
+
+func TestPullRequestsService_CommentLikes(t *testing.T) {
+	c, mux, _, teardown := setup()
+	defer teardown()
+
+	var methods []string
+	mux.HandleFunc("/o/p/_apis/git/repositories/r/pullrequests/1/threads/2/comments/3/likes", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("api-version"); got != "5.1-preview.1" {
+			t.Errorf("api-version = %q, want 5.1-preview.1", got)
+		}
+		methods = append(methods, r.Method)
+		if r.Method == "GET" {
+			fmt.Fprint(w, `{"count": 1, "value": [{"id": "u1", "displayName": "User"}]}`)
+		}
+	})
+
+	ctx := context.Background()
+	if _, err := c.PullRequests.LikeComment(ctx, "o", "p", "r", 1, 2, 3); err != nil {
+		t.Errorf("PullRequests.LikeComment returned error: %v", err)
+	}
+	got, _, err := c.PullRequests.ListCommentLikes(ctx, "o", "p", "r", 1, 2, 3)
+	if err != nil {
+		t.Errorf("PullRequests.ListCommentLikes returned error: %v", err)
+	}
+	if len(got) != 1 || got[0].GetID() != "u1" {
+		t.Errorf("PullRequests.ListCommentLikes returned %+v", got)
+	}
+	if _, err := c.PullRequests.UnlikeComment(ctx, "o", "p", "r", 1, 2, 3); err != nil {
+		t.Errorf("PullRequests.UnlikeComment returned error: %v", err)
+	}
+	if fmt.Sprint(methods) != "[POST GET DELETE]" {
+		t.Errorf("Requests = %v, want [POST GET DELETE]", methods)
+	}
+}
@@ -4,10 +4,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"net/http"
+	"strconv"
 )
 
 // Vote identifiers
+//
+// Deprecated: use the typed PullRequestVote values, such as VoteApprove.
 const (
 	VoteApproved                = 10
 	VoteApprovedWithSuggestions = 5
@@ -50,7 +52,7 @@ const (
 )
 
 func (d CommentThreadStatus) String() string {
-	return [...]string{"unknown", "active", "fixed", "wontfix", "closed", "byDesign", "pending"}[d]
+	return [...]string{"unknown", "active", "fixed", "wontFix", "closed", "byDesign", "pending"}[d]
 }
 
 // PullRequestAsyncStatus The current status of a pull request merge.
@@ -170,10 +172,11 @@ type PullRequestListOptions struct {
 // List returns list of pull requests in the specified Team Project with optional
 // filters
 // https://docs.microsoft.com/en-us/rest/api/azure/devops/git/pull%20requests/get%20pull%20requests%20by%20project
-func (s *PullRequestsService) List(ctx context.Context, owner, project string, opts *PullRequestListOptions) ([]*GitPullRequest, *http.Response, error) {
-	URL := fmt.Sprintf("%s/%s/_apis/git/pullrequests?api-version=5.1-preview.1",
+func (s *PullRequestsService) List(ctx context.Context, owner, project string, opts *PullRequestListOptions) ([]*GitPullRequest, *Response, error) {
+	URL := fmt.Sprintf("%s/%s/_apis/git/pullrequests?api-version=%s",
 		owner,
 		project,
+		s.client.apiVersion("git", "pullRequests"),
 	)
 	URL, err := addOptions(URL, opts)
 
@@ -191,13 +194,44 @@ func (s *PullRequestsService) List(ctx context.Context, owner, project string, o
 	return r.GitPullRequests, resp, err
 }
 
+// ListPages walks every page of pull requests matching opts, calling fn with
+// each page. Pages are requested with $top and $skip, starting at opts.Skip.
+func (s *PullRequestsService) ListPages(ctx context.Context, owner, project string, opts *PullRequestListOptions, fn func([]*GitPullRequest, *Response) error) error {
+	o := PullRequestListOptions{}
+	if opts != nil {
+		o = *opts
+	}
+	skip, _ := strconv.Atoi(o.Skip)
+	top, _ := strconv.Atoi(o.Top)
+	return walkSkip(skip, top, func(skip, top int) (int, error) {
+		o.Skip, o.Top = strconv.Itoa(skip), strconv.Itoa(top)
+		pulls, resp, err := s.List(ctx, owner, project, &o)
+		if err != nil {
+			return 0, err
+		}
+		return len(pulls), fn(pulls, resp)
+	})
+}
+
+// ListAll returns every pull request matching opts, walking all pages of
+// results.
+func (s *PullRequestsService) ListAll(ctx context.Context, owner, project string, opts *PullRequestListOptions) ([]*GitPullRequest, error) {
+	var all []*GitPullRequest
+	err := s.ListPages(ctx, owner, project, opts, func(pulls []*GitPullRequest, _ *Response) error {
+		all = append(all, pulls...)
+		return nil
+	})
+	return all, err
+}
+
 // Get returns a single pull request
 // utilising https://docs.microsoft.com/en-us/rest/api/vsts/git/pull%20requests/get%20pull%20requests%20by%20project
-func (s *PullRequestsService) Get(ctx context.Context, owner, project string, pullNum int, opts *PullRequestListOptions) (*GitPullRequest, *http.Response, error) {
-	URL := fmt.Sprintf("%s/%s/_apis/git/pullrequests/%d?api-version=5.1-preview.1",
+func (s *PullRequestsService) Get(ctx context.Context, owner, project string, pullNum int, opts *PullRequestListOptions) (*GitPullRequest, *Response, error) {
+	URL := fmt.Sprintf("%s/%s/_apis/git/pullrequests/%d?api-version=%s",
 		owner,
 		project,
 		pullNum,
+		s.client.apiVersion("git", "pullRequests"),
 	)
 	URL, err := addOptions(URL, opts)
 
@@ -213,12 +247,13 @@ func (s *PullRequestsService) Get(ctx context.Context, owner, project string, pu
 
 // GetWithRepo returns a single pull request with additional information
 // https://docs.microsoft.com/en-us/rest/api/azure/devops/git/pull%20requests/get%20pull%20request?view=azure-devops-rest-5.1
-func (s *PullRequestsService) GetWithRepo(ctx context.Context, owner, project, repo string, pullNum int, opts *PullRequestGetOptions) (*GitPullRequest, *http.Response, error) {
-	URL := fmt.Sprintf("%s/%s/_apis/git/repositories/%s/pullrequests/%d?api-version=5.1-preview.1",
+func (s *PullRequestsService) GetWithRepo(ctx context.Context, owner, project, repo string, pullNum int, opts *PullRequestGetOptions) (*GitPullRequest, *Response, error) {
+	URL := fmt.Sprintf("%s/%s/_apis/git/repositories/%s/pullrequests/%d?api-version=%s",
 		owner,
 		project,
 		repo,
 		pullNum,
+		s.client.apiVersion("git", "pullRequests"),
 	)
 
 	URL, err := addOptions(URL, opts)
@@ -236,12 +271,13 @@ func (s *PullRequestsService) GetWithRepo(ctx context.Context, owner, project, r
 // Merge Completes a pull request
 // pull may be nil
 // https://docs.microsoft.com/en-us/rest/api/azure/devops/git/pull%20requests/update?view=azure-devops-rest-5.1
-func (s *PullRequestsService) Merge(ctx context.Context, owner, project string, repoName string, pullNum int, pull *GitPullRequest, completionOpts GitPullRequestCompletionOptions, id IdentityRef) (*GitPullRequest, *http.Response, error) {
-	URL := fmt.Sprintf("%s/%s/_apis/git/repositories/%s/pullrequests/%d?api-version=5.1-preview.1",
+func (s *PullRequestsService) Merge(ctx context.Context, owner, project string, repoName string, pullNum int, pull *GitPullRequest, completionOpts GitPullRequestCompletionOptions, id IdentityRef) (*GitPullRequest, *Response, error) {
+	URL := fmt.Sprintf("%s/%s/_apis/git/repositories/%s/pullrequests/%d?api-version=%s",
 		owner,
 		project,
 		repoName,
 		pullNum,
+		s.client.apiVersion("git", "pullRequests"),
 	)
 
 	/* If pull not nil, prepare for merge
@@ -276,11 +312,12 @@ func (s *PullRequestsService) Merge(ctx context.Context, owner, project string,
 // SourceRefName can be either the full ref name "refs/heads/branchname" or
 // just "branchname".  The latter will be converted before submission.
 // https://docs.microsoft.com/en-us/rest/api/azure/devops/git/pull%20requests/create?view=azure-devops-rest-5.1
-func (s *PullRequestsService) Create(ctx context.Context, owner, project string, repoName string, pull *GitPullRequest) (*GitPullRequest, *http.Response, error) {
-	URL := fmt.Sprintf("%s/%s/_apis/git/repositories/%s/pullrequests?api-version=5.1-preview.1",
+func (s *PullRequestsService) Create(ctx context.Context, owner, project string, repoName string, pull *GitPullRequest) (*GitPullRequest, *Response, error) {
+	URL := fmt.Sprintf("%s/%s/_apis/git/repositories/%s/pullrequests?api-version=%s",
 		owner,
 		project,
 		repoName,
+		s.client.apiVersion("git", "pullRequests"),
 	)
 
 	if pull.GetTitle() == "" || pull.GetDescription() == "" ||
@@ -333,15 +370,21 @@ type GitPullRequestCommentThread struct {
 	Identities               []*IdentityRef                      `json:"identities,omitempty"`
 	IsDeleted                *bool                               `json:"isDeleted,omitempty"`
 	LastUpdatedDate          *Time                               `json:"lastUpdatedDate,omitempty"`
-	Properties               []*int                              `json:"properties,omitempty"`
+	Properties               map[string]interface{}              `json:"properties,omitempty"`
 	PublishedDate            *Time                               `json:"publishedDate,omitempty"`
 	Status                   *string                             `json:"status,omitempty"`
-	PullRequestThreadContext *GitPullRequestCommentThreadContext `json:"pullRequestThreadContext,omitempty"`
+	ThreadContext            *GitPullRequestCommentThreadContext `json:"threadContext,omitempty"`
+	PullRequestContext       *GitPullRequestThreadContext        `json:"pullRequestThreadContext,omitempty"`
+	// Deprecated: the service's pullRequestThreadContext does not have the
+	// shape of GitPullRequestCommentThreadContext, so this field is no longer
+	// encoded or decoded. Use ThreadContext for the file and lines of a
+	// thread, and PullRequestContext for its iteration and change tracking.
+	PullRequestThreadContext *GitPullRequestCommentThreadContext `json:"-"`
 }
 
-// GitPullRequestCommentThreadContext Comment thread context contains details about what
-// diffs were being viewed at the time of thread creation and whether or not the thread
-// has been tracked from that original diff.
+// GitPullRequestCommentThreadContext Comment thread context contains the file
+// a thread was left on and the range of lines it covers, on the left (base)
+// or right (target) side of the diff.
 type GitPullRequestCommentThreadContext struct {
 	FilePath       *string          `json:"filePath,omitempty"`
 	LeftFileEnd    *CommentPosition `json:"leftFileEnd,omitempty"`
@@ -350,6 +393,34 @@ type GitPullRequestCommentThreadContext struct {
 	RightFileStart *CommentPosition `json:"rightFileStart,omitempty"`
 }
 
+// GitPullRequestThreadContext Pull request thread context contains details
+// about what diffs were being viewed at the time of thread creation and
+// whether or not the thread has been tracked from that original diff.
+type GitPullRequestThreadContext struct {
+	ChangeTrackingID *int                     `json:"changeTrackingId,omitempty"`
+	IterationContext *CommentIterationContext `json:"iterationContext,omitempty"`
+	TrackingCriteria *CommentTrackingCriteria `json:"trackingCriteria,omitempty"`
+}
+
+// CommentIterationContext describes the iterations being compared when a
+// thread was created.
+type CommentIterationContext struct {
+	FirstComparingIteration  *int `json:"firstComparingIteration,omitempty"`
+	SecondComparingIteration *int `json:"secondComparingIteration,omitempty"`
+}
+
+// CommentTrackingCriteria describes where a thread was originally placed,
+// when it has since been tracked to a newer iteration.
+type CommentTrackingCriteria struct {
+	FirstComparingIteration  *int             `json:"firstComparingIteration,omitempty"`
+	OrigFilePath             *string          `json:"origFilePath,omitempty"`
+	OrigLeftFileEnd          *CommentPosition `json:"origLeftFileEnd,omitempty"`
+	OrigLeftFileStart        *CommentPosition `json:"origLeftFileStart,omitempty"`
+	OrigRightFileEnd         *CommentPosition `json:"origRightFileEnd,omitempty"`
+	OrigRightFileStart       *CommentPosition `json:"origRightFileStart,omitempty"`
+	SecondComparingIteration *int             `json:"secondComparingIteration,omitempty"`
+}
+
 // GitPullRequestWithComment contains a reference to an existing pull request and a
 // comment.
 type GitPullRequestWithComment struct {
@@ -357,16 +428,33 @@ type GitPullRequestWithComment struct {
 	PullRequest *GitPullRequest `json:"pullRequest,omitempty"`
 }
 
-// ListCommits lists the commits in a pull request.
+// PullRequestCommitsListOptions describes the paging parameters of
+// ListCommitsPages and ListAllCommits
+type PullRequestCommitsListOptions struct {
+	Top               int    `url:"$top,omitempty"`
+	ContinuationToken string `url:"continuationToken,omitempty"`
+}
+
+// ListCommits lists the commits in a pull request. It returns a single page
+// of commits; use ListCommitsPages or ListAllCommits to walk every page.
 // Azure Devops API docs: https://docs.microsoft.com/en-us/rest/api/azure/devops/git/pull%20request%20commits/get%20pull%20request%20commits
 //
-func (s *PullRequestsService) ListCommits(ctx context.Context, owner, project, repo string, pullNum int) ([]*GitCommitRef, *http.Response, error) {
-	URL := fmt.Sprintf("%s/%s/_apis/git/repositories/%s/pullrequests/%d/commits?api-version=5.1-preview.1",
+func (s *PullRequestsService) ListCommits(ctx context.Context, owner, project, repo string, pullNum int) ([]*GitCommitRef, *Response, error) {
+	return s.listCommits(ctx, owner, project, repo, pullNum, nil)
+}
+
+func (s *PullRequestsService) listCommits(ctx context.Context, owner, project, repo string, pullNum int, opts *PullRequestCommitsListOptions) ([]*GitCommitRef, *Response, error) {
+	URL := fmt.Sprintf("%s/%s/_apis/git/repositories/%s/pullrequests/%d/commits?api-version=%s",
 		owner,
 		project,
 		repo,
 		pullNum,
+		s.client.apiVersion("git", "pullRequestCommits"),
 	)
+	URL, err := addOptions(URL, opts)
+	if err != nil {
+		return nil, nil, err
+	}
 
 	req, err := s.client.NewRequest("GET", URL, nil)
 	if err != nil {
@@ -382,16 +470,45 @@ func (s *PullRequestsService) ListCommits(ctx context.Context, owner, project, r
 	return r.GitCommitRefs, resp, err
 }
 
+// ListCommitsPages walks every page of commits in a pull request, calling fn
+// with each page. Pages are requested with continuation tokens.
+func (s *PullRequestsService) ListCommitsPages(ctx context.Context, owner, project, repo string, pullNum int, opts *PullRequestCommitsListOptions, fn func([]*GitCommitRef, *Response) error) error {
+	o := PullRequestCommitsListOptions{}
+	if opts != nil {
+		o = *opts
+	}
+	return walkContinuation(o.ContinuationToken, func(token string) (*Response, error) {
+		o.ContinuationToken = token
+		commits, resp, err := s.listCommits(ctx, owner, project, repo, pullNum, &o)
+		if err != nil {
+			return nil, err
+		}
+		return resp, fn(commits, resp)
+	})
+}
+
+// ListAllCommits returns every commit in a pull request, walking all pages of
+// results.
+func (s *PullRequestsService) ListAllCommits(ctx context.Context, owner, project, repo string, pullNum int, opts *PullRequestCommitsListOptions) ([]*GitCommitRef, error) {
+	var all []*GitCommitRef
+	err := s.ListCommitsPages(ctx, owner, project, repo, pullNum, opts, func(commits []*GitCommitRef, _ *Response) error {
+		all = append(all, commits...)
+		return nil
+	})
+	return all, err
+}
+
 // CreateComment adds a comment to a pull request thread.
 // Azure Devops API docs: https://docs.microsoft.com/en-us/rest/api/azure/devops/git/pull%20request%20thread%20comments/create
 //
-func (s *PullRequestsService) CreateComment(ctx context.Context, owner, project, repo string, pullNum int, threadId int, comment *Comment) (*Comment, *http.Response, error) {
-	URL := fmt.Sprintf("%s/%s/_apis/git/repositories/%s/pullrequests/%d/threads/%d/comments?api-version=5.1-preview.1",
+func (s *PullRequestsService) CreateComment(ctx context.Context, owner, project, repo string, pullNum int, threadId int, comment *Comment) (*Comment, *Response, error) {
+	URL := fmt.Sprintf("%s/%s/_apis/git/repositories/%s/pullrequests/%d/threads/%d/comments?api-version=%s",
 		owner,
 		project,
 		repo,
 		pullNum,
 		threadId,
+		s.client.apiVersion("git", "pullRequestThreadComments"),
 	)
 
 	if comment.GetContent() == "" {
@@ -420,12 +537,13 @@ func (s *PullRequestsService) CreateComment(ctx context.Context, owner, project,
 // and may include additional context
 // Azure Devops API docs: https://docs.microsoft.com/en-us/rest/api/azure/devops/git/pull%20request%20threads/create
 //
-func (s *PullRequestsService) CreateComments(ctx context.Context, owner, project, repo string, pullNum int, body *GitPullRequestCommentThread) (*GitPullRequestCommentThread, *http.Response, error) {
-	URL := fmt.Sprintf("%s/%s/_apis/git/repositories/%s/pullrequests/%d/threads?api-version=5.1-preview.1",
+func (s *PullRequestsService) CreateComments(ctx context.Context, owner, project, repo string, pullNum int, body *GitPullRequestCommentThread) (*GitPullRequestCommentThread, *Response, error) {
+	URL := fmt.Sprintf("%s/%s/_apis/git/repositories/%s/pullrequests/%d/threads?api-version=%s",
 		owner,
 		project,
 		repo,
 		pullNum,
+		s.client.apiVersion("git", "pullRequestThreads"),
 	)
 
 	if len(body.Comments) == 0 {
@@ -459,12 +577,13 @@ func (s *PullRequestsService) CreateComments(ctx context.Context, owner, project
 // CreateStatus Create a pull request status.
 // Azure Devops API docs: https://docs.microsoft.com/en-us/rest/api/azure/devops/git/pull%20request%20statuses/create
 //
-func (s *PullRequestsService) CreateStatus(ctx context.Context, owner, project, repo string, pullNum int, status *GitPullRequestStatus) (*GitPullRequestStatus, *http.Response, error) {
-	URL := fmt.Sprintf("%s/%s/_apis/git/repositories/%s/pullrequests/%d/statuses?api-version=5.1-preview.1",
+func (s *PullRequestsService) CreateStatus(ctx context.Context, owner, project, repo string, pullNum int, status *GitPullRequestStatus) (*GitPullRequestStatus, *Response, error) {
+	URL := fmt.Sprintf("%s/%s/_apis/git/repositories/%s/pullrequests/%d/statuses?api-version=%s",
 		owner,
 		project,
 		repo,
 		pullNum,
+		s.client.apiVersion("git", "pullRequestStatuses"),
 	)
 
 	if context := status.GetContext(); context != nil {
@@ -490,13 +609,14 @@ func (s *PullRequestsService) CreateStatus(ctx context.Context, owner, project,
 // GetIteration Gets a single pull request iteration.
 // Azure Devops API docs: https://docs.microsoft.com/en-us/rest/api/azure/devops/git/pull%20request%20iterations/get?view=azure-devops-rest-5.1
 //
-func (s *PullRequestsService) GetIteration(ctx context.Context, owner, project, repo string, pullNum int, iterationID int) (*GitPullRequestIteration, *http.Response, error) {
-	URL := fmt.Sprintf("%s/%s/_apis/git/repositories/%s/pullrequests/%d/iterations/%d?api-version=5.1",
+func (s *PullRequestsService) GetIteration(ctx context.Context, owner, project, repo string, pullNum int, iterationID int) (*GitPullRequestIteration, *Response, error) {
+	URL := fmt.Sprintf("%s/%s/_apis/git/repositories/%s/pullrequests/%d/iterations/%d?api-version=%s",
 		owner,
 		project,
 		repo,
 		pullNum,
 		iterationID,
+		s.client.apiVersion("git", "pullRequestIterations"),
 	)
 
 	req, err := s.client.NewRequest("GET", URL, nil)
@@ -516,12 +636,13 @@ func (s *PullRequestsService) GetIteration(ctx context.Context, owner, project,
 // ListIterations Lists all iterations on a pull request.
 // Azure Devops API docs: https://docs.microsoft.com/en-us/rest/api/azure/devops/git/pull%20request%20iterations/list?view=azure-devops-rest-5.1
 //
-func (s *PullRequestsService) ListIterations(ctx context.Context, owner, project, repo string, pullNum int, opts *PullRequestIterationsListOptions) ([]*GitPullRequestIteration, *http.Response, error) {
-	URL := fmt.Sprintf("%s/%s/_apis/git/repositories/%s/pullrequests/%d/iterations?api-version=5.1",
+func (s *PullRequestsService) ListIterations(ctx context.Context, owner, project, repo string, pullNum int, opts *PullRequestIterationsListOptions) ([]*GitPullRequestIteration, *Response, error) {
+	URL := fmt.Sprintf("%s/%s/_apis/git/repositories/%s/pullrequests/%d/iterations?api-version=%s",
 		owner,
 		project,
 		repo,
 		pullNum,
+		s.client.apiVersion("git", "pullRequestIterations"),
 	)
 
 	URL, err := addOptions(URL, opts)
@@ -539,3 +660,42 @@ func (s *PullRequestsService) ListIterations(ctx context.Context, owner, project
 
 	return r.GitPullRequestIterations, resp, err
 }
+
+// PullRequestIterationChangesOptions describes the parameters of the pull
+// request iteration changes API. CompareTo is the iteration to compare
+// against; when nil the changes are relative to the target branch.
+type PullRequestIterationChangesOptions struct {
+	CompareTo *int `url:"$compareTo,omitempty"`
+	Skip      *int `url:"$skip,omitempty"`
+	Top       *int `url:"$top,omitempty"`
+}
+
+// GetIterationChanges Retrieve the changes made in a pull request between
+// two iterations. Each change carries the change tracking ID used to anchor
+// comment threads to a file.
+// Azure Devops API docs: https://docs.microsoft.com/en-us/rest/api/azure/devops/git/pull%20request%20iteration%20changes/get?view=azure-devops-rest-5.1
+//
+func (s *PullRequestsService) GetIterationChanges(ctx context.Context, owner, project, repo string, pullNum int, iterationID int, opts *PullRequestIterationChangesOptions) (*GitPullRequestIterationChanges, *Response, error) {
+	URL := fmt.Sprintf("%s/%s/_apis/git/repositories/%s/pullrequests/%d/iterations/%d/changes?api-version=%s",
+		owner,
+		project,
+		repo,
+		pullNum,
+		iterationID,
+		s.client.apiVersion("git", "pullRequestIterationChanges"),
+	)
+	URL, err := addOptions(URL, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := s.client.NewRequest("GET", URL, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := new(GitPullRequestIterationChanges)
+	resp, err := s.client.Execute(ctx, req, r)
+
+	return r, resp, err
+}
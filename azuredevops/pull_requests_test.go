@@ -145,6 +145,33 @@ func TestPullRequestsService_ListCommits(t *testing.T) {
 	}
 }
 
+func TestPullRequestsService_ListAllCommits(t *testing.T) {
+	c, mux, _, teardown := setup()
+	defer teardown()
+	mux.HandleFunc("/o/p/_apis/git/repositories/r/pullrequests/22/commits", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		switch token := r.FormValue("continuationToken"); token {
+		case "":
+			testFormValues(t, r, values{"$top": "1"})
+			w.Header().Set("X-Ms-Continuationtoken", "next")
+			fmt.Fprint(w, `{"count": 1, "value": [{"commitId": "a"}]}`)
+		case "next":
+			fmt.Fprint(w, `{"count": 1, "value": [{"commitId": "b"}]}`)
+		default:
+			t.Errorf("Unexpected continuationToken %q", token)
+		}
+	})
+
+	opts := &azuredevops.PullRequestCommitsListOptions{Top: 1}
+	got, err := c.PullRequests.ListAllCommits(context.Background(), "o", "p", "r", 22, opts)
+	if err != nil {
+		t.Fatalf("PullRequests.ListAllCommits returned error: %v", err)
+	}
+	if len(got) != 2 || got[0].GetCommitID() != "a" || got[1].GetCommitID() != "b" {
+		t.Errorf("PullRequests.ListAllCommits returned %+v", got)
+	}
+}
+
 func TestPullRequestsService_Get(t *testing.T) {
 	c, mux, _, teardown := setup()
 	defer teardown()
@@ -294,6 +321,26 @@ func TestPullRequestsService_Create(t *testing.T) {
 	}
 }
 
+func TestPullRequestsService_Create_nestedBranch(t *testing.T) {
+	c, mux, _, teardown := setup()
+	defer teardown()
+	mux.HandleFunc("/o/p/_apis/git/repositories/r/pullrequests", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testBody(t, r, `{"completionQueueTime":null,"description":"d","sourceRefName":"refs/heads/feature/x","targetRefName":"refs/heads/release/1.0","title":"t"}`+"\n")
+		fmt.Fprint(w, `{"pullRequestId": 10}`)
+	})
+
+	pull := &azuredevops.GitPullRequest{
+		Title:         String("t"),
+		Description:   String("d"),
+		SourceRefName: String("refs/heads/feature/x"),
+		TargetRefName: String("release/1.0"),
+	}
+	if _, _, err := c.PullRequests.Create(context.Background(), "o", "p", "r", pull); err != nil {
+		t.Errorf("PullRequests.Create returned error: %v", err)
+	}
+}
+
 func TestPullRequestsService_GetWithRepo(t *testing.T) {
 	c, mux, _, teardown := setup()
 	defer teardown()
@@ -634,3 +681,49 @@ func TestPullRequestsService_ListIterations(t *testing.T) {
 		t.Errorf("PullRequests.ListIterations IDs don't match ID 0 = %+v ID 1 = %+v, want ID 0 = %+v ID 1 = %+v", *got[0].ID, *got[1].ID, *want[0].ID, *want[1].ID)
 	}
 }
+
+func TestPullRequestsService_GetIterationChanges(t *testing.T) {
+	c, mux, _, teardown := setup()
+	defer teardown()
+	mux.HandleFunc("/o/p/_apis/git/repositories/r/pullrequests/1/iterations/3/changes", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{
+			"$compareTo": "1",
+		})
+		fmt.Fprint(w, `{
+			"changeEntries": [
+				{"changeTrackingId": 1, "changeId": 1, "item": {"path": "/README.md"}, "changeType": "edit"},
+				{"changeTrackingId": 2, "changeId": 2, "item": {"path": "/new.go"}, "changeType": "rename", "originalPath": "/old.go"}
+			]
+		}`)
+	})
+
+	opts := &azuredevops.PullRequestIterationChangesOptions{CompareTo: Int(1)}
+	got, _, err := c.PullRequests.GetIterationChanges(context.Background(), "o", "p", "r", 1, 3, opts)
+	if err != nil {
+		t.Fatalf("PullRequests.GetIterationChanges returned error: %v", err)
+	}
+	if len(got.ChangeEntries) != 2 {
+		t.Fatalf("PullRequests.GetIterationChanges returned %+v", got)
+	}
+	if e := got.ChangeEntries[1]; e.GetChangeTrackingID() != 2 || e.GetItem().GetPath() != "/new.go" || e.GetOriginalPath() != "/old.go" {
+		t.Errorf("PullRequests.GetIterationChanges entry = %+v", e)
+	}
+}
+
+func TestPullRequestsService_GetIterationChanges_error(t *testing.T) {
+	c, mux, _, teardown := setup()
+	defer teardown()
+	mux.HandleFunc("/o/p/_apis/git/repositories/r/pullrequests/1/iterations/3/changes", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"message": "iteration not found"}`)
+	})
+
+	_, resp, err := c.PullRequests.GetIterationChanges(context.Background(), "o", "p", "r", 1, 3, nil)
+	if err == nil {
+		t.Fatal("Expected error to be returned.")
+	}
+	if resp == nil || resp.StatusCode != http.StatusNotFound {
+		t.Errorf("PullRequests.GetIterationChanges returned response %+v, want the 404 response", resp)
+	}
+}
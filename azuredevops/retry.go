@@ -0,0 +1,163 @@
+package azuredevops
+
+import (
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RateLimit describes the global rate limit state reported by Azure DevOps in
+// the response headers. The headers are only present once a caller is close
+// to, or has exceeded, its limit.
+// https://docs.microsoft.com/en-us/azure/devops/integrate/concepts/rate-limits
+type RateLimit struct {
+	// Resource is the service and type of threshold that was reached, from
+	// the X-RateLimit-Resource header.
+	Resource string
+	// Delay is how long the request was delayed by the service, from the
+	// X-RateLimit-Delay header.
+	Delay time.Duration
+	// Limit is the total number of TSTUs allowed before delays are imposed,
+	// from the X-RateLimit-Limit header.
+	Limit int
+	// Remaining is the number of TSTUs remaining before the caller is
+	// delayed, from the X-RateLimit-Remaining header.
+	Remaining int
+	// Reset is the time at which usage will be back to zero, from the
+	// X-RateLimit-Reset header.
+	Reset time.Time
+	// RetryAfter is how long the caller should wait before sending another
+	// request, from the Retry-After header.
+	RetryAfter time.Duration
+}
+
+// Throttled reports whether the service reported any rate limiting for the
+// response.
+func (r RateLimit) Throttled() bool {
+	return r.Resource != "" || r.Delay > 0 || r.RetryAfter > 0
+}
+
+// parseRateLimit parses the rate limit headers of r.
+func parseRateLimit(r *http.Response) RateLimit {
+	var rate RateLimit
+	if r == nil {
+		return rate
+	}
+	rate.Resource = r.Header.Get("X-RateLimit-Resource")
+	if delay := r.Header.Get("X-RateLimit-Delay"); delay != "" {
+		if f, err := strconv.ParseFloat(delay, 64); err == nil {
+			rate.Delay = time.Duration(f * float64(time.Second))
+		}
+	}
+	if limit := r.Header.Get("X-RateLimit-Limit"); limit != "" {
+		rate.Limit, _ = strconv.Atoi(limit)
+	}
+	if remaining := r.Header.Get("X-RateLimit-Remaining"); remaining != "" {
+		rate.Remaining, _ = strconv.Atoi(remaining)
+	}
+	if reset := r.Header.Get("X-RateLimit-Reset"); reset != "" {
+		if v, err := strconv.ParseInt(reset, 10, 64); err == nil {
+			rate.Reset = time.Unix(v, 0)
+		}
+	}
+	rate.RetryAfter = parseRetryAfter(r.Header.Get("Retry-After"))
+	return rate
+}
+
+// parseRetryAfter parses a Retry-After header, which is either a number of
+// seconds or an HTTP date.
+func parseRetryAfter(v string) time.Duration {
+	if v == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(v); err == nil {
+		return time.Duration(seconds) * time.Second
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		if d := time.Until(t); d > 0 {
+			return d
+		}
+	}
+	return 0
+}
+
+// RetryPolicy describes how the client retries requests which were throttled
+// (429) or failed with a transient server error (502, 503, 504), or which
+// could not be sent because of a network error.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of times a request is sent,
+	// including the first attempt. Values below 2 disable retries.
+	MaxAttempts int
+	// MinBackoff is the base delay before the first retry. The delay doubles
+	// with each further attempt, and is randomised by up to half its value.
+	MinBackoff time.Duration
+	// MaxBackoff caps the delay between attempts. It does not apply to
+	// delays requested by the service through the Retry-After header.
+	MaxBackoff time.Duration
+	// RetryNonIdempotent allows POST and PATCH requests to be retried. These
+	// may have been processed by the service even though they failed.
+	RetryNonIdempotent bool
+}
+
+// DefaultRetryPolicy returns the RetryPolicy used by NewClient.
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts: 3,
+		MinBackoff:  time.Second,
+		MaxBackoff:  30 * time.Second,
+	}
+}
+
+// retry reports whether req should be sent again after attempt, and how long
+// to wait before doing so.
+func (p *RetryPolicy) retry(req *http.Request, resp *http.Response, err error, attempt int) (time.Duration, bool) {
+	if p == nil || attempt >= p.MaxAttempts {
+		return 0, false
+	}
+	if !p.RetryNonIdempotent && !isIdempotent(req.Method) {
+		return 0, false
+	}
+	// The request body has been consumed and can't be sent again.
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return 0, false
+	}
+	if err != nil {
+		return p.backoff(attempt), true
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+	default:
+		return 0, false
+	}
+	if d := parseRetryAfter(resp.Header.Get("Retry-After")); d > 0 {
+		return d, true
+	}
+	return p.backoff(attempt), true
+}
+
+// backoff returns the delay before retrying after attempt.
+func (p *RetryPolicy) backoff(attempt int) time.Duration {
+	d := p.MinBackoff
+	for i := 1; i < attempt && (p.MaxBackoff <= 0 || d < p.MaxBackoff); i++ {
+		d *= 2
+	}
+	if p.MaxBackoff > 0 && d > p.MaxBackoff {
+		d = p.MaxBackoff
+	}
+	if half := int64(d / 2); half > 0 {
+		d = time.Duration(half + rand.Int63n(half))
+	}
+	return d
+}
+
+// isIdempotent reports whether requests using method may safely be sent more
+// than once.
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,178 @@
+package azuredevops_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/mcdafydd/go-azuredevops/azuredevops"
+)
+
+func testRetryPolicy() *azuredevops.RetryPolicy {
+	return &azuredevops.RetryPolicy{
+		MaxAttempts: 3,
+		MinBackoff:  time.Millisecond,
+		MaxBackoff:  5 * time.Millisecond,
+	}
+}
+
+func TestExecute_retriesTransientErrors(t *testing.T) {
+	c, mux, _, teardown := setup()
+	defer teardown()
+	c.RetryPolicy = testRetryPolicy()
+
+	attempts := 0
+	mux.HandleFunc(gitRepositoryURL, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		attempts++
+		if attempts < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprint(w, `{"id": "2f3d611a-f012-4b39-b157-8db63f380226"}`)
+	})
+
+	repo, _, err := c.Git.GetRepository(context.Background(), "o", "p", "r")
+	if err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+	if attempts != 3 {
+		t.Errorf("expected 3 attempts, got %d", attempts)
+	}
+	if got, want := repo.GetID(), "2f3d611a-f012-4b39-b157-8db63f380226"; got != want {
+		t.Errorf("repository ID = %s, want %s", got, want)
+	}
+}
+
+func TestExecute_retryAttemptsExhausted(t *testing.T) {
+	c, mux, _, teardown := setup()
+	defer teardown()
+	c.RetryPolicy = testRetryPolicy()
+
+	attempts := 0
+	mux.HandleFunc(gitRepositoryURL, func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.Header().Set("X-RateLimit-Resource", "ATCPU")
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+
+	_, _, err := c.Git.GetRepository(context.Background(), "o", "p", "r")
+	if !errors.Is(err, azuredevops.ErrRateLimited) {
+		t.Fatalf("expected ErrRateLimited, got %v", err)
+	}
+	var errResp *azuredevops.ErrorResponse
+	if errors.As(err, &errResp) && errResp.RateLimit.Resource != "ATCPU" {
+		t.Errorf("RateLimit.Resource = %q, want %q", errResp.RateLimit.Resource, "ATCPU")
+	}
+	if attempts != 3 {
+		t.Errorf("expected 3 attempts, got %d", attempts)
+	}
+}
+
+func TestExecute_nonIdempotentNotRetried(t *testing.T) {
+	c, mux, _, teardown := setup()
+	defer teardown()
+	c.RetryPolicy = testRetryPolicy()
+
+	attempts := 0
+	mux.HandleFunc("/o/p/_apis/build/builds", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		attempts++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+
+	_, _, err := c.Builds.Queue(context.Background(), "o", "p", &azuredevops.Build{}, nil)
+	if err == nil {
+		t.Fatal("expected an error, got none")
+	}
+	if attempts != 1 {
+		t.Errorf("expected 1 attempt, got %d", attempts)
+	}
+}
+
+func TestExecute_retryNonIdempotentResendsBody(t *testing.T) {
+	c, mux, _, teardown := setup()
+	defer teardown()
+	c.RetryPolicy = testRetryPolicy()
+	c.RetryPolicy.RetryNonIdempotent = true
+
+	attempts := 0
+	mux.HandleFunc("/o/p/_apis/build/builds", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testBody(t, r, `{"definition":{"id":1}}`+"\n")
+		attempts++
+		if attempts == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		fmt.Fprint(w, `{"id": 1}`)
+	})
+
+	build := &azuredevops.Build{Definition: &azuredevops.BuildDefinition{ID: Int(1)}}
+	_, _, err := c.Builds.Queue(context.Background(), "o", "p", build, nil)
+	if err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+	if attempts != 2 {
+		t.Errorf("expected 2 attempts, got %d", attempts)
+	}
+}
+
+func TestExecute_retryAfterRespectsContext(t *testing.T) {
+	c, mux, _, teardown := setup()
+	defer teardown()
+	c.RetryPolicy = testRetryPolicy()
+
+	mux.HandleFunc(gitRepositoryURL, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "60")
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, _, err := c.Git.GetRepository(ctx, "o", "p", "r")
+	if err != context.DeadlineExceeded {
+		t.Fatalf("expected context.DeadlineExceeded, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 10*time.Second {
+		t.Errorf("request was not interrupted by the context, took %v", elapsed)
+	}
+}
+
+func TestResponse_RateLimit(t *testing.T) {
+	c, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc(gitRepositoryURL, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Resource", "ATCPU")
+		w.Header().Set("X-RateLimit-Delay", "0.5")
+		w.Header().Set("X-RateLimit-Limit", "200")
+		w.Header().Set("X-RateLimit-Remaining", "42")
+		w.Header().Set("X-RateLimit-Reset", "1586614800")
+		fmt.Fprint(w, `{}`)
+	})
+
+	_, resp, err := c.Git.GetRepository(context.Background(), "o", "p", "r")
+	if err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+
+	want := azuredevops.RateLimit{
+		Resource:  "ATCPU",
+		Delay:     500 * time.Millisecond,
+		Limit:     200,
+		Remaining: 42,
+		Reset:     time.Unix(1586614800, 0),
+	}
+	if got := resp.RateLimit; got != want {
+		t.Errorf("RateLimit = %+v, want %+v", got, want)
+	}
+	if !resp.RateLimit.Throttled() {
+		t.Errorf("Throttled() = false, want true")
+	}
+}
@@ -3,7 +3,6 @@ package azuredevops
 import (
 	"context"
 	"fmt"
-	"net/http"
 )
 
 // TeamsService handles communication with the teams methods on the API
@@ -69,10 +68,11 @@ type TeamProjectReference struct {
 // List returns list of the teams
 // https://docs.microsoft.com/en-us/rest/api/azure/devops/core/teams/get%20teams
 // GET https://dev.azure.com/{organization}/_apis/projects/{projectId}/teams?api-version=5.1-preview.2
-func (s *TeamsService) List(ctx context.Context, owner, project string, opts *TeamsListOptions) ([]*Team, *http.Response, error) {
-	URL := fmt.Sprintf("%s/%s/_apis/teams?api-version=5.1-preview.1",
+func (s *TeamsService) List(ctx context.Context, owner, project string, opts *TeamsListOptions) ([]*Team, *Response, error) {
+	URL := fmt.Sprintf("%s/%s/_apis/teams?api-version=%s",
 		owner,
 		project,
+		s.client.apiVersion("core", "teams"),
 	)
 	URL, err := addOptions(URL, opts)
 
@@ -86,3 +86,30 @@ func (s *TeamsService) List(ctx context.Context, owner, project string, opts *Te
 
 	return r.Teams, resp, err
 }
+
+// ListPages walks every page of teams matching opts, calling fn with each
+// page. Pages are requested with $top and $skip, starting at opts.Skip.
+func (s *TeamsService) ListPages(ctx context.Context, owner, project string, opts *TeamsListOptions, fn func([]*Team, *Response) error) error {
+	o := TeamsListOptions{}
+	if opts != nil {
+		o = *opts
+	}
+	return walkSkip(o.GetSkip(), o.GetTop(), func(skip, top int) (int, error) {
+		o.Skip, o.Top = Int(skip), Int(top)
+		teams, resp, err := s.List(ctx, owner, project, &o)
+		if err != nil {
+			return 0, err
+		}
+		return len(teams), fn(teams, resp)
+	})
+}
+
+// ListAll returns every team matching opts, walking all pages of results.
+func (s *TeamsService) ListAll(ctx context.Context, owner, project string, opts *TeamsListOptions) ([]*Team, error) {
+	var all []*Team
+	err := s.ListPages(ctx, owner, project, opts, func(teams []*Team, _ *Response) error {
+		all = append(all, teams...)
+		return nil
+	})
+	return all, err
+}
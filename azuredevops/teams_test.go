@@ -73,3 +73,32 @@ func TestTeamsService_List(t *testing.T) {
 		})
 	}
 }
+
+func TestTeamsService_ListAll(t *testing.T) {
+	c, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc(teamsListURL, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{"$top": "2", "$skip": r.URL.Query().Get("$skip")})
+		switch skip := r.URL.Query().Get("$skip"); skip {
+		case "0":
+			fmt.Fprint(w, `{"count": 2, "value": [{"id": "1"}, {"id": "2"}]}`)
+		case "2":
+			fmt.Fprint(w, `{"count": 1, "value": [{"id": "3"}]}`)
+		default:
+			t.Errorf("unexpected $skip %q", skip)
+		}
+	})
+
+	teams, err := c.Teams.ListAll(context.Background(), "o", "p", &azuredevops.TeamsListOptions{Top: Int(2)})
+	if err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+	if len(teams) != 3 {
+		t.Fatalf("expected 3 teams, got %d", len(teams))
+	}
+	if got := teams[2].GetID(); got != "3" {
+		t.Errorf("teams[2].ID = %s, want 3", got)
+	}
+}
@@ -2,8 +2,8 @@ package azuredevops
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"net/http"
 )
 
 // TestsService handles communication with the Tests methods on the API
@@ -41,15 +41,17 @@ type Test struct {
 // TestsListOptions describes what the request to the API should look like
 type TestsListOptions struct {
 	Count    *int    `url:"$top,omitempty"`
+	Skip     *int    `url:"$skip,omitempty"`
 	BuildURI *string `url:"buildUri,omitempty"`
 }
 
 // List returns list of the tests
 // utilising https://docs.microsoft.com/en-gb/rest/api/vsts/test/runs/list
-func (s *TestsService) List(ctx context.Context, owner, project string, opts *TestsListOptions) ([]*Test, *http.Response, error) {
-	URL := fmt.Sprintf("%s/%s/_apis/test/runs?api-version=4.1",
+func (s *TestsService) List(ctx context.Context, owner, project string, opts *TestsListOptions) ([]*Test, *Response, error) {
+	URL := fmt.Sprintf("%s/%s/_apis/test/runs?api-version=%s",
 		owner,
 		project,
+		s.client.apiVersion("test", "runs"),
 	)
 	URL, err := addOptions(URL, opts)
 
@@ -63,6 +65,33 @@ func (s *TestsService) List(ctx context.Context, owner, project string, opts *Te
 	return r.Tests, resp, err
 }
 
+// ListPages walks every page of test runs matching opts, calling fn with each
+// page. Pages are requested with $top and $skip, starting at opts.Skip.
+func (s *TestsService) ListPages(ctx context.Context, owner, project string, opts *TestsListOptions, fn func([]*Test, *Response) error) error {
+	o := TestsListOptions{}
+	if opts != nil {
+		o = *opts
+	}
+	return walkSkip(o.GetSkip(), o.GetCount(), func(skip, top int) (int, error) {
+		o.Skip, o.Count = Int(skip), Int(top)
+		tests, resp, err := s.List(ctx, owner, project, &o)
+		if err != nil {
+			return 0, err
+		}
+		return len(tests), fn(tests, resp)
+	})
+}
+
+// ListAll returns every test run matching opts, walking all pages of results.
+func (s *TestsService) ListAll(ctx context.Context, owner, project string, opts *TestsListOptions) ([]*Test, error) {
+	var all []*Test
+	err := s.ListPages(ctx, owner, project, opts, func(tests []*Test, _ *Response) error {
+		all = append(all, tests...)
+		return nil
+	})
+	return all, err
+}
+
 // TestResultsListResponse is the wrapper around the main response for the List of Tests
 type TestResultsListResponse struct {
 	Results []TestResult `json:"value"`
@@ -134,26 +163,67 @@ type TestResult struct {
 // TestResultsListOptions describes what the request to the API should look like
 type TestResultsListOptions struct {
 	Count int    `url:"$top,omitempty"`
+	Skip  int    `url:"$skip,omitempty"`
 	RunID string `url:"runId,omitempty"`
 }
 
 // ResultsList returns list of the test results
 // utilising https://docs.microsoft.com/en-gb/rest/api/vsts/test/runs/list
 func (s *TestsService) ResultsList(ctx context.Context, owner, project string, opts *TestResultsListOptions) ([]TestResult, error) {
-	URL := fmt.Sprintf("%s/%s/_apis/test/Runs/%s/results?api-version=4.1",
+	results, _, err := s.resultsList(ctx, owner, project, opts)
+	return results, err
+}
+
+func (s *TestsService) resultsList(ctx context.Context, owner, project string, opts *TestResultsListOptions) ([]TestResult, *Response, error) {
+	URL := fmt.Sprintf("%s/%s/_apis/test/Runs/%s/results?api-version=%s",
 		owner,
 		project,
 		opts.RunID,
+		s.client.apiVersion("test", "results"),
 	)
 	opts.RunID = ""
 	URL, err := addOptions(URL, opts)
 
 	request, err := s.client.NewRequest("GET", URL, nil)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	var response TestResultsListResponse
-	_, err = s.client.Execute(ctx, request, &response)
+	resp, err := s.client.Execute(ctx, request, &response)
+
+	return response.Results, resp, err
+}
+
+// ResultsListPages walks every page of test results of the run in
+// opts.RunID, calling fn with each page. Pages are requested with $top and
+// $skip, starting at opts.Skip.
+func (s *TestsService) ResultsListPages(ctx context.Context, owner, project string, opts *TestResultsListOptions, fn func([]TestResult, *Response) error) error {
+	o := TestResultsListOptions{}
+	if opts != nil {
+		o = *opts
+	}
+	if o.RunID == "" {
+		return errors.New("Tests.ResultsListPages: Empty RunID in opts")
+	}
+	return walkSkip(o.Skip, o.Count, func(skip, top int) (int, error) {
+		// resultsList clears RunID, so each page gets a fresh copy.
+		page := o
+		page.Skip, page.Count = skip, top
+		results, resp, err := s.resultsList(ctx, owner, project, &page)
+		if err != nil {
+			return 0, err
+		}
+		return len(results), fn(results, resp)
+	})
+}
 
-	return response.Results, err
+// ResultsListAll returns every test result of the run in opts.RunID, walking
+// all pages of results with $top and $skip.
+func (s *TestsService) ResultsListAll(ctx context.Context, owner, project string, opts *TestResultsListOptions) ([]TestResult, error) {
+	var all []TestResult
+	err := s.ResultsListPages(ctx, owner, project, opts, func(results []TestResult, _ *Response) error {
+		all = append(all, results...)
+		return nil
+	})
+	return all, err
 }
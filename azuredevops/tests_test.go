@@ -215,3 +215,45 @@ func TestTestsService_ResultsList(t *testing.T) {
 		})
 	}
 }
+
+func TestTestsService_ResultsListPages(t *testing.T) {
+	c, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc(testResultsListURL, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		switch r.FormValue("$skip") {
+		case "":
+			fmt.Fprint(w, `{"value": [{"outcome": "Passed"}, {"outcome": "Failed"}]}`)
+		case "2":
+			fmt.Fprint(w, `{"value": [{"outcome": "Passed"}]}`)
+		default:
+			t.Errorf("Unexpected $skip %q", r.FormValue("$skip"))
+		}
+	})
+
+	var pages []int
+	opts := &azuredevops.TestResultsListOptions{RunID: "1", Count: 2}
+	err := c.Tests.ResultsListPages(context.Background(), "o", "p", opts, func(results []azuredevops.TestResult, _ *azuredevops.Response) error {
+		pages = append(pages, len(results))
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Tests.ResultsListPages returned error: %v", err)
+	}
+	if fmt.Sprint(pages) != "[2 1]" {
+		t.Errorf("Tests.ResultsListPages pages = %v, want [2 1]", pages)
+	}
+	if opts.RunID != "1" {
+		t.Errorf("Tests.ResultsListPages changed opts.RunID to %q", opts.RunID)
+	}
+}
+
+func TestTestsService_ResultsListAll_nilOptions(t *testing.T) {
+	c, _, _, teardown := setup()
+	defer teardown()
+
+	if _, err := c.Tests.ResultsListAll(context.Background(), "o", "p", nil); err == nil {
+		t.Error("Tests.ResultsListAll without a RunID returned no error")
+	}
+}
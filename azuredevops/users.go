@@ -3,7 +3,6 @@ package azuredevops
 import (
 	"context"
 	"fmt"
-	"net/http"
 )
 
 // UsersService handles communication with the Graph.Users methods on the API
@@ -125,11 +124,12 @@ type GraphUsersListResponse struct {
 
 // Get returns information about a single user in an org
 // https://docs.microsoft.com/en-us/rest/api/azure/devops/graph/users/get
-func (s *UsersService) Get(ctx context.Context, owner, descriptor string) (*GraphUser, *http.Response, error) {
-	URL := fmt.Sprintf("%s%s/_apis/graph/users/%s?api-version=5.1-preview.1",
+func (s *UsersService) Get(ctx context.Context, owner, descriptor string) (*GraphUser, *Response, error) {
+	URL := fmt.Sprintf("%s%s/_apis/graph/users/%s?api-version=%s",
 		s.client.VsspsBaseURL.String(),
 		owner,
 		descriptor,
+		s.client.apiVersion("graph", "users"),
 	)
 
 	request, err := s.client.NewRequest("GET", URL, nil)
@@ -144,11 +144,25 @@ func (s *UsersService) Get(ctx context.Context, owner, descriptor string) (*Grap
 
 // List returns a list of users in an org
 // utilising https://docs.microsoft.com/en-us/rest/api/azure/devops/graph/users/list
-func (s *UsersService) List(ctx context.Context, owner string) ([]*GraphUser, *http.Response, error) {
-	URL := fmt.Sprintf("%s%s/_apis/graph/users?api-version=5.1-preview.1",
+func (s *UsersService) List(ctx context.Context, owner string) ([]*GraphUser, *Response, error) {
+	return s.list(ctx, owner, &usersListOptions{})
+}
+
+// usersListOptions describes the paging parameters of the users list API
+type usersListOptions struct {
+	ContinuationToken string `url:"continuationToken,omitempty"`
+}
+
+func (s *UsersService) list(ctx context.Context, owner string, opts *usersListOptions) ([]*GraphUser, *Response, error) {
+	URL := fmt.Sprintf("%s%s/_apis/graph/users?api-version=%s",
 		s.client.VsspsBaseURL.String(),
 		owner,
+		s.client.apiVersion("graph", "users"),
 	)
+	URL, err := addOptions(URL, opts)
+	if err != nil {
+		return nil, nil, err
+	}
 
 	request, err := s.client.NewRequest("GET", URL, nil)
 	if err != nil {
@@ -160,6 +174,28 @@ func (s *UsersService) List(ctx context.Context, owner string) ([]*GraphUser, *h
 	return r.GraphUsers, resp, err
 }
 
+// ListPages walks every page of users in an org, calling fn with each page.
+// Pages are requested with continuation tokens.
+func (s *UsersService) ListPages(ctx context.Context, owner string, fn func([]*GraphUser, *Response) error) error {
+	return walkContinuation("", func(token string) (*Response, error) {
+		users, resp, err := s.list(ctx, owner, &usersListOptions{ContinuationToken: token})
+		if err != nil {
+			return nil, err
+		}
+		return resp, fn(users, resp)
+	})
+}
+
+// ListAll returns every user in an org, walking all pages of results.
+func (s *UsersService) ListAll(ctx context.Context, owner string) ([]*GraphUser, error) {
+	var all []*GraphUser
+	err := s.ListPages(ctx, owner, func(users []*GraphUser, _ *Response) error {
+		all = append(all, users...)
+		return nil
+	})
+	return all, err
+}
+
 // GraphDescriptorResult Returns user descriptor and links related to the
 // request
 type GraphDescriptorResult struct {
@@ -170,11 +206,12 @@ type GraphDescriptorResult struct {
 // GetDescriptors returns descriptors for one or more users based on filter
 // criteria
 // https://docs.microsoft.com/en-us/rest/api/azure/devops/graph/descriptors/get?view=azure-devops-rest-5.1
-func (s *UsersService) GetDescriptors(ctx context.Context, owner, storageKey string) (*GraphDescriptorResult, *http.Response, error) {
-	URL := fmt.Sprintf("%s%s/_apis/graph/descriptors/%s?api-version=5.1-preview.1",
+func (s *UsersService) GetDescriptors(ctx context.Context, owner, storageKey string) (*GraphDescriptorResult, *Response, error) {
+	URL := fmt.Sprintf("%s%s/_apis/graph/descriptors/%s?api-version=%s",
 		s.client.VsspsBaseURL.String(),
 		owner,
 		storageKey,
+		s.client.apiVersion("graph", "descriptors"),
 	)
 
 	request, err := s.client.NewRequest("GET", URL, nil)
@@ -180,3 +180,31 @@ func Test_UsersGetDescriptors(t *testing.T) {
 		t.Errorf("Users.GetDescriptors returned %+v, want %+v", got, want)
 	}
 }
+
+func Test_UsersListAll(t *testing.T) {
+	c, mux, _, teardown := setup()
+	defer teardown()
+	u, _ := url.Parse("")
+	c.VsspsBaseURL = *u
+
+	mux.HandleFunc("/o/_apis/graph/users", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		if r.URL.Query().Get("continuationToken") == "" {
+			w.Header().Set("X-MS-ContinuationToken", "next")
+			fmt.Fprint(w, `{"count": 1, "value": [{"principalName": "a@example.com"}]}`)
+			return
+		}
+		fmt.Fprint(w, `{"count": 1, "value": [{"principalName": "b@example.com"}]}`)
+	})
+
+	users, err := c.Users.ListAll(context.Background(), "o")
+	if err != nil {
+		t.Fatalf("returned error: %v", err)
+	}
+	if len(users) != 2 {
+		t.Fatalf("expected 2 users, got %d", len(users))
+	}
+	if got := users[1].GetPrincipalName(); got != "b@example.com" {
+		t.Errorf("users[1].PrincipalName = %s, want b@example.com", got)
+	}
+}
@@ -3,7 +3,6 @@ package azuredevops
 import (
 	"context"
 	"fmt"
-	"net/http"
 	"net/url"
 	"strconv"
 	"strings"
@@ -50,9 +49,11 @@ type WorkItemCommentList struct {
 // Valid Expand strings are:
 // all, mentions, none, reactions, renderedText, renderedTextOnly
 type WorkItemCommentListOptions struct {
-	IDs            []int  `url:"ids,omitempty"`
-	IncludeDeleted bool   `url:"includeDeleted,omitempty"`
-	Expand         string `url:"$expand,omitempty"`
+	IDs               []int  `url:"ids,omitempty"`
+	IncludeDeleted    bool   `url:"includeDeleted,omitempty"`
+	Expand            string `url:"$expand,omitempty"`
+	Top               int    `url:"$top,omitempty"`
+	ContinuationToken string `url:"continuationToken,omitempty"`
 }
 
 // WorkItemLink A link between two work items.
@@ -127,7 +128,7 @@ type WorkItemUpdate struct {
 
 // GetForIteration will get a list of work items based on an iteration name
 // utilising https://docs.microsoft.com/en-gb/rest/api/vsts/wit/work%20items/list
-func (s *WorkItemsService) GetForIteration(ctx context.Context, owner, project, team string, iteration Iteration) ([]*WorkItem, *http.Response, error) {
+func (s *WorkItemsService) GetForIteration(ctx context.Context, owner, project, team string, iteration Iteration) ([]*WorkItem, *Response, error) {
 	iterationWorkItems, resp, err := s.GetIdsForIteration(ctx, owner, project, team, iteration)
 	if err != nil {
 		return nil, resp, err
@@ -148,11 +149,12 @@ func (s *WorkItemsService) GetForIteration(ctx context.Context, owner, project,
 
 	// Now we want to pad out the fields for the work items
 	URL := fmt.Sprintf(
-		"%s/%s/_apis/wit/workitems?ids=%s&fields=%s&api-version=5.1-preview.1",
+		"%s/%s/_apis/wit/workitems?ids=%s&fields=%s&api-version=%s",
 		owner,
 		project,
 		strings.Join(workIds, ","),
 		strings.Join(fields, ","),
+		s.client.apiVersion("wit", "workItems"),
 	)
 
 	req, err := s.client.NewRequest("GET", URL, nil)
@@ -168,13 +170,14 @@ func (s *WorkItemsService) GetForIteration(ctx context.Context, owner, project,
 
 // GetIdsForIteration will return an array of ids for a given iteration
 // utilising https://docs.microsoft.com/en-gb/rest/api/vsts/work/iterations/get%20iteration%20work%20items
-func (s *WorkItemsService) GetIdsForIteration(ctx context.Context, owner, project, team string, iteration Iteration) (*IterationWorkItems, *http.Response, error) {
+func (s *WorkItemsService) GetIdsForIteration(ctx context.Context, owner, project, team string, iteration Iteration) (*IterationWorkItems, *Response, error) {
 	URL := fmt.Sprintf(
-		"%s/%s/%s/_apis/work/teamsettings/iterations/%s/workitems?api-version=5.1-preview.1",
+		"%s/%s/%s/_apis/work/teamsettings/iterations/%s/workitems?api-version=%s",
 		owner,
 		project,
 		url.PathEscape(team),
 		*iteration.ID,
+		s.client.apiVersion("work", "iterationWorkItems"),
 	)
 
 	req, err := s.client.NewRequest("GET", URL, nil)
@@ -191,12 +194,13 @@ func (s *WorkItemsService) GetIdsForIteration(ctx context.Context, owner, projec
 
 // ListComments Lists all comments on a work item
 // https://docs.microsoft.com/en-us/rest/api/azure/devops/wit/comments/get%20comment?view=azure-devops-rest-5.1#comment
-func (s *WorkItemsService) ListComments(ctx context.Context, owner, project string, workItemID int, opts *WorkItemCommentListOptions) (*WorkItemCommentList, *http.Response, error) {
+func (s *WorkItemsService) ListComments(ctx context.Context, owner, project string, workItemID int, opts *WorkItemCommentListOptions) (*WorkItemCommentList, *Response, error) {
 	URL := fmt.Sprintf(
-		"%s/%s/_apis/wit/workItems/%d/comments?api-version=5.1-preview.3",
+		"%s/%s/_apis/wit/workItems/%d/comments?api-version=%s",
 		owner,
 		project,
 		workItemID,
+		s.client.apiVersion("wit", "comments"),
 	)
 
 	URL, err := addOptions(URL, opts)
@@ -209,19 +213,52 @@ func (s *WorkItemsService) ListComments(ctx context.Context, owner, project stri
 	}
 
 	resp, err := s.client.Execute(ctx, req, r)
+	// The comments API returns its continuation token in the response body.
+	if resp != nil && resp.ContinuationToken == "" {
+		resp.ContinuationToken = r.GetContinuationToken()
+	}
 
 	return r, resp, err
 }
 
+// ListCommentsPages walks every page of comments on a work item, calling fn
+// with each page. Pages are requested with continuation tokens.
+func (s *WorkItemsService) ListCommentsPages(ctx context.Context, owner, project string, workItemID int, opts *WorkItemCommentListOptions, fn func([]*WorkItemComment, *Response) error) error {
+	o := WorkItemCommentListOptions{}
+	if opts != nil {
+		o = *opts
+	}
+	return walkContinuation(o.ContinuationToken, func(token string) (*Response, error) {
+		o.ContinuationToken = token
+		list, resp, err := s.ListComments(ctx, owner, project, workItemID, &o)
+		if err != nil {
+			return nil, err
+		}
+		return resp, fn(list.Comments, resp)
+	})
+}
+
+// ListAllComments returns every comment on a work item, walking all pages of
+// results.
+func (s *WorkItemsService) ListAllComments(ctx context.Context, owner, project string, workItemID int, opts *WorkItemCommentListOptions) ([]*WorkItemComment, error) {
+	var all []*WorkItemComment
+	err := s.ListCommentsPages(ctx, owner, project, workItemID, opts, func(comments []*WorkItemComment, _ *Response) error {
+		all = append(all, comments...)
+		return nil
+	})
+	return all, err
+}
+
 // GetComment Gets a work item comment
 // https://docs.microsoft.com/en-us/rest/api/azure/devops/wit/comments/get%20comments%20batch?view=azure-devops-rest-5.1#commentlist
-func (s *WorkItemsService) GetComment(ctx context.Context, owner, project string, workItemID, commentID int, opts *WorkItemCommentListOptions) (*WorkItemComment, *http.Response, error) {
+func (s *WorkItemsService) GetComment(ctx context.Context, owner, project string, workItemID, commentID int, opts *WorkItemCommentListOptions) (*WorkItemComment, *Response, error) {
 	URL := fmt.Sprintf(
-		"%s/%s/_apis/wit/workItems/%d/comments/%d?api-version=5.1-preview.3",
+		"%s/%s/_apis/wit/workItems/%d/comments/%d?api-version=%s",
 		owner,
 		project,
 		workItemID,
 		commentID,
+		s.client.apiVersion("wit", "comments"),
 	)
 
 	r := new(WorkItemComment)
@@ -238,12 +275,13 @@ func (s *WorkItemsService) GetComment(ctx context.Context, owner, project string
 
 // CreateComment Posts a comment to a work item
 // https://docs.microsoft.com/en-us/rest/api/azure/devops/wit/comments/add
-func (s *WorkItemsService) CreateComment(ctx context.Context, owner, project string, workItemID int, comment *WorkItemComment) (*WorkItemComment, *http.Response, error) {
+func (s *WorkItemsService) CreateComment(ctx context.Context, owner, project string, workItemID int, comment *WorkItemComment) (*WorkItemComment, *Response, error) {
 	URL := fmt.Sprintf(
-		"%s/%s/_apis/wit/workItems/%d/comments?api-version=5.1-preview.3",
+		"%s/%s/_apis/wit/workItems/%d/comments?api-version=%s",
 		owner,
 		project,
 		workItemID,
+		s.client.apiVersion("wit", "comments"),
 	)
 
 	r := new(WorkItemComment)